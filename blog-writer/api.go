@@ -0,0 +1,77 @@
+// Copyright (c) 2025 Asymmetric Effort, LLC. <scaldwell@asymmetric-effort.com>
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"blog-writer/internal/about"
+	"blog-writer/internal/api"
+	"blog-writer/internal/config"
+)
+
+// apiServer is the running local API and the info file advertising it.
+type apiServer struct {
+	srv      *api.Server
+	infoPath string
+}
+
+// apiMethods lists, per service name, the methods the local API exposes.
+// Lifecycle methods such as RepoService.Close stay private to the app.
+var apiMethods = map[string][]string{
+	"Repo":    {"Recent", "RecentRepos", "Open", "Current", "Templates", "CreateFromRemote"},
+	"Tree":    {"List"},
+	"Article": {"Load", "Save"},
+	"Git":     {"Fetch", "Pull", "Push", "Commit", "Branches", "Checkout", "Cancel"},
+	"Schema":  {"Validate", "ValidateSettings", "Migrate"},
+}
+
+// startAPI serves the apiMethods of svcs on the loopback interface when the
+// api section of the user config at cfgPath enables it, writing the URL and
+// a fresh token to api.json next to the config file. It returns nil when
+// the API is disabled or cannot be started.
+func startAPI(cfgPath string, svcs map[string]any) *apiServer {
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		log.Error("load config", "err", err)
+		return nil
+	}
+	if !cfg.API.Enabled {
+		return nil
+	}
+	srv, err := api.New(api.Options{Version: about.BuildInfo().Version, ErrorFormatter: formatError})
+	if err != nil {
+		log.Error("create api server", "err", err)
+		return nil
+	}
+	for name, svc := range svcs {
+		if err := srv.Register(name, svc, apiMethods[name]...); err != nil {
+			log.Error("register api service", "service", name, "err", err)
+			return nil
+		}
+	}
+	if err := srv.Listen(cfg.API.Port); err != nil {
+		log.Error("start api server", "err", err)
+		return nil
+	}
+	a := &apiServer{srv: srv, infoPath: filepath.Join(filepath.Dir(cfgPath), api.InfoFile)}
+	if err := srv.WriteInfo(a.infoPath); err != nil {
+		log.Error("write api info", "err", err)
+	}
+	return a
+}
+
+// Close stops the server and removes its info file so the token cannot be
+// reused.
+func (a *apiServer) Close() {
+	if a == nil {
+		return
+	}
+	if err := a.srv.Close(); err != nil {
+		log.Error("stop api server", "err", err)
+	}
+	if err := os.Remove(a.infoPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Error("remove api info", "err", err)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2025 Asymmetric Effort, LLC. <scaldwell@asymmetric-effort.com>
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"blog-writer/internal/api"
+	"blog-writer/internal/services"
+)
+
+// TestAPIMethods ensures every allowlisted method can be exposed and
+// lifecycle methods stay private.
+func TestAPIMethods(t *testing.T) {
+	svcs := map[string]any{
+		"Repo":    (*services.RepoService)(nil),
+		"Tree":    (*services.TreeService)(nil),
+		"Article": (*services.ArticleService)(nil),
+		"Git":     (*services.GitService)(nil),
+		"Schema":  (*services.SchemaService)(nil),
+	}
+	srv, err := api.New(api.Options{Token: "test"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	for name, methods := range apiMethods {
+		if err := srv.Register(name, svcs[name], methods...); err != nil {
+			t.Fatalf("Register %s: %v", name, err)
+		}
+	}
+	if slices.Contains(srv.Methods(), "Repo.Close") {
+		t.Fatal("Repo.Close must not be exposed")
+	}
+}
@@ -4,27 +4,83 @@ package main
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"sync"
 
 	"github.com/wailsapp/wails/v2/pkg/menu"
 	"github.com/wailsapp/wails/v2/pkg/runtime"
 
 	"blog-writer/internal/about"
+	"blog-writer/internal/config"
+	"blog-writer/internal/help"
+	"blog-writer/internal/instance"
+	"blog-writer/internal/services"
 )
 
+// newIssueURL is the GitHub page for filing issues.
+const newIssueURL = "https://github.com/asymmetric-effort/blog-writer/issues/new"
+
 // App holds application state.
 type App struct {
-	ctx context.Context
+	ctx     context.Context
+	cfgPath string
+
+	// inst is the single-instance listener, nil when it could not be started.
+	inst    *instance.Instance
+	mu      sync.Mutex
+	pending []instance.Target
+
+	// repos and git back the menu actions; either may be nil in tests.
+	repos *services.RepoService
+	git   *services.GitService
+	menu  menuState
 }
 
 // NewApp creates a new App application struct.
 func NewApp() *App {
-	return &App{}
+	p, _ := config.DefaultPath()
+	return &App{cfgPath: p}
 }
 
 // startup is called when the app starts. The context is saved
 // so we can call the runtime methods.
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	a.restoreWindow()
+	if a.inst != nil {
+		go a.inst.Serve(a.openForwarded)
+	}
+}
+
+// OpenTargets returns the repositories and articles named on the command
+// line. Each target is returned once; later launches are delivered as
+// "app:open" events instead.
+func (a *App) OpenTargets() []instance.Target {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	targets := a.pending
+	a.pending = nil
+	if targets == nil {
+		targets = []instance.Target{}
+	}
+	return targets
+}
+
+// openForwarded handles the arguments of a later launch by emitting them to
+// the frontend and bringing the window to the front.
+func (a *App) openForwarded(msg instance.Message) {
+	runtime.WindowUnminimise(a.ctx)
+	runtime.WindowShow(a.ctx)
+	if targets := instance.Targets(msg); len(targets) > 0 {
+		runtime.EventsEmit(a.ctx, "app:open", targets)
+	}
+}
+
+// beforeClose is called when the window is about to close. It remembers the
+// window geometry and never prevents closing.
+func (a *App) beforeClose(ctx context.Context) bool {
+	a.saveWindow()
+	return false
 }
 
 // Greet returns a greeting for the given name.
@@ -32,21 +88,57 @@ func (a *App) Greet(name string) string {
 	return fmt.Sprintf("Hello %s, It's show time!", name)
 }
 
-// ShowAbout displays the application's about information.
+// ShowAbout opens the About view with version details and third-party
+// notices.
 func (a *App) ShowAbout(data *menu.CallbackData) {
-	runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
-		Title:   "About",
-		Message: about.Info(),
-		Icon:    about.Icon(),
-	})
+	runtime.EventsEmit(a.ctx, EventAbout)
 }
 
-// ShowDocs opens the project documentation in the user's default browser.
+// AboutDetails returns the build, tool versions and third-party notices
+// shown in the About view.
+func (a *App) AboutDetails() about.Details {
+	return about.Collect()
+}
+
+// ShowDocs opens the bundled user guide in the help viewer, or on GitHub
+// when the user prefers the online documentation.
 func (a *App) ShowDocs(data *menu.CallbackData) {
-	runtime.BrowserOpenURL(a.ctx, "https://github.com/asymmetric-effort/blog-writer/blob/main/docs/user-guide.md")
+	if cfg, err := config.Load(a.cfgPath); err == nil && cfg.OnlineDocs {
+		a.OpenDocsOnline("user-guide")
+		return
+	}
+	runtime.EventsEmit(a.ctx, EventHelpOpen, "user-guide")
+}
+
+// OpenDocsOnline opens the online copy of the help page name in the user's
+// default browser.
+func (a *App) OpenDocsOnline(name string) {
+	runtime.BrowserOpenURL(a.ctx, help.OnlineURL+help.File(name))
 }
 
-// ReportBug opens the bug report page in the user's default browser.
+// ReportBug opens the bug report dialog, where a diagnostic bundle can be
+// created and attached to the issue.
 func (a *App) ReportBug(data *menu.CallbackData) {
-	runtime.BrowserOpenURL(a.ctx, "https://github.com/asymmetric-effort/blog-writer/issues/new/choose")
+	runtime.EventsEmit(a.ctx, EventReportBug)
+}
+
+// OpenIssue opens a new GitHub issue pre-filled with title and body in the
+// user's default browser.
+func (a *App) OpenIssue(title, body string) {
+	runtime.BrowserOpenURL(a.ctx, issueURL(title, body))
+}
+
+// issueURL returns the new-issue URL for title and body.
+func issueURL(title, body string) string {
+	q := url.Values{}
+	if title != "" {
+		q.Set("title", title)
+	}
+	if body != "" {
+		q.Set("body", body)
+	}
+	if len(q) == 0 {
+		return newIssueURL + "/choose"
+	}
+	return newIssueURL + "?" + q.Encode()
 }
@@ -0,0 +1,16 @@
+// Copyright (c) 2025 Asymmetric Effort, LLC. <scaldwell@asymmetric-effort.com>
+package main
+
+import "testing"
+
+// TestIssueURL ensures issue links are pre-filled and fall back to the
+// template chooser.
+func TestIssueURL(t *testing.T) {
+	if got := issueURL("", ""); got != newIssueURL+"/choose" {
+		t.Fatalf("unexpected url %q", got)
+	}
+	want := newIssueURL + "?body=%2A%2AEnvironment%2A%2A&title=Crash+on+save"
+	if got := issueURL("Crash on save", "**Environment**"); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 blog-writer authors
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"blog-writer/internal/fsutil"
+	"blog-writer/internal/textdiff"
+	"blog-writer/pkg/article"
+)
+
+// main is the entry point for the article migration CLI.
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report migrations without writing files")
+	diff := flag.Bool("diff", false, "print a unified diff for each migrated article")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: migrate [-dry-run] [-diff] <repo>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	args := flag.Args()
+	if len(args) != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(os.Stdout, article.Migrations, article.CurrentVersion, args[0], *dryRun, *diff); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run upgrades every article in repo to target using the steps in reg.
+func run(out io.Writer, reg *article.Registry, target, repo string, dryRun, diff bool) error {
+	paths, err := article.Scan(repo)
+	if err != nil {
+		return err
+	}
+	migrated := 0
+	for _, p := range paths {
+		before, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		after, applied, err := reg.MigrateJSON(before, target)
+		if err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+		if len(applied) == 0 {
+			continue
+		}
+		migrated++
+		rel, _ := filepath.Rel(repo, p)
+		fmt.Fprintf(out, "%s: %s -> %s\n", rel, applied[0].From, applied[len(applied)-1].To)
+		if diff {
+			fmt.Fprint(out, textdiff.Unified("a/"+filepath.ToSlash(rel), "b/"+filepath.ToSlash(rel), string(before), string(after)))
+		}
+		if dryRun {
+			continue
+		}
+		if err := fsutil.WriteFileAtomic(p, after, 0o644); err != nil {
+			return err
+		}
+	}
+	verb := "migrated"
+	if dryRun {
+		verb = "would migrate"
+	}
+	fmt.Fprintf(out, "%s %d of %d articles\n", verb, migrated, len(paths))
+	return nil
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025 blog-writer authors
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"blog-writer/pkg/article"
+)
+
+// oldArticle is a 1.0.0 article using b, which testRegistry renames.
+const oldArticle = `{"version":"1.0.0","metadata":{"title":"T","author":"A","description":"","publicationDate":"2025-01-01T00:00:00Z","updatedDate":"2025-01-01T00:00:00Z","keywords":[]},"document":[{"tag":"p","content":[{"tag":"b","content":"x"}]}]}`
+
+// testRegistry returns a registry whose 1.0.0 -> 1.1.0 step renames b to strong.
+func testRegistry(t *testing.T) *article.Registry {
+	t.Helper()
+	r := article.NewRegistry()
+	err := r.Register(article.Migration{From: "1.0.0", To: "1.1.0", Apply: func(a *article.Article) error {
+		article.Walk(a.Document, func(n *article.Node, _ int) bool {
+			if n.Tag == "b" {
+				n.Tag = "strong"
+			}
+			return true
+		})
+		return nil
+	}})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	return r
+}
+
+// writeArticle stores data as an article in a new repository and returns
+// the repository and the article path.
+func writeArticle(t *testing.T, data string) (string, string) {
+	t.Helper()
+	repo := t.TempDir()
+	p := filepath.Join(repo, "blog", "1755288225.json")
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return repo, p
+}
+
+// TestRunCurrentArticles ensures up-to-date repositories are reported without changes.
+func TestRunCurrentArticles(t *testing.T) {
+	repo, _ := writeArticle(t, oldArticle)
+	var out bytes.Buffer
+	if err := run(&out, article.Migrations, article.CurrentVersion, repo, true, true); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if !strings.Contains(out.String(), "would migrate 0 of 1 articles") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+// TestRunMigrates ensures a migration step rewrites the article on disk.
+func TestRunMigrates(t *testing.T) {
+	repo, p := writeArticle(t, oldArticle)
+	var out bytes.Buffer
+	if err := run(&out, testRegistry(t), "1.1.0", repo, false, false); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if !strings.Contains(out.String(), "1.0.0 -> 1.1.0") || !strings.Contains(out.String(), "migrated 1 of 1 articles") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+	b, err := os.ReadFile(p)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	a, err := article.Parse(b)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if a.Version != "1.1.0" || a.Document[0].Content.Nodes[0].Tag != "strong" {
+		t.Fatalf("article not migrated: %s", b)
+	}
+}
+
+// TestRunDryRunDiff ensures -dry-run leaves files unchanged and -diff prints
+// a unified diff of the migration.
+func TestRunDryRunDiff(t *testing.T) {
+	repo, p := writeArticle(t, oldArticle)
+	var out bytes.Buffer
+	if err := run(&out, testRegistry(t), "1.1.0", repo, true, true); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if b, _ := os.ReadFile(p); string(b) != oldArticle {
+		t.Fatalf("dry run modified the article: %s", b)
+	}
+	got := out.String()
+	for _, want := range []string{
+		"--- a/blog/1755288225.json\n", "+++ b/blog/1755288225.json\n", "@@ ",
+		"\n+  \"version\": \"1.1.0\",\n", "\"tag\": \"strong\"", "would migrate 1 of 1 articles",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in output:\n%s", want, got)
+		}
+	}
+}
+
+// TestRunInvalidArticle ensures unreadable articles abort the run.
+func TestRunInvalidArticle(t *testing.T) {
+	repo, _ := writeArticle(t, `{"version":"9.0.0"}`)
+	var out bytes.Buffer
+	if err := run(&out, article.Migrations, article.CurrentVersion, repo, false, false); err == nil {
+		t.Fatal("expected error for newer article")
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2025 blog-writer authors
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"blog-writer/internal/about"
+	"blog-writer/internal/fsutil"
+)
+
+// licenseFiles are the file names searched for a module's license text.
+var licenseFiles = []string{"LICENSE", "LICENSE.md", "LICENSE.txt", "LICENCE", "COPYING"}
+
+// main is the entry point for the third-party notice generator.
+func main() {
+	root := flag.String("root", "../..", "blog-writer module root holding go.mod and frontend/")
+	out := flag.String("o", "", "output file; standard output when empty")
+	modCache := flag.String("modcache", "", "Go module cache; defaults to `go env GOMODCACHE`")
+	flag.Parse()
+	if *modCache == "" {
+		b, err := exec.Command("go", "env", "GOMODCACHE").Output()
+		if err == nil {
+			*modCache = strings.TrimSpace(string(b))
+		}
+	}
+	notices, err := collect(*root, *modCache)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	b, err := json.MarshalIndent(notices, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	b = append(b, '\n')
+	if *out == "" {
+		os.Stdout.Write(b)
+		return
+	}
+	if err := fsutil.WriteFileAtomic(*out, b, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// collect lists the Go modules required by root/go.mod and the production
+// packages in root/frontend/package-lock.json, sorted by ecosystem and name.
+func collect(root, modCache string) ([]about.Notice, error) {
+	f, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	notices, err := goModules(f, modCache)
+	if err != nil {
+		return nil, err
+	}
+	lock, err := os.Open(filepath.Join(root, "frontend", "package-lock.json"))
+	if err != nil {
+		return nil, err
+	}
+	defer lock.Close()
+	npm, err := npmPackages(lock)
+	if err != nil {
+		return nil, err
+	}
+	notices = append(notices, npm...)
+	sort.Slice(notices, func(i, j int) bool {
+		if notices[i].Ecosystem != notices[j].Ecosystem {
+			return notices[i].Ecosystem < notices[j].Ecosystem
+		}
+		return notices[i].Name < notices[j].Name
+	})
+	return notices, nil
+}
+
+// goModules parses the require directives of a go.mod file. Licenses are
+// read from the module's license file in modCache.
+func goModules(r io.Reader, modCache string) ([]about.Notice, error) {
+	var notices []about.Notice
+	inBlock := false
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line, _, _ := strings.Cut(sc.Text(), "//")
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+			continue
+		case fields[0] == "require" && len(fields) > 1 && fields[1] == "(":
+			inBlock = true
+			continue
+		case inBlock && fields[0] == ")":
+			inBlock = false
+			continue
+		case fields[0] == "require":
+			fields = fields[1:]
+		case !inBlock:
+			continue
+		}
+		if len(fields) < 2 {
+			continue
+		}
+		license, text, err := goLicense(modCache, fields[0], fields[1])
+		if err != nil {
+			return nil, err
+		}
+		notices = append(notices, about.Notice{
+			Name:      fields[0],
+			Version:   fields[1],
+			License:   license,
+			Text:      text,
+			Ecosystem: "go",
+		})
+	}
+	return notices, sc.Err()
+}
+
+// goLicense returns the license identifier and text of module path@version
+// in modCache. It fails when the module is missing from the cache or has no
+// recognised license file, since the notices must carry every license text.
+func goLicense(modCache, path, version string) (license, text string, err error) {
+	if modCache == "" {
+		return "", "", fmt.Errorf("%s@%s: module cache unknown; pass -modcache", path, version)
+	}
+	dir := filepath.Join(modCache, filepath.FromSlash(escapePath(path))+"@"+version)
+	if _, err := os.Stat(dir); err != nil {
+		return "", "", fmt.Errorf("%s@%s: not in the module cache; run go mod download: %w", path, version, err)
+	}
+	for _, name := range licenseFiles {
+		if b, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+			if license = identify(string(b)); license == "unknown" {
+				return "", "", fmt.Errorf("%s@%s: unrecognised license in %s", path, version, name)
+			}
+			return license, string(b), nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return "", "", err
+		}
+	}
+	return "", "", fmt.Errorf("%s@%s: no license file in %s", path, version, dir)
+}
+
+// escapePath applies the module cache case encoding: upper-case letters
+// become "!" followed by the lower-case letter.
+func escapePath(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if unicode.IsUpper(r) {
+			b.WriteByte('!')
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// identify returns the SPDX identifier of a license text, or "unknown".
+func identify(text string) string {
+	t := strings.Join(strings.Fields(text), " ")
+	switch {
+	case strings.Contains(t, "Apache License") && strings.Contains(t, "Version 2.0"):
+		return "Apache-2.0"
+	case strings.Contains(t, "Mozilla Public License") && strings.Contains(t, "2.0"):
+		return "MPL-2.0"
+	case strings.Contains(t, "Permission is hereby granted, free of charge"):
+		return "MIT"
+	case strings.Contains(t, "Permission to use, copy, modify, and/or distribute"),
+		strings.Contains(t, "Permission to use, copy, modify, and distribute"):
+		return "ISC"
+	case strings.Contains(t, "Redistribution and use in source and binary forms"):
+		if strings.Contains(t, "Neither the name") || strings.Contains(t, "names of its contributors") {
+			return "BSD-3-Clause"
+		}
+		return "BSD-2-Clause"
+	}
+	return "unknown"
+}
+
+// lockfile is the subset of an npm v2/v3 package-lock.json read here.
+type lockfile struct {
+	Packages map[string]struct {
+		Version string `json:"version"`
+		License any    `json:"license"`
+		Dev     bool   `json:"dev"`
+	} `json:"packages"`
+}
+
+// npmPackages lists the non-development packages of a package-lock.json.
+func npmPackages(r io.Reader) ([]about.Notice, error) {
+	var lock lockfile
+	if err := json.NewDecoder(r).Decode(&lock); err != nil {
+		return nil, fmt.Errorf("parse package-lock.json: %w", err)
+	}
+	var notices []about.Notice
+	for key, p := range lock.Packages {
+		_, name, ok := strings.Cut(key, "node_modules/")
+		if !ok || p.Dev {
+			continue
+		}
+		if i := strings.LastIndex(name, "/node_modules/"); i >= 0 {
+			name = name[i+len("/node_modules/"):]
+		}
+		license := "unknown"
+		switch l := p.License.(type) {
+		case string:
+			license = l
+		case map[string]any:
+			if s, ok := l["type"].(string); ok {
+				license = s
+			}
+		}
+		notices = append(notices, about.Notice{Name: name, Version: p.Version, License: license, Ecosystem: "npm"})
+	}
+	return notices, nil
+}
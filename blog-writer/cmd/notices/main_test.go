@@ -0,0 +1,100 @@
+// Copyright (c) 2025 blog-writer authors
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGoModules ensures require directives are parsed and licenses are
+// read from the module cache.
+func TestGoModules(t *testing.T) {
+	cache := t.TempDir()
+	mit := "Permission is hereby granted, free of charge, to any person"
+	for _, dir := range []string{"github.com/!burnt!sushi/toml@v1.0.0", "example.com/single@v0.1.0", "example.com/other@v0.2.0"} {
+		writeLicense(t, filepath.Join(cache, dir), "LICENSE", mit)
+	}
+	gomod := "module x\n\ngo 1.24\n\nrequire example.com/single v0.1.0\n\nrequire (\n\tgithub.com/BurntSushi/toml v1.0.0\n\texample.com/other v0.2.0 // indirect\n)\n"
+	notices, err := goModules(strings.NewReader(gomod), cache)
+	if err != nil {
+		t.Fatalf("goModules: %v", err)
+	}
+	if len(notices) != 3 {
+		t.Fatalf("expected 3 modules, got %+v", notices)
+	}
+	if notices[1].Name != "github.com/BurntSushi/toml" || notices[1].License != "MIT" || notices[1].Text != mit {
+		t.Fatalf("unexpected notice %+v", notices[1])
+	}
+	if notices[0].Name != "example.com/single" || notices[2].Version != "v0.2.0" {
+		t.Fatalf("unexpected notices %+v", notices)
+	}
+}
+
+// TestGoModulesMissingLicense ensures modules without a recognised license
+// file fail the generator instead of being listed as unknown.
+func TestGoModulesMissingLicense(t *testing.T) {
+	cache := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(cache, "example.com", "bare@v1.0.0"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeLicense(t, filepath.Join(cache, "example.com", "custom@v1.0.0"), "COPYING", "All rights reserved.")
+	for _, mod := range []string{"example.com/absent v1.0.0", "example.com/bare v1.0.0", "example.com/custom v1.0.0"} {
+		if _, err := goModules(strings.NewReader("require "+mod+"\n"), cache); err == nil {
+			t.Errorf("%s: expected error", mod)
+		}
+	}
+	if _, err := goModules(strings.NewReader("require example.com/absent v1.0.0\n"), ""); err == nil {
+		t.Error("expected error without a module cache")
+	}
+}
+
+// writeLicense writes a license file named name into dir.
+func writeLicense(t *testing.T, dir, name, text string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestNpmPackages ensures development packages are skipped and nested
+// packages are named after their own directory.
+func TestNpmPackages(t *testing.T) {
+	lock := `{"lockfileVersion":3,"packages":{
+		"":{"name":"app"},
+		"node_modules/react":{"version":"18.2.0","license":"MIT"},
+		"node_modules/a/node_modules/@scope/b":{"version":"1.0.0","license":{"type":"ISC"}},
+		"node_modules/vitest":{"version":"1.0.0","license":"MIT","dev":true}}}`
+	notices, err := npmPackages(strings.NewReader(lock))
+	if err != nil {
+		t.Fatalf("npmPackages: %v", err)
+	}
+	got := map[string]string{}
+	for _, n := range notices {
+		got[n.Name] = n.License
+	}
+	if len(got) != 2 || got["react"] != "MIT" || got["@scope/b"] != "ISC" {
+		t.Fatalf("unexpected packages %v", got)
+	}
+}
+
+// TestIdentify ensures common license texts are recognised.
+func TestIdentify(t *testing.T) {
+	cases := map[string]string{
+		"Apache License\n  Version 2.0, January 2004":                                      "Apache-2.0",
+		"Redistribution and use in source and binary forms ... Neither the name of Google": "BSD-3-Clause",
+		"Redistribution and use in source and binary forms":                                "BSD-2-Clause",
+		"Permission to use, copy, modify, and/or distribute this software":                 "ISC",
+		"All rights reserved.": "unknown",
+	}
+	for text, want := range cases {
+		if got := identify(text); got != want {
+			t.Errorf("identify(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
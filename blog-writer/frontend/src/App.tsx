@@ -10,6 +10,14 @@ import Modal from './components/Modal';
 import MenuBar from './components/MenuBar';
 import StatusBar from './components/StatusBar';
 import FileTree from './components/FileTree';
+import CommitDialog from './components/CommitDialog';
+import ReplaceDialog from './components/ReplaceDialog';
+import { Save } from '../wailsjs/go/services/ArticleService';
+import { Effective } from '../wailsjs/go/services/SettingsService';
+import { Get as GetUIState } from '../wailsjs/go/services/UIStateService';
+import { article } from '../wailsjs/go/models';
+import { onMenuEvent } from './utils/menuEvents';
+import { errorMessage } from './utils/serviceError';
 import logo from './assets/images/logo-universal.png';
 
   /**
@@ -21,6 +29,9 @@ export default function App(): JSX.Element {
   const [showLogo, setShowLogo] = useState(true);
   const [repo, setRepo] = useState('');
   const [file, setFile] = useState('');
+  const [dialog, setDialog] = useState<'commit' | 'replace' | null>(null);
+  const [showSidebar, setShowSidebar] = useState(true);
+  const [revision, setRevision] = useState(0);
   const editorStyle: React.CSSProperties = { flex: 1, display: 'flex' };
 
   useEffect(() => {
@@ -28,6 +39,49 @@ export default function App(): JSX.Element {
     return () => clearTimeout(t);
   }, []);
 
+  useEffect(() => {
+    if (!(window as any).runtime) return;
+    GetUIState()
+      .then(st => { if (st.panels && 'sidebar' in st.panels) setShowSidebar(st.panels.sidebar); })
+      .catch(() => {});
+  }, []);
+
+  // Native menu actions that are not handled by the editor itself.
+  useEffect(() => {
+    const offs = [
+      onMenuEvent('menu:open-repo', () => setShowRepoWizard(true)),
+      onMenuEvent('menu:new-article', () => { if (repo) newArticle(repo); }),
+      onMenuEvent('menu:commit', () => { if (repo) setDialog('commit'); }),
+      onMenuEvent('menu:replace', () => { if (repo) setDialog('replace'); }),
+      onMenuEvent('menu:toggle-panel', t => { if (t.panel === 'sidebar') setShowSidebar(t.visible); })
+    ];
+    return () => offs.forEach(off => off());
+  }, [repo]);
+
+  /** newArticle saves an untitled article using the repository defaults and opens it. */
+  const newArticle = async (r: string) => {
+    try {
+      const { settings } = await Effective(r);
+      const now = new Date().toISOString().replace(/\.\d{3}Z$/, 'Z');
+      const doc = article.Article.createFrom({
+        version: '1.0.0',
+        metadata: {
+          title: 'Untitled',
+          author: settings.defaultAuthor,
+          description: '',
+          publicationDate: now,
+          updatedDate: now,
+          keywords: settings.defaultKeywords ?? []
+        },
+        document: []
+      });
+      const id = await Save(r, '', doc, false);
+      setFile(`blog/${id}.json`);
+    } catch (err) {
+      window.alert(errorMessage(err));
+    }
+  };
+
   const handleOpen = (p: string) => {
     setRepo(p);
     setFile('');
@@ -38,15 +92,25 @@ export default function App(): JSX.Element {
       <div id="App" className="app-window">
         <MenuBar />
         <div className="main-area" style={{ marginTop: 0 }}>
-          <FileTree repo={repo} onSelect={setFile} />
+          {showSidebar && <FileTree repo={repo} onSelect={setFile} />}
           <div className="editor-container" style={editorStyle}>
-            <Editor repo={repo} file={file} />
+            <Editor repo={repo} file={file} revision={revision} />
           </div>
         </div>
         <StatusBar repo={repo} file={file} wizardOpen={showRepoWizard} />
         <Modal open={showRepoWizard} title="Repository Wizard">
           {showLogo ? <img src={logo} alt="logo" /> : <RepoWizard onOpen={handleOpen} />}
         </Modal>
+        <Modal open={dialog === 'commit'} title="Commit">
+          <CommitDialog repo={repo} onClose={() => setDialog(null)} />
+        </Modal>
+        <Modal open={dialog === 'replace'} title="Replace">
+          <ReplaceDialog
+            repo={repo}
+            onReplaced={() => setRevision(n => n + 1)}
+            onClose={() => setDialog(null)}
+          />
+        </Modal>
       </div>
   );
 }
@@ -1,10 +1,13 @@
 // Copyright (c) 2025 Sam Caldwell
 // SPDX-License-Identifier: MIT
 
-import React from 'react';
+import React, { useEffect, useState } from 'react';
+import { AboutDetails } from '../../wailsjs/go/main/App';
+import { about } from '../../wailsjs/go/models';
 
 /**
- * About displays licensing and author information for Blog Writer.
+ * About displays version details, licensing and author information and the
+ * third-party notices for Blog Writer.
  */
 interface AboutProps {
   /** Callback to close the About dialog. */
@@ -12,9 +15,34 @@ interface AboutProps {
 }
 
 export default function About({ onClose }: AboutProps): JSX.Element {
+  const [details, setDetails] = useState<about.Details | null>(null);
+
+  useEffect(() => {
+    let active = true;
+    // Outside the desktop shell the bindings are missing; show the static text.
+    Promise.resolve()
+      .then(() => AboutDetails())
+      .then(d => { if (active) setDetails(d); })
+      .catch(() => undefined);
+    return () => { active = false; };
+  }, []);
+
   return (
     <div style={containerStyle}>
       <h1>Blog Writer</h1>
+      {details && (
+        <dl aria-label="Version information" style={versionStyle}>
+          <dt>Version</dt>
+          <dd>{details.version}</dd>
+          {details.commit && (<><dt>Commit</dt><dd>{details.commit.slice(0, 12)}{details.modified ? ' (modified)' : ''}</dd></>)}
+          {details.date && (<><dt>Built</dt><dd>{details.date}</dd></>)}
+          <dt>Go</dt>
+          <dd>{details.goVersion}</dd>
+          {details.wailsVersion && (<><dt>Wails</dt><dd>{details.wailsVersion}</dd></>)}
+          <dt>Git</dt>
+          <dd>{details.gitVersion || 'not found'}</dd>
+        </dl>
+      )}
       <p>This application is a static-content blog post authoring tool built by Sam Caldwell using Golang, Wails, ReactJS and TypeScript.  Please enjoy this tool and your freedom of expression.</p>
       <h1>MIT License</h1>
       <p>(c) 2025 Asymmetric Effort, LLC.  &lt;scaldwell@asymmetric-effort.com&gt;</p>
@@ -45,6 +73,31 @@ export default function About({ onClose }: AboutProps): JSX.Element {
       <h1>PostScript</h1>
       <p>Fuck Trump!</p>
       <p></p>
+      {details && details.notices.length > 0 && (
+        <>
+          <h1>Third-Party Notices</h1>
+          <table aria-label="Third-party notices">
+            <thead>
+              <tr><th>Package</th><th>Version</th><th>License</th></tr>
+            </thead>
+            <tbody>
+              {details.notices.map(n => (
+                <tr key={`${n.ecosystem}:${n.name}@${n.version}`}>
+                  <td>{n.name}</td><td>{n.version}</td>
+                  <td>
+                    {n.text ? (
+                      <details>
+                        <summary>{n.license}</summary>
+                        <pre style={preStyle}>{n.text}</pre>
+                      </details>
+                    ) : n.license}
+                  </td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        </>
+      )}
       <button type="button" onClick={onClose}>Close</button>
     </div>
   );
@@ -60,6 +113,13 @@ const containerStyle: React.CSSProperties = {
   width: '600px'
 };
 
+/** versionStyle lays the version details out as a two-column grid. */
+const versionStyle: React.CSSProperties = {
+  display: 'grid',
+  gridTemplateColumns: 'max-content 1fr',
+  columnGap: '1rem'
+};
+
 /** preStyle preserves formatting for license text. */
 const preStyle: React.CSSProperties = {
   whiteSpace: 'pre-wrap'
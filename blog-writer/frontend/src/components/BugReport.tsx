@@ -2,25 +2,46 @@
 // SPDX-License-Identifier: MIT
 
 import React, { useState } from 'react';
+import { Create as CreateDiagnostics } from '../../wailsjs/go/services/DiagnosticsService';
+import { errorMessage } from '../utils/serviceError';
 
 /**
- * BugReport collects information required to file a GitHub issue.
+ * BugReport collects information required to file a GitHub issue and can
+ * create a diagnostic bundle to attach to it.
  */
 interface BugReportProps {
   /** Callback to close the BugReport dialog. */
   onClose: () => void;
+  /** Current repository, included in the diagnostic bundle when set. */
+  repo?: string;
 }
 
-export default function BugReport({ onClose }: BugReportProps): JSX.Element {
+export default function BugReport({ onClose, repo = '' }: BugReportProps): JSX.Element {
   const [title, setTitle] = useState('');
   const [description, setDescription] = useState('');
   const [steps, setSteps] = useState('');
   const [expected, setExpected] = useState('');
   const [actual, setActual] = useState('');
+  const [redact, setRedact] = useState(true);
+  const [bundlePath, setBundlePath] = useState('');
+  const [environment, setEnvironment] = useState('');
+  const [includeEnvironment, setIncludeEnvironment] = useState(true);
+  const [bundleError, setBundleError] = useState('');
+
+  const createBundle = async () => {
+    setBundleError('');
+    try {
+      const bundle = await CreateDiagnostics({ repo, redact, webview: navigator.userAgent });
+      setBundlePath(bundle.path);
+      setEnvironment(bundle.issueBody);
+    } catch (err) {
+      setBundleError(errorMessage(err));
+    }
+  };
 
   const handleSubmit = (e: React.FormEvent) => {
     e.preventDefault();
-    const body = encodeURIComponent(`**Description**\n${description}\n\n**Steps to Reproduce**\n${steps}\n\n**Expected Behavior**\n${expected}\n\n**Actual Behavior**\n${actual}`);
+    const body = encodeURIComponent(`**Description**\n${description}\n\n**Steps to Reproduce**\n${steps}\n\n**Expected Behavior**\n${expected}\n\n**Actual Behavior**\n${actual}${includeEnvironment && environment ? `\n\n${environment}` : ''}`);
     const url = `https://github.com/asymmetric-effort/blog-writer/issues/new?title=${encodeURIComponent(title)}&body=${body}`;
     window.open(url, '_blank');
   };
@@ -47,6 +68,22 @@ export default function BugReport({ onClose }: BugReportProps): JSX.Element {
         Actual Behavior
         <textarea value={actual} onChange={e => setActual(e.target.value)} />
       </label>
+      <fieldset>
+        <legend>Diagnostics</legend>
+        <label>
+          <input type="checkbox" checked={redact} onChange={e => setRedact(e.target.checked)} />
+          Redact paths and emails
+        </label>
+        <button type="button" onClick={() => { void createBundle(); }}>Create diagnostic bundle</button>
+        {bundlePath && <p>Saved to <code>{bundlePath}</code>; attach it to the issue.</p>}
+        {environment && (
+          <label>
+            <input type="checkbox" checked={includeEnvironment} onChange={e => setIncludeEnvironment(e.target.checked)} />
+            Include environment in issue
+          </label>
+        )}
+        {bundleError && <p role="alert">{bundleError}</p>}
+      </fieldset>
       <div style={buttonRow}>
         <button type="submit">Submit</button>
         <button type="button" onClick={onClose}>Close</button>
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 blog-writer authors
+// SPDX-License-Identifier: MIT
+
+import React, { useState } from 'react';
+import { Commit } from '../../wailsjs/go/services/GitService';
+import { errorMessage } from '../utils/serviceError';
+
+/**
+ * CommitDialog asks for a message and commits the staged changes of the
+ * repository, as requested by Git → Commit… in the native menu.
+ */
+interface CommitDialogProps {
+  /** Repository to commit in. */
+  repo: string;
+  /** Callback to close the dialog. */
+  onClose: () => void;
+}
+
+export default function CommitDialog({ repo, onClose }: CommitDialogProps): JSX.Element {
+  const [message, setMessage] = useState('');
+  const [amend, setAmend] = useState(false);
+  const [error, setError] = useState('');
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setError('');
+    try {
+      await Commit(repo, message, amend);
+      onClose();
+    } catch (err) {
+      setError(errorMessage(err));
+    }
+  };
+
+  return (
+    <form onSubmit={e => { void handleSubmit(e); }} style={formStyle}>
+      <label>
+        Message
+        <textarea value={message} onChange={e => setMessage(e.target.value)} required />
+      </label>
+      <label>
+        <input type="checkbox" checked={amend} onChange={e => setAmend(e.target.checked)} />
+        Amend the last commit
+      </label>
+      {error && <p role="alert">{error}</p>}
+      <div style={buttonRow}>
+        <button type="submit">Commit</button>
+        <button type="button" onClick={onClose}>Cancel</button>
+      </div>
+    </form>
+  );
+}
+
+/** Form layout uses vertical stacking with spacing between fields. */
+const formStyle: React.CSSProperties = {
+  display: 'flex',
+  flexDirection: 'column',
+  gap: '0.5rem',
+  padding: '1rem',
+  width: '400px'
+};
+
+/** buttonRow displays action buttons horizontally spaced. */
+const buttonRow: React.CSSProperties = {
+  display: 'flex',
+  justifyContent: 'space-between'
+};
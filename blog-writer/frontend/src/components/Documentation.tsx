@@ -2,37 +2,51 @@
 // SPDX-License-Identifier: MIT
 
 import React from 'react';
-import userGuide from '../../../../docs/user-guide.md?raw';
+import { OpenDocsOnline } from '../../wailsjs/go/main/App';
 
 /**
- * Documentation displays user documentation in a scrollable view.
+ * Documentation shows the bundled help pages served by the backend under
+ * /help/, with a fallback to the online copy.
  */
 interface DocumentationProps {
   /** Callback to close the documentation dialog. */
   onClose: () => void;
+  /** Help page to open, e.g. "user-guide" or "user-guide#menus-and-shortcuts". */
+  page?: string;
 }
 
-export default function Documentation({ onClose }: DocumentationProps): JSX.Element {
+export default function Documentation({ onClose, page = 'user-guide' }: DocumentationProps): JSX.Element {
   return (
     <div style={containerStyle}>
-      <pre style={preStyle}>{userGuide}</pre>
-      <button type="button" onClick={onClose}>Close</button>
+      <iframe title="User Guide" src={`/help/${page}`} style={frameStyle} />
+      <div style={buttonRowStyle}>
+        <button type="button" onClick={() => { void OpenDocsOnline(page.split('#')[0]); }}>View online</button>
+        <button type="button" onClick={onClose}>Close</button>
+      </div>
     </div>
   );
 }
 
-/** containerStyle constrains height and enables vertical scrolling. */
+/** containerStyle sizes the help viewer inside the modal. */
 const containerStyle: React.CSSProperties = {
   display: 'flex',
   flexDirection: 'column',
-  maxHeight: '70vh',
-  overflowY: 'auto',
+  height: '75vh',
   padding: '1rem',
-  width: '600px'
+  width: '900px',
+  maxWidth: '90vw'
 };
 
-/** preStyle ensures whitespace is preserved for markdown text. */
-const preStyle: React.CSSProperties = {
-  whiteSpace: 'pre-wrap'
+/** frameStyle lets the help page fill the dialog. */
+const frameStyle: React.CSSProperties = {
+  flex: 1,
+  border: '1px solid #dde2ea'
 };
 
+/** buttonRowStyle aligns the dialog buttons. */
+const buttonRowStyle: React.CSSProperties = {
+  display: 'flex',
+  gap: '0.5rem',
+  justifyContent: 'flex-end',
+  marginTop: '0.5rem'
+};
@@ -1,9 +1,13 @@
 // Copyright (c) 2025 Sam Caldwell
 // SPDX-License-Identifier: MIT
 
-import React, { useEffect, useState } from 'react';
+import React, { useEffect, useRef, useState } from 'react';
 import ReactQuill from 'react-quill';
 import 'react-quill/dist/quill.snow.css';
+import { LoadDelta, SaveDelta } from '../../wailsjs/go/services/ArticleService';
+import { delta } from '../../wailsjs/go/models';
+import { onMenuEvent } from '../utils/menuEvents';
+import { errorMessage } from '../utils/serviceError';
 
 /**
  * Disable the default Quill toolbar since controls live in MenuBar.
@@ -18,22 +22,106 @@ interface EditorProps {
   repo: string;
   /** Currently selected file path. */
   file: string;
+  /** Changing revision reloads the article, e.g. after a repository-wide replace. */
+  revision?: number;
 }
 
-export const Editor: React.FC<EditorProps> = ({ repo, file }) => {
+/** articleID returns the ID of the article stored at file, or '' for other files. */
+export function articleID(file: string): string {
+  const m = /(?:^|[\\/])(\d+)\.json$/.exec(file);
+  return m ? m[1] : '';
+}
+
+export const Editor: React.FC<EditorProps> = ({ repo, file, revision = 0 }) => {
+  const quill = useRef<ReactQuill>(null);
+  const lastMatch = useRef(-1);
   const [value, setValue] = useState<string>('');
+  const [findOpen, setFindOpen] = useState(false);
+  const [query, setQuery] = useState('');
+  const [error, setError] = useState('');
+  const id = articleID(file);
+
   useEffect(() => {
     setValue('');
-  }, [repo, file]);
+    setError('');
+    lastMatch.current = -1;
+    if (!repo || !id) return undefined;
+    let cancelled = false;
+    LoadDelta(repo, id)
+      .then(d => { if (!cancelled) quill.current?.getEditor().setContents(d as any); })
+      .catch(err => { if (!cancelled) setError(errorMessage(err)); });
+    return () => { cancelled = true; };
+  }, [repo, id, revision]);
+
+  // File → Save and Save All write the open article back through the
+  // article service; the editor holds a single article, so both save it.
+  // Edit → Find opens the find bar.
+  useEffect(() => {
+    const save = () => {
+      const editor = quill.current?.getEditor();
+      if (!repo || !id || !editor) return;
+      SaveDelta(repo, id, delta.Delta.createFrom(editor.getContents()), false)
+        .then(() => setError(''))
+        .catch(err => setError(errorMessage(err)));
+    };
+    const offSave = onMenuEvent('menu:save', save);
+    const offSaveAll = onMenuEvent('menu:save-all', save);
+    const offFind = onMenuEvent('menu:find', () => setFindOpen(true));
+    return () => { offSave(); offSaveAll(); offFind(); };
+  }, [repo, id]);
+
+  /** findNext selects the next case-insensitive match of query, wrapping around. */
+  const findNext = () => {
+    const editor = quill.current?.getEditor();
+    if (!editor || !query) return;
+    const text = editor.getText().toLowerCase();
+    const q = query.toLowerCase();
+    let at = text.indexOf(q, lastMatch.current + 1);
+    if (at < 0) at = text.indexOf(q);
+    lastMatch.current = at;
+    if (at < 0) {
+      setError(`"${query}" not found`);
+      return;
+    }
+    setError('');
+    editor.setSelection(at, query.length);
+  };
+
   return (
-    <ReactQuill
-      theme="snow"
-      value={value}
-      onChange={setValue}
-      modules={quillModules}
-      style={{ height: '100%', width: '100%' }}
-    />
+    <div style={containerStyle}>
+      {findOpen && (
+        <form className="find-bar" style={findBarStyle} onSubmit={e => { e.preventDefault(); findNext(); }}>
+          <input aria-label="Find" value={query} onChange={e => setQuery(e.target.value)} autoFocus />
+          <button type="submit">Find Next</button>
+          <button type="button" onClick={() => setFindOpen(false)}>Close</button>
+        </form>
+      )}
+      {error && <p role="alert">{error}</p>}
+      <ReactQuill
+        ref={quill}
+        theme="snow"
+        value={value}
+        onChange={setValue}
+        modules={quillModules}
+        style={{ height: '100%', width: '100%' }}
+      />
+    </div>
   );
 };
 
+/** containerStyle stacks the find bar above the editor. */
+const containerStyle: React.CSSProperties = {
+  display: 'flex',
+  flexDirection: 'column',
+  flex: 1,
+  minHeight: 0
+};
+
+/** findBarStyle lays out the find controls in a row. */
+const findBarStyle: React.CSSProperties = {
+  display: 'flex',
+  gap: '0.5rem',
+  padding: '0.25rem'
+};
+
 export default Editor;
@@ -0,0 +1,137 @@
+// Copyright (c) 2025 Sam Caldwell
+// SPDX-License-Identifier: MIT
+
+import React, { useEffect, useState } from 'react';
+import { Levels, Recent, SetLevel } from '../../wailsjs/go/services/LogService';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+import { logging, services } from '../../wailsjs/go/models';
+import { errorMessage } from '../utils/serviceError';
+
+/** Number of entries loaded when the viewer opens and kept while streaming. */
+const maxEntries = 500;
+
+/** Log levels selectable per subsystem. */
+const levelNames = ['debug', 'info', 'warn', 'error'];
+
+/**
+ * LogViewer shows recent application log entries, follows new entries as
+ * they are logged and lets the user change the level of each subsystem.
+ */
+interface LogViewerProps {
+  /** Callback to close the log viewer. */
+  onClose: () => void;
+}
+
+export default function LogViewer({ onClose }: LogViewerProps): JSX.Element {
+  const [entries, setEntries] = useState<logging.Entry[]>([]);
+  const [levels, setLevels] = useState<services.LogLevel[]>([]);
+  const [subsystem, setSubsystem] = useState('');
+  const [error, setError] = useState('');
+
+  useEffect(() => {
+    let active = true;
+    Promise.all([Recent(maxEntries), Levels()])
+      .then(([recent, lvls]) => {
+        if (!active) return;
+        setEntries(recent ?? []);
+        setLevels(lvls ?? []);
+      })
+      .catch(err => setError(errorMessage(err)));
+    const off = (window as any).runtime
+      ? EventsOn('log:entry', (e: logging.Entry) => {
+        setEntries(prev => [...prev, e].slice(-maxEntries));
+      })
+      : undefined;
+    return () => {
+      active = false;
+      off?.();
+    };
+  }, []);
+
+  const changeLevel = async (sub: string, level: string) => {
+    try {
+      await SetLevel(sub, level);
+      setLevels(prev => prev.map(l => (l.subsystem === sub ? { ...l, level } : l)));
+    } catch (err) {
+      setError(errorMessage(err));
+    }
+  };
+
+  const shown = subsystem ? entries.filter(e => e.subsystem === subsystem) : entries;
+
+  return (
+    <div style={containerStyle}>
+      <div style={toolbarStyle}>
+        <label>
+          Subsystem
+          <select value={subsystem} onChange={e => setSubsystem(e.target.value)}>
+            <option value="">All</option>
+            {levels.map(l => <option key={l.subsystem} value={l.subsystem}>{l.subsystem}</option>)}
+          </select>
+        </label>
+        {levels.map(l => (
+          <label key={l.subsystem}>
+            {`${l.subsystem} level`}
+            <select value={l.level} onChange={e => { void changeLevel(l.subsystem, e.target.value); }}>
+              {levelNames.map(n => <option key={n} value={n}>{n}</option>)}
+            </select>
+          </label>
+        ))}
+      </div>
+      {error && <p role="alert">{error}</p>}
+      <div style={listStyle}>
+        <table aria-label="Log entries">
+          <tbody>
+            {shown.map((e, i) => (
+              <tr key={i}>
+                <td>{new Date(e.time).toLocaleTimeString()}</td>
+                <td>{e.level}</td>
+                <td>{e.subsystem}</td>
+                <td>
+                  {e.message}
+                  {e.attrs && Object.entries(e.attrs).map(([k, v]) => ` ${k}=${v}`).join('')}
+                </td>
+              </tr>
+            ))}
+          </tbody>
+        </table>
+      </div>
+      <div style={buttonRowStyle}>
+        <button type="button" onClick={onClose}>Close</button>
+      </div>
+    </div>
+  );
+}
+
+/** containerStyle sizes the viewer inside the modal. */
+const containerStyle: React.CSSProperties = {
+  display: 'flex',
+  flexDirection: 'column',
+  height: '70vh',
+  padding: '1rem',
+  width: '900px',
+  maxWidth: '90vw'
+};
+
+/** toolbarStyle lays out the filter and level selectors. */
+const toolbarStyle: React.CSSProperties = {
+  display: 'flex',
+  flexWrap: 'wrap',
+  gap: '0.75rem',
+  marginBottom: '0.5rem'
+};
+
+/** listStyle makes the entry list scroll independently. */
+const listStyle: React.CSSProperties = {
+  flex: 1,
+  overflowY: 'auto',
+  fontFamily: 'monospace',
+  fontSize: '0.85em'
+};
+
+/** buttonRowStyle aligns the dialog buttons. */
+const buttonRowStyle: React.CSSProperties = {
+  display: 'flex',
+  justifyContent: 'flex-end',
+  marginTop: '0.5rem'
+};
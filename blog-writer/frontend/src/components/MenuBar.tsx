@@ -4,12 +4,14 @@
  * MenuBar component renders a toolbar with common editor actions and a help menu.
  * Each action is represented by a button containing a unicode icon.
  */
-import React, { useState } from 'react';
+import React, { useEffect, useState } from 'react';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
 import './MenuBar.css';
 import Modal from './Modal';
 import About from './About';
 import Documentation from './Documentation';
 import BugReport from './BugReport';
+import LogViewer from './LogViewer';
 import HelpIcon from './icons/HelpIcon';
 
 /** Interface describing a toolbar action. */
@@ -43,14 +45,30 @@ export const actions: Action[] = [
  */
 export function MenuBar(): JSX.Element {
   const [helpOpen, setHelpOpen] = useState(false);
-  const [dialog, setDialog] = useState<'about' | 'docs' | 'bug' | null>(null);
+  const [dialog, setDialog] = useState<'about' | 'docs' | 'bug' | 'logs' | null>(null);
+  const [docsPage, setDocsPage] = useState('user-guide');
+
+  // The native menus open the About, docs, bug report and log dialogs
+  // through "help:about", "help:open", "help:report-bug" and
+  // "menu:show-logs" events.
+  useEffect(() => {
+    if (!(window as any).runtime) return undefined;
+    const offDocs = EventsOn('help:open', (page: string) => {
+      setDocsPage(page || 'user-guide');
+      setDialog('docs');
+    });
+    const offBug = EventsOn('help:report-bug', () => setDialog('bug'));
+    const offAbout = EventsOn('help:about', () => setDialog('about'));
+    const offLogs = EventsOn('menu:show-logs', () => setDialog('logs'));
+    return () => { offDocs(); offBug(); offAbout(); offLogs(); };
+  }, []);
 
   const style: React.CSSProperties = {
     borderBottom: '1px outset',
   };
 
   const openAbout = () => { setDialog('about'); setHelpOpen(false); };
-  const openDocs = () => { setDialog('docs'); setHelpOpen(false); };
+  const openDocs = () => { setDocsPage('user-guide'); setDialog('docs'); setHelpOpen(false); };
   const openBug = () => { setDialog('bug'); setHelpOpen(false); };
 
   return (
@@ -89,11 +107,14 @@ export function MenuBar(): JSX.Element {
         <About onClose={() => setDialog(null)} />
       </Modal>
       <Modal open={dialog === 'docs'} title="Blog Writer docs">
-        <Documentation onClose={() => setDialog(null)} />
+        <Documentation page={docsPage} onClose={() => setDialog(null)} />
       </Modal>
       <Modal open={dialog === 'bug'} title="Bug Reporting">
         <BugReport onClose={() => setDialog(null)} />
       </Modal>
+      <Modal open={dialog === 'logs'} title="Logs">
+        <LogViewer onClose={() => setDialog(null)} />
+      </Modal>
     </div>
   );
 }
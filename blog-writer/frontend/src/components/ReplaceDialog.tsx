@@ -0,0 +1,73 @@
+// Copyright (c) 2025 blog-writer authors
+// SPDX-License-Identifier: MIT
+
+import React, { useState } from 'react';
+import { Replace } from '../../wailsjs/go/services/ArticleService';
+import { errorMessage } from '../utils/serviceError';
+
+/**
+ * ReplaceDialog replaces text in every article of the repository, as
+ * requested by Edit → Replace in Repository… in the native menu.
+ */
+interface ReplaceDialogProps {
+  /** Repository whose articles are searched. */
+  repo: string;
+  /** Called after articles changed so open views can reload. */
+  onReplaced?: () => void;
+  /** Callback to close the dialog. */
+  onClose: () => void;
+}
+
+export default function ReplaceDialog({ repo, onReplaced, onClose }: ReplaceDialogProps): JSX.Element {
+  const [find, setFind] = useState('');
+  const [replacement, setReplacement] = useState('');
+  const [result, setResult] = useState('');
+  const [error, setError] = useState('');
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setError('');
+    setResult('');
+    try {
+      const changed = await Replace(repo, find, replacement, false);
+      setResult(`Replaced in ${changed} article${changed === 1 ? '' : 's'}.`);
+      if (changed > 0) onReplaced?.();
+    } catch (err) {
+      setError(errorMessage(err));
+    }
+  };
+
+  return (
+    <form onSubmit={e => { void handleSubmit(e); }} style={formStyle}>
+      <label>
+        Find
+        <input value={find} onChange={e => setFind(e.target.value)} required />
+      </label>
+      <label>
+        Replace with
+        <input value={replacement} onChange={e => setReplacement(e.target.value)} />
+      </label>
+      {result && <p role="status">{result}</p>}
+      {error && <p role="alert">{error}</p>}
+      <div style={buttonRow}>
+        <button type="submit">Replace All</button>
+        <button type="button" onClick={onClose}>Close</button>
+      </div>
+    </form>
+  );
+}
+
+/** Form layout uses vertical stacking with spacing between fields. */
+const formStyle: React.CSSProperties = {
+  display: 'flex',
+  flexDirection: 'column',
+  gap: '0.5rem',
+  padding: '1rem',
+  width: '400px'
+};
+
+/** buttonRow displays action buttons horizontally spaced. */
+const buttonRow: React.CSSProperties = {
+  display: 'flex',
+  justifyContent: 'space-between'
+};
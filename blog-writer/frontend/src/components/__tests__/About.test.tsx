@@ -4,13 +4,35 @@
  * About component should display application information and license text.
  */
 import { render, screen } from '@testing-library/react';
-import { describe, it, expect } from 'vitest';
+import { describe, it, expect, vi } from 'vitest';
 import About from '../About';
+import * as AppSvc from '../../../wailsjs/go/main/App';
+
+vi.mock('../../../wailsjs/go/main/App');
 
 describe('About', () => {
   it('renders license information', () => {
+    vi.mocked(AppSvc.AboutDetails).mockResolvedValue(undefined as any);
     render(<About onClose={() => undefined} />);
     expect(screen.getByText('Blog Writer')).toBeInTheDocument();
     expect(screen.getByText(/MIT License/)).toBeInTheDocument();
   });
+
+  it('shows version details and third-party notices', async () => {
+    vi.mocked(AppSvc.AboutDetails).mockResolvedValue({
+      version: 'v1.2.3',
+      goVersion: 'go1.24.0',
+      wailsVersion: 'v2.10.2',
+      gitVersion: 'git version 2.45.0',
+      notices: [
+        { name: 'react', version: '18.2.0', license: 'MIT', ecosystem: 'npm' },
+        { name: 'github.com/google/uuid', version: 'v1.6.0', license: 'BSD-3-Clause', text: 'Copyright (c) 2009,2014 Google Inc.', ecosystem: 'go' }
+      ]
+    } as any);
+    render(<About onClose={() => undefined} />);
+    expect(await screen.findByText('v1.2.3')).toBeInTheDocument();
+    expect(screen.getByText('git version 2.45.0')).toBeInTheDocument();
+    expect(screen.getByText('react')).toBeInTheDocument();
+    expect(screen.getByText('Copyright (c) 2009,2014 Google Inc.')).toBeInTheDocument();
+  });
 });
@@ -7,6 +7,9 @@ import { render, screen } from '@testing-library/react';
 import userEvent from '@testing-library/user-event';
 import { describe, it, expect, vi } from 'vitest';
 import BugReport from '../BugReport';
+import * as DiagSvc from '../../../wailsjs/go/services/DiagnosticsService';
+
+vi.mock('../../../wailsjs/go/services/DiagnosticsService');
 
 describe('BugReport', () => {
   it('renders required form fields', () => {
@@ -25,4 +28,18 @@ describe('BugReport', () => {
     expect(openSpy).toHaveBeenCalled();
     openSpy.mockRestore();
   });
+
+  it('creates a diagnostic bundle and adds the environment to the issue', async () => {
+    vi.mocked(DiagSvc.Create).mockResolvedValue({ path: '/tmp/diag.zip', files: [], issueBody: '**Environment**' } as any);
+    const openSpy = vi.spyOn(window, 'open').mockImplementation(() => null);
+    render(<BugReport onClose={() => undefined} repo="/r" />);
+    await userEvent.click(screen.getByText('Create diagnostic bundle'));
+    expect(DiagSvc.Create).toHaveBeenCalledWith(expect.objectContaining({ repo: '/r', redact: true }));
+    expect(await screen.findByText('/tmp/diag.zip')).toBeInTheDocument();
+    await userEvent.type(screen.getByLabelText('Title'), 'bug');
+    await userEvent.type(screen.getByLabelText('Description'), 'desc');
+    await userEvent.click(screen.getByText('Submit'));
+    expect(openSpy.mock.calls[0][0]).toContain(encodeURIComponent('**Environment**'));
+    openSpy.mockRestore();
+  });
 });
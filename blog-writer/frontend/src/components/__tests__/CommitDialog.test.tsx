@@ -0,0 +1,35 @@
+// Copyright (c) 2025 blog-writer authors
+// SPDX-License-Identifier: MIT
+/**
+ * Tests for CommitDialog ensure the message is committed and failures are
+ * shown without closing the dialog.
+ */
+import { render, screen } from '@testing-library/react';
+import userEvent from '@testing-library/user-event';
+import { describe, it, expect, vi } from 'vitest';
+import CommitDialog from '../CommitDialog';
+import * as GitSvc from '../../../wailsjs/go/services/GitService';
+
+vi.mock('../../../wailsjs/go/services/GitService');
+
+describe('CommitDialog', () => {
+  it('commits with the entered message and closes', async () => {
+    vi.mocked(GitSvc.Commit).mockResolvedValue(undefined as any);
+    const onClose = vi.fn();
+    render(<CommitDialog repo="/r" onClose={onClose} />);
+    await userEvent.type(screen.getByLabelText('Message'), 'docs: typo');
+    await userEvent.click(screen.getByText('Commit'));
+    expect(GitSvc.Commit).toHaveBeenCalledWith('/r', 'docs: typo', false);
+    expect(onClose).toHaveBeenCalled();
+  });
+
+  it('keeps the dialog open on failure', async () => {
+    vi.mocked(GitSvc.Commit).mockRejectedValue({ code: 'GIT_FAILED', message: 'nothing to commit' });
+    const onClose = vi.fn();
+    render(<CommitDialog repo="/r" onClose={onClose} />);
+    await userEvent.type(screen.getByLabelText('Message'), 'x');
+    await userEvent.click(screen.getByText('Commit'));
+    expect(await screen.findByRole('alert')).toHaveTextContent('nothing to commit');
+    expect(onClose).not.toHaveBeenCalled();
+  });
+});
@@ -1,15 +1,26 @@
 // Copyright (c) 2025 Sam Caldwell
 // SPDX-License-Identifier: MIT
 /**
- * Documentation component should display user guide text and close button.
+ * Documentation component should embed the bundled help pages and offer the
+ * online fallback.
  */
 import { render, screen } from '@testing-library/react';
-import { describe, it, expect } from 'vitest';
+import userEvent from '@testing-library/user-event';
+import { describe, it, expect, vi } from 'vitest';
 import Documentation from '../Documentation';
+import * as AppSvc from '../../../wailsjs/go/main/App';
+
+vi.mock('../../../wailsjs/go/main/App');
 
 describe('Documentation', () => {
-  it('renders user guide content', () => {
+  it('renders the user guide from the help server', () => {
     render(<Documentation onClose={() => undefined} />);
-    expect(screen.getByText(/User Guide/)).toBeInTheDocument();
+    expect(screen.getByTitle('User Guide')).toHaveAttribute('src', '/help/user-guide');
+  });
+
+  it('opens the online copy of the current page', async () => {
+    render(<Documentation onClose={() => undefined} page="developer-guide#backend-go" />);
+    await userEvent.click(screen.getByRole('button', { name: 'View online' }));
+    expect(AppSvc.OpenDocsOnline).toHaveBeenCalledWith('developer-guide');
   });
 });
@@ -4,7 +4,7 @@
 import { describe, it, expect } from 'vitest';
 import { render, screen } from '@testing-library/react';
 import '@testing-library/jest-dom/vitest';
-import Editor from '../Editor';
+import Editor, { articleID } from '../Editor';
 
 /**
  * Render tests for the WYSIWYG editor.
@@ -28,4 +28,11 @@ describe('Editor', () => {
     const toolbar = document.querySelector('.ql-toolbar');
     expect(toolbar).toBeNull();
   });
+
+  it('derives article IDs from article file paths only', () => {
+    expect(articleID('blog/1700000000.json')).toBe('1700000000');
+    expect(articleID('blog\\2024\\1700000001.json')).toBe('1700000001');
+    expect(articleID('README.md')).toBe('');
+    expect(articleID('draft1.json')).toBe('');
+  });
 });
@@ -0,0 +1,42 @@
+// Copyright (c) 2025 Sam Caldwell
+// SPDX-License-Identifier: MIT
+/**
+ * LogViewer should list recent entries, filter them by subsystem and change
+ * subsystem levels.
+ */
+import { render, screen } from '@testing-library/react';
+import userEvent from '@testing-library/user-event';
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import LogViewer from '../LogViewer';
+import * as LogSvc from '../../../wailsjs/go/services/LogService';
+
+vi.mock('../../../wailsjs/go/services/LogService');
+
+describe('LogViewer', () => {
+  beforeEach(() => {
+    vi.mocked(LogSvc.Recent).mockResolvedValue([
+      { time: '2025-01-01T00:00:00Z', level: 'WARN', subsystem: 'git', message: 'git failed', attrs: { stderr: 'fatal' } },
+      { time: '2025-01-01T00:00:01Z', level: 'INFO', subsystem: 'fs', message: 'saved' }
+    ] as any);
+    vi.mocked(LogSvc.Levels).mockResolvedValue([
+      { subsystem: 'git', level: 'info' },
+      { subsystem: 'fs', level: 'info' }
+    ] as any);
+    vi.mocked(LogSvc.SetLevel).mockResolvedValue();
+  });
+
+  it('lists and filters recent entries', async () => {
+    render(<LogViewer onClose={() => undefined} />);
+    expect(await screen.findByText(/git failed/)).toBeInTheDocument();
+    expect(screen.getByText(/stderr=fatal/)).toBeInTheDocument();
+    await userEvent.selectOptions(screen.getByLabelText('Subsystem'), 'fs');
+    expect(screen.queryByText(/git failed/)).not.toBeInTheDocument();
+    expect(screen.getByText('saved')).toBeInTheDocument();
+  });
+
+  it('changes a subsystem level', async () => {
+    render(<LogViewer onClose={() => undefined} />);
+    await userEvent.selectOptions(await screen.findByLabelText('git level'), 'debug');
+    expect(LogSvc.SetLevel).toHaveBeenCalledWith('git', 'debug');
+  });
+});
@@ -0,0 +1,35 @@
+// Copyright (c) 2025 blog-writer authors
+// SPDX-License-Identifier: MIT
+/**
+ * Tests for ReplaceDialog ensure repository-wide replacement is requested
+ * and the result is reported.
+ */
+import { render, screen } from '@testing-library/react';
+import userEvent from '@testing-library/user-event';
+import { describe, it, expect, vi } from 'vitest';
+import ReplaceDialog from '../ReplaceDialog';
+import * as ArticleSvc from '../../../wailsjs/go/services/ArticleService';
+
+vi.mock('../../../wailsjs/go/services/ArticleService');
+
+describe('ReplaceDialog', () => {
+  it('replaces text across the repository', async () => {
+    vi.mocked(ArticleSvc.Replace).mockResolvedValue(2);
+    const onReplaced = vi.fn();
+    render(<ReplaceDialog repo="/r" onReplaced={onReplaced} onClose={() => undefined} />);
+    await userEvent.type(screen.getByLabelText('Find'), 'old');
+    await userEvent.type(screen.getByLabelText('Replace with'), 'new');
+    await userEvent.click(screen.getByText('Replace All'));
+    expect(ArticleSvc.Replace).toHaveBeenCalledWith('/r', 'old', 'new', false);
+    expect(await screen.findByRole('status')).toHaveTextContent('Replaced in 2 articles.');
+    expect(onReplaced).toHaveBeenCalled();
+  });
+
+  it('shows service errors', async () => {
+    vi.mocked(ArticleSvc.Replace).mockRejectedValue({ code: 'INVALID_ARGUMENT', message: 'search text required' });
+    render(<ReplaceDialog repo="/r" onClose={() => undefined} />);
+    await userEvent.type(screen.getByLabelText('Find'), 'x');
+    await userEvent.click(screen.getByText('Replace All'));
+    expect(await screen.findByRole('alert')).toHaveTextContent('search text required');
+  });
+});
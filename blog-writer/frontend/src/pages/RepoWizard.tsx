@@ -3,6 +3,7 @@ import { useEffect, useState } from 'react';
 import { Create, Open, Recent } from '../../wailsjs/go/services/RepoService';
 import DirectoryPicker from '../components/DirectoryPicker';
 import Grid from '../components/Grid';
+import { hasErrorCode } from '../utils/serviceError';
 import './RepoWizard.css';
 
 interface RecentRepo {
@@ -40,7 +41,7 @@ export default function RepoWizard({ onOpen }: RepoWizardProps) {
       await Open(p);
       onOpen(p);
     } catch (err) {
-      if (String(err).includes('not a git repository')) {
+      if (hasErrorCode(err, 'NOT_GIT_REPO')) {
         if (window.confirm('Initialize directory as blog content repository?')) {
           await Create('', p);
           onOpen(p);
@@ -0,0 +1,34 @@
+// Copyright (c) 2025 blog-writer authors
+// SPDX-License-Identifier: MIT
+import { describe, it, expect, vi } from 'vitest';
+
+const listeners: Array<(e: unknown) => void> = [];
+vi.mock('../../../wailsjs/runtime/runtime', () => ({
+  EventsOn: (_name: string, cb: (e: unknown) => void) => {
+    listeners.push(cb);
+    return () => listeners.splice(listeners.indexOf(cb), 1);
+  },
+}));
+
+import { matchTopic, onAppEvent } from '../appEvents';
+
+describe('appEvents', () => {
+  it('matches exact, prefix and wildcard patterns', () => {
+    expect(matchTopic('*', 'article.saved')).toBe(true);
+    expect(matchTopic('article.saved', 'article.saved')).toBe(true);
+    expect(matchTopic('git.*', 'git.branch.changed')).toBe(true);
+    expect(matchTopic('git.*', 'article.saved')).toBe(false);
+  });
+
+  it('filters backend events by topic', () => {
+    (window as any).runtime = {};
+    const seen: string[] = [];
+    const off = onAppEvent('git.*', e => seen.push(e.topic));
+    listeners.forEach(l => l({ topic: 'article.saved', time: '', data: {} }));
+    listeners.forEach(l => l({ topic: 'git.status.changed', time: '', data: { op: 'pull' } }));
+    off();
+    delete (window as any).runtime;
+    expect(seen).toEqual(['git.status.changed']);
+    expect(listeners).toHaveLength(0);
+  });
+});
@@ -0,0 +1,21 @@
+// Copyright (c) 2025 blog-writer authors
+// SPDX-License-Identifier: MIT
+import { describe, it, expect } from 'vitest';
+import { errorMessage, hasErrorCode, isServiceError } from '../serviceError';
+
+describe('serviceError', () => {
+  const err = { code: 'NOT_GIT_REPO', message: 'not a git repository', retryable: false };
+
+  it('recognises service errors by code', () => {
+    expect(isServiceError(err)).toBe(true);
+    expect(hasErrorCode(err, 'NOT_GIT_REPO')).toBe(true);
+    expect(hasErrorCode(err, 'NETWORK')).toBe(false);
+    expect(isServiceError('not a git repository')).toBe(false);
+  });
+
+  it('formats any rejection value', () => {
+    expect(errorMessage(err)).toBe('not a git repository');
+    expect(errorMessage(new Error('boom'))).toBe('boom');
+    expect(errorMessage('plain')).toBe('plain');
+  });
+});
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 blog-writer authors
+// SPDX-License-Identifier: MIT
+
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+import { keymap, services } from '../../wailsjs/go/models';
+
+/** Name of the Wails event carrying every backend bus event. */
+export const APP_EVENT = 'app:event';
+
+/** Payload of an article.saved event. */
+export interface ArticleSavedData {
+  id: string;
+  path: string;
+  created: boolean;
+  committed: boolean;
+}
+
+/** Payload of git.status.changed and git.branch.changed events. */
+export interface GitChange {
+  op: string;
+  branch?: string;
+}
+
+/** Payload of a git.progress event. */
+export interface GitProgress {
+  repo: string;
+  op: string;
+  phase: string;
+  percent: number;
+  current: number;
+  total: number;
+}
+
+/** Payload of a repo.progress event. */
+export interface RepoProgress {
+  step: string;
+  message: string;
+}
+
+/** Payload of a repo.locked event. */
+export interface RepoLockWarning {
+  repo: string;
+  pid: number;
+  host: string;
+  since: string;
+  message: string;
+}
+
+/** Payload type of each topic published by internal/events. */
+export interface AppEventData {
+  'article.saved': ArticleSavedData;
+  'git.status.changed': GitChange;
+  'git.branch.changed': GitChange;
+  'git.progress': GitProgress;
+  'settings.updated': services.Settings;
+  'repo.opened': undefined;
+  'repo.progress': RepoProgress;
+  'repo.locked': RepoLockWarning;
+  'repo.changed': undefined;
+  'keybindings.changed': keymap.Binding[];
+  'workspace.active.changed': undefined;
+}
+
+/** Topic of a backend event. */
+export type Topic = keyof AppEventData;
+
+/** AppEvent is a single event published on the backend bus. */
+export type AppEvent<T extends Topic = Topic> = {
+  [K in T]: { topic: K; repo?: string; time: string; data: AppEventData[K] };
+}[T];
+
+/**
+ * matchTopic reports whether topic matches pattern, which is an exact topic,
+ * a prefix ending in ".*" such as "git.*", or "*" for every topic.
+ */
+export function matchTopic(pattern: string, topic: string): boolean {
+  if (pattern === '*' || pattern === topic) return true;
+  return pattern.endsWith('.*') && topic.startsWith(pattern.slice(0, -1));
+}
+
+/**
+ * onAppEvent calls handler for every backend event matching pattern and
+ * returns a function removing the listener. Outside the Wails runtime it
+ * does nothing.
+ */
+export function onAppEvent<T extends Topic>(pattern: T, handler: (e: AppEvent<T>) => void): () => void;
+export function onAppEvent(pattern: string, handler: (e: AppEvent) => void): () => void;
+export function onAppEvent(pattern: string, handler: (e: AppEvent) => void): () => void {
+  if (!(window as any).runtime) return () => {};
+  return EventsOn(APP_EVENT, (e: AppEvent) => {
+    if (matchTopic(pattern, e.topic)) handler(e);
+  });
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2025 blog-writer authors
+// SPDX-License-Identifier: MIT
+
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+
+/**
+ * Events emitted by the native application menu (menu.go). Every event
+ * carries the current repository path except menu:toggle-panel, which
+ * carries a PanelToggle.
+ */
+export type MenuEvent =
+  | 'menu:new-article'
+  | 'menu:open-repo'
+  | 'menu:save'
+  | 'menu:save-all'
+  | 'menu:find'
+  | 'menu:replace'
+  | 'menu:commit'
+  | 'menu:toggle-panel';
+
+/** Payload of a menu:toggle-panel event. */
+export interface PanelToggle {
+  panel: string;
+  visible: boolean;
+}
+
+/**
+ * onMenuEvent calls handler whenever the native menu emits name and returns
+ * a function removing the listener. Outside the Wails runtime it does
+ * nothing.
+ */
+export function onMenuEvent(name: 'menu:toggle-panel', handler: (t: PanelToggle) => void): () => void;
+export function onMenuEvent(name: MenuEvent, handler: (repo: string) => void): () => void;
+export function onMenuEvent(name: MenuEvent, handler: (data: any) => void): () => void {
+  if (!(window as any).runtime) return () => {};
+  return EventsOn(name, handler);
+}
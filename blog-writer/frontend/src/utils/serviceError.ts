@@ -0,0 +1,66 @@
+// Copyright (c) 2025 blog-writer authors
+// SPDX-License-Identifier: MIT
+
+/**
+ * Stable codes of errors returned by the Go services. See
+ * internal/services/errors.go for their meaning.
+ */
+export type ErrorCode =
+  | 'NOT_GIT_REPO'
+  | 'VALIDATION_FAILED'
+  | 'MERGE_CONFLICT'
+  | 'AUTH_REQUIRED'
+  | 'PATH_OUTSIDE_REPO'
+  | 'NETWORK'
+  | 'GIT_FAILED'
+  | 'CANCELLED'
+  | 'NOT_FOUND'
+  | 'PERMISSION_DENIED'
+  | 'INVALID_ARGUMENT'
+  | 'SETTINGS_TOO_NEW'
+  | 'NOT_IN_WORKSPACE'
+  | 'UNKNOWN_ACTION'
+  | 'KEYBINDING_CONFLICT'
+  | 'UNKNOWN_SUBSYSTEM'
+  | 'PLUGIN_FAILED'
+  | 'INTERNAL';
+
+/** ServiceError is the rejection value of a failed Wails binding call. */
+export interface ServiceError {
+  code: ErrorCode;
+  message: string;
+  details?: Record<string, unknown>;
+  retryable: boolean;
+}
+
+/**
+ * Report whether err is a ServiceError.
+ *
+ * @param err - Value a binding promise rejected with.
+ */
+export function isServiceError(err: unknown): err is ServiceError {
+  return typeof err === 'object' && err !== null &&
+    typeof (err as ServiceError).code === 'string' &&
+    typeof (err as ServiceError).message === 'string';
+}
+
+/**
+ * Report whether err is a ServiceError with the given code.
+ *
+ * @param err - Value a binding promise rejected with.
+ * @param code - Expected error code.
+ */
+export function hasErrorCode(err: unknown, code: ErrorCode): boolean {
+  return isServiceError(err) && err.code === code;
+}
+
+/**
+ * Return a human-readable message for any rejection value.
+ *
+ * @param err - Value a binding promise rejected with.
+ */
+export function errorMessage(err: unknown): string {
+  if (isServiceError(err)) return err.message;
+  if (err instanceof Error) return err.message;
+  return String(err);
+}
@@ -1,9 +1,21 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {about} from '../models';
+import {instance} from '../models';
 import {menu} from '../models';
 
+export function AboutDetails():Promise<about.Details>;
+
 export function Greet(arg1:string):Promise<string>;
 
+export function OpenDocsOnline(arg1:string):Promise<void>;
+
+export function OpenIssue(arg1:string,arg2:string):Promise<void>;
+
+export function OpenTargets():Promise<Array<instance.Target>>;
+
+export function RefreshMenu():Promise<void>;
+
 export function ReportBug(arg1:menu.CallbackData):Promise<void>;
 
 export function ShowAbout(arg1:menu.CallbackData):Promise<void>;
@@ -2,10 +2,30 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AboutDetails() {
+  return window['go']['main']['App']['AboutDetails']();
+}
+
 export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
 
+export function OpenDocsOnline(arg1) {
+  return window['go']['main']['App']['OpenDocsOnline'](arg1);
+}
+
+export function OpenIssue(arg1, arg2) {
+  return window['go']['main']['App']['OpenIssue'](arg1, arg2);
+}
+
+export function OpenTargets() {
+  return window['go']['main']['App']['OpenTargets']();
+}
+
+export function RefreshMenu() {
+  return window['go']['main']['App']['RefreshMenu']();
+}
+
 export function ReportBug(arg1) {
   return window['go']['main']['App']['ReportBug'](arg1);
 }
@@ -1,3 +1,359 @@
+export namespace about {
+	
+	export class Notice {
+	    name: string;
+	    version: string;
+	    license: string;
+	    text?: string;
+	    ecosystem: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Notice(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.version = source["version"];
+	        this.license = source["license"];
+	        this.text = source["text"];
+	        this.ecosystem = source["ecosystem"];
+	    }
+	}
+	export class Details {
+	    version: string;
+	    commit?: string;
+	    date?: string;
+	    modified?: boolean;
+	    goVersion: string;
+	    wailsVersion?: string;
+	    gitVersion: string;
+	    notices: Notice[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Details(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.commit = source["commit"];
+	        this.date = source["date"];
+	        this.modified = source["modified"];
+	        this.goVersion = source["goVersion"];
+	        this.wailsVersion = source["wailsVersion"];
+	        this.gitVersion = source["gitVersion"];
+	        this.notices = this.convertValues(source["notices"], Notice);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace article {
+	
+	export class Node {
+	    tag: string;
+	    content?: any;
+	    mode?: string;
+	    numbered?: boolean;
+	    label?: string;
+	    alt?: string;
+	    url?: string;
+	    lang?: string;
+	    start?: number;
+	    datetime?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Node(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.tag = source["tag"];
+	        this.content = source["content"];
+	        this.mode = source["mode"];
+	        this.numbered = source["numbered"];
+	        this.label = source["label"];
+	        this.alt = source["alt"];
+	        this.url = source["url"];
+	        this.lang = source["lang"];
+	        this.start = source["start"];
+	        this.datetime = source["datetime"];
+	    }
+	}
+	export class Metadata {
+	    title: string;
+	    author: string;
+	    description: string;
+	    publicationDate: string;
+	    updatedDate: string;
+	    keywords: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Metadata(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.title = source["title"];
+	        this.author = source["author"];
+	        this.description = source["description"];
+	        this.publicationDate = source["publicationDate"];
+	        this.updatedDate = source["updatedDate"];
+	        this.keywords = source["keywords"];
+	    }
+	}
+	export class Article {
+	    version: string;
+	    metadata: Metadata;
+	    document: Node[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Article(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.version = source["version"];
+	        this.metadata = this.convertValues(source["metadata"], Metadata);
+	        this.document = this.convertValues(source["document"], Node);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace config {
+	
+	export class ArticleTab {
+	    repo: string;
+	    id: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ArticleTab(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repo = source["repo"];
+	        this.id = source["id"];
+	    }
+	}
+	export class RecentRepo {
+	    path: string;
+	    name: string;
+	    // Go type: time
+	    lastOpened: any;
+	    pinned: boolean;
+	    remote: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecentRepo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.name = source["name"];
+	        this.lastOpened = this.convertValues(source["lastOpened"], null);
+	        this.pinned = source["pinned"];
+	        this.remote = source["remote"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class UIState {
+	    lastRepo: string;
+	    tabs: ArticleTab[];
+	    activeTab: number;
+	    splits: Record<string, number>;
+	    panels: Record<string, boolean>;
+	
+	    static createFrom(source: any = {}) {
+	        return new UIState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.lastRepo = source["lastRepo"];
+	        this.tabs = this.convertValues(source["tabs"], ArticleTab);
+	        this.activeTab = source["activeTab"];
+	        this.splits = source["splits"];
+	        this.panels = source["panels"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace delta {
+	
+	export class Op {
+	    insert?: any;
+	    retain?: number;
+	    delete?: number;
+	    attributes?: Record<string, any>;
+	
+	    static createFrom(source: any = {}) {
+	        return new Op(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.insert = source["insert"];
+	        this.retain = source["retain"];
+	        this.delete = source["delete"];
+	        this.attributes = source["attributes"];
+	    }
+	}
+	export class Delta {
+	    ops: Op[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Delta(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ops = this.convertValues(source["ops"], Op);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace instance {
+	
+	export class Target {
+	    repo: string;
+	    article?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Target(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repo = source["repo"];
+	        this.article = source["article"];
+	    }
+	}
+
+}
+
+export namespace keymap {
+	
+	export class Binding {
+	    id: string;
+	    label: string;
+	    default: string;
+	    shortcut: string;
+	    customized: boolean;
+	    conflicts: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Binding(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.label = source["label"];
+	        this.default = source["default"];
+	        this.shortcut = source["shortcut"];
+	        this.customized = source["customized"];
+	        this.conflicts = source["conflicts"];
+	    }
+	}
+
+}
+
 export namespace keys {
 	
 	export class Accelerator {
@@ -17,6 +373,32 @@ export namespace keys {
 
 }
 
+export namespace logging {
+	
+	export class Entry {
+	    // Go type: time
+	    time: any;
+	    level: string;
+	    subsystem: string;
+	    message: string;
+	    attrs?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new Entry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.time = source["time"];
+	        this.level = source["level"];
+	        this.subsystem = source["subsystem"];
+	        this.message = source["message"];
+	        this.attrs = source["attrs"];
+	    }
+	}
+
+}
+
 export namespace menu {
 	
 	export class Menu {
@@ -127,3 +509,463 @@ export namespace menu {
 
 }
 
+export namespace plugin {
+	
+	export class Capability {
+	    kind: string;
+	    id: string;
+	    name: string;
+	    description?: string;
+	    extension?: string;
+	    extensions?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Capability(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.extension = source["extension"];
+	        this.extensions = source["extensions"];
+	    }
+	}
+	export class CommandResult {
+	    message?: string;
+	    article?: article.Article;
+	
+	    static createFrom(source: any = {}) {
+	        return new CommandResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.message = source["message"];
+	        this.article = this.convertValues(source["article"], article.Article);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Diagnostic {
+	    path: string;
+	    severity: string;
+	    message: string;
+	    source?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Diagnostic(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.severity = source["severity"];
+	        this.message = source["message"];
+	        this.source = source["source"];
+	    }
+	}
+	export class Manifest {
+	    name: string;
+	    version: string;
+	    protocolVersion: number;
+	    capabilities: Capability[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Manifest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.version = source["version"];
+	        this.protocolVersion = source["protocolVersion"];
+	        this.capabilities = this.convertValues(source["capabilities"], Capability);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace plugins {
+	
+	export class Info {
+	    name: string;
+	    path: string;
+	    source: string;
+	    manifest?: plugin.Manifest;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Info(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.source = source["source"];
+	        this.manifest = this.convertValues(source["manifest"], plugin.Manifest);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+}
+
+export namespace services {
+	
+	export class Branch {
+	    name: string;
+	    current: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Branch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.current = source["current"];
+	    }
+	}
+	export class CreateOptions {
+	    remote: string;
+	    path: string;
+	    branch: string;
+	    push: boolean;
+	    template: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CreateOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.remote = source["remote"];
+	        this.path = source["path"];
+	        this.branch = source["branch"];
+	        this.push = source["push"];
+	        this.template = source["template"];
+	    }
+	}
+	export class Autosave {
+	    enabled: boolean;
+	    intervalMs: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Autosave(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.enabled = source["enabled"];
+	        this.intervalMs = source["intervalMs"];
+	    }
+	}
+	export class ImageVectorization {
+	    mode: string;
+	    threshold: number;
+	    colors: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImageVectorization(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.mode = source["mode"];
+	        this.threshold = source["threshold"];
+	        this.colors = source["colors"];
+	    }
+	}
+	export class Settings {
+	    schemaVersion: number;
+	    defaultAuthor: string;
+	    defaultKeywords: string[];
+	    defaultBranch: string;
+	    remote: string;
+	    preCommitValidate: boolean;
+	    maxEmbeddedSvgBytes: number;
+	    maxSvgNodeCount: number;
+	    imageVectorization: ImageVectorization;
+	    autosave: Autosave;
+	
+	    static createFrom(source: any = {}) {
+	        return new Settings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.schemaVersion = source["schemaVersion"];
+	        this.defaultAuthor = source["defaultAuthor"];
+	        this.defaultKeywords = source["defaultKeywords"];
+	        this.defaultBranch = source["defaultBranch"];
+	        this.remote = source["remote"];
+	        this.preCommitValidate = source["preCommitValidate"];
+	        this.maxEmbeddedSvgBytes = source["maxEmbeddedSvgBytes"];
+	        this.maxSvgNodeCount = source["maxSvgNodeCount"];
+	        this.imageVectorization = this.convertValues(source["imageVectorization"], ImageVectorization);
+	        this.autosave = this.convertValues(source["autosave"], Autosave);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class EffectiveSettings {
+	    settings: Settings;
+	    sources: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new EffectiveSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.settings = this.convertValues(source["settings"], Settings);
+	        this.sources = source["sources"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+
+	export class DiagnosticBundle {
+	    path: string;
+	    files: string[];
+	    issueBody: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiagnosticBundle(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.files = source["files"];
+	        this.issueBody = source["issueBody"];
+	    }
+	}
+	export class DiagnosticOptions {
+	    repo: string;
+	    redact: boolean;
+	    webview: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiagnosticOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repo = source["repo"];
+	        this.redact = source["redact"];
+	        this.webview = source["webview"];
+	    }
+	}
+	export class IndexEntry {
+	    repo: string;
+	    id: string;
+	    path: string;
+	    title: string;
+	    author: string;
+	    keywords: string[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new IndexEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repo = source["repo"];
+	        this.id = source["id"];
+	        this.path = source["path"];
+	        this.title = source["title"];
+	        this.author = source["author"];
+	        this.keywords = source["keywords"];
+	        this.error = source["error"];
+	    }
+	}
+	export class LogLevel {
+	    subsystem: string;
+	    level: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LogLevel(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.subsystem = source["subsystem"];
+	        this.level = source["level"];
+	    }
+	}
+	export class SearchHit {
+	    repo: string;
+	    id: string;
+	    path: string;
+	    title: string;
+	    author: string;
+	    keywords: string[];
+	    error?: string;
+	    snippet: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SearchHit(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repo = source["repo"];
+	        this.id = source["id"];
+	        this.path = source["path"];
+	        this.title = source["title"];
+	        this.author = source["author"];
+	        this.keywords = source["keywords"];
+	        this.error = source["error"];
+	        this.snippet = source["snippet"];
+	    }
+	}
+	export class ValidationResult {
+	    repo: string;
+	    id: string;
+	    path: string;
+	    valid: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ValidationResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.repo = source["repo"];
+	        this.id = source["id"];
+	        this.path = source["path"];
+	        this.valid = source["valid"];
+	        this.error = source["error"];
+	    }
+	}
+	export class WorkspaceRepo {
+	    path: string;
+	    name: string;
+	    active: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkspaceRepo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.name = source["name"];
+	        this.active = source["active"];
+	    }
+	}
+
+}
+
+export namespace templates {
+	
+	export class Info {
+	    name: string;
+	    description: string;
+	    builtin: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Info(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.builtin = source["builtin"];
+	    }
+	}
+
+}
+
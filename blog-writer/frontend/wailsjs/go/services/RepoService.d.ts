@@ -1,8 +1,27 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
+import {services} from '../models';
+import {config} from '../models';
+import {templates} from '../models';
+
+export function Close():Promise<void>;
 
 export function Create(arg1:string,arg2:string):Promise<void>;
 
+export function CreateFromRemote(arg1:services.CreateOptions):Promise<void>;
+
+export function Current():Promise<string>;
+
+export function Forget(arg1:string):Promise<void>;
+
 export function Open(arg1:string):Promise<void>;
 
+export function Pin(arg1:string,arg2:boolean):Promise<void>;
+
 export function Recent():Promise<Array<string>>;
+
+export function RecentRepos():Promise<Array<config.RecentRepo>>;
+
+export function SetRecentLimit(arg1:number):Promise<void>;
+
+export function Templates():Promise<Array<templates.Info>>;
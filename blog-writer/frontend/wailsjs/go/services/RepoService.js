@@ -2,14 +2,46 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function Close() {
+  return window['go']['services']['RepoService']['Close']();
+}
+
 export function Create(arg1, arg2) {
   return window['go']['services']['RepoService']['Create'](arg1, arg2);
 }
 
+export function CreateFromRemote(arg1) {
+  return window['go']['services']['RepoService']['CreateFromRemote'](arg1);
+}
+
+export function Current() {
+  return window['go']['services']['RepoService']['Current']();
+}
+
+export function Forget(arg1) {
+  return window['go']['services']['RepoService']['Forget'](arg1);
+}
+
 export function Open(arg1) {
   return window['go']['services']['RepoService']['Open'](arg1);
 }
 
+export function Pin(arg1, arg2) {
+  return window['go']['services']['RepoService']['Pin'](arg1, arg2);
+}
+
 export function Recent() {
   return window['go']['services']['RepoService']['Recent']();
 }
+
+export function RecentRepos() {
+  return window['go']['services']['RepoService']['RecentRepos']();
+}
+
+export function SetRecentLimit(arg1) {
+  return window['go']['services']['RepoService']['SetRecentLimit'](arg1);
+}
+
+export function Templates() {
+  return window['go']['services']['RepoService']['Templates']();
+}
@@ -54,3 +54,36 @@ func TestIcon(t *testing.T) {
 		t.Errorf("icon bytes do not match test fixture")
 	}
 }
+
+// TestBuildInfoLinkValues verifies link-time values override the toolchain
+// build details.
+func TestBuildInfoLinkValues(t *testing.T) {
+	defer func(v, c, d string) { Version, Commit, Date = v, c, d }(Version, Commit, Date)
+	Version, Commit, Date = "v1.2.3", "0123456789abcdef", "2025-01-02"
+	b := BuildInfo()
+	if b.Version != "v1.2.3" || b.Commit != "0123456789abcdef" || b.Date != "2025-01-02" {
+		t.Fatalf("unexpected build info %+v", b)
+	}
+	if got := b.Summary(); got != "v1.2.3 (0123456, 2025-01-02)" {
+		t.Fatalf("unexpected summary %q", got)
+	}
+	if b.GoVersion == "" {
+		t.Fatal("expected go version")
+	}
+}
+
+// TestNotices verifies the embedded third-party notices parse.
+func TestNotices(t *testing.T) {
+	notices := Notices()
+	if len(notices) == 0 {
+		t.Fatal("expected notices")
+	}
+	for _, n := range notices {
+		if n.Name == "" || n.License == "" || (n.Ecosystem != "go" && n.Ecosystem != "npm") {
+			t.Fatalf("incomplete notice %+v", n)
+		}
+		if n.Ecosystem == "go" && (n.License == "unknown" || n.Text == "") {
+			t.Fatalf("go module %s lacks its license", n.Name)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2025 Asymmetric Effort, LLC. <scaldwell@asymmetric-effort.com>
+package about
+
+import (
+	"os/exec"
+	"runtime/debug"
+	"strings"
+)
+
+// Build metadata injected at link time, e.g.
+//
+//	go build -ldflags "-X blog-writer/internal/about.Version=v1.2.3"
+//
+// Empty values fall back to the VCS details recorded by the Go toolchain.
+var (
+	Version string
+	Commit  string
+	Date    string
+)
+
+// wailsModule is the module path used to look up the Wails version.
+const wailsModule = "github.com/wailsapp/wails/v2"
+
+// Build describes the running binary.
+type Build struct {
+	Version      string `json:"version"`
+	Commit       string `json:"commit,omitempty"`
+	Date         string `json:"date,omitempty"`
+	Modified     bool   `json:"modified,omitempty"`
+	GoVersion    string `json:"goVersion"`
+	WailsVersion string `json:"wailsVersion,omitempty"`
+}
+
+// Details is everything shown in the About view.
+type Details struct {
+	Build
+	// GitVersion is the output of "git --version", empty when git is missing.
+	GitVersion string   `json:"gitVersion"`
+	Notices    []Notice `json:"notices"`
+}
+
+// BuildInfo returns the version of the running binary. Link-time values
+// take precedence over the VCS details embedded by the Go toolchain.
+func BuildInfo() Build {
+	b := Build{Version: "(devel)"}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		b.GoVersion = info.GoVersion
+		if v := info.Main.Version; v != "" {
+			b.Version = v
+		}
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				b.Commit = s.Value
+			case "vcs.time":
+				b.Date = s.Value
+			case "vcs.modified":
+				b.Modified = s.Value == "true"
+			}
+		}
+		for _, dep := range info.Deps {
+			if dep.Path == wailsModule {
+				b.WailsVersion = dep.Version
+			}
+		}
+	}
+	if Version != "" {
+		b.Version = Version
+	}
+	if Commit != "" {
+		b.Commit, b.Modified = Commit, false
+	}
+	if Date != "" {
+		b.Date = Date
+	}
+	return b
+}
+
+// GitVersion returns the version reported by the git CLI, or "" when git
+// cannot be run.
+func GitVersion() string {
+	out, err := exec.Command("git", "--version").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// Summary returns a one-line description of the build, e.g.
+// "v1.2.3 (abc1234, 2025-01-02)".
+func (b Build) Summary() string {
+	var extra []string
+	if b.Commit != "" {
+		c := b.Commit
+		if len(c) > 7 {
+			c = c[:7]
+		}
+		if b.Modified {
+			c += "-dirty"
+		}
+		extra = append(extra, c)
+	}
+	if b.Date != "" {
+		extra = append(extra, b.Date)
+	}
+	if len(extra) == 0 {
+		return b.Version
+	}
+	return b.Version + " (" + strings.Join(extra, ", ") + ")"
+}
+
+// Collect gathers the About view details.
+func Collect() Details {
+	return Details{Build: BuildInfo(), GitVersion: GitVersion(), Notices: Notices()}
+}
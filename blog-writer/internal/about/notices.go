@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Asymmetric Effort, LLC. <scaldwell@asymmetric-effort.com>
+package about
+
+import (
+	_ "embed"
+	"encoding/json"
+)
+
+//go:generate go run ../../cmd/notices -o notices.json
+
+// noticesJSON is the third-party dependency list written by cmd/notices.
+//
+//go:embed notices.json
+var noticesJSON []byte
+
+// Notice identifies a third-party dependency and its license.
+type Notice struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	License string `json:"license"`
+	// Text is the full license text, which licenses such as MIT and BSD
+	// require in redistributions. It is set for Go modules.
+	Text string `json:"text,omitempty"`
+	// Ecosystem is "go" for Go modules and "npm" for frontend packages.
+	Ecosystem string `json:"ecosystem"`
+}
+
+// Notices returns the third-party dependencies shipped with the app.
+func Notices() []Notice {
+	var n []Notice
+	if err := json.Unmarshal(noticesJSON, &n); err != nil {
+		return []Notice{}
+	}
+	return n
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2025 blog-writer authors
+
+// Package api serves bound services to local scripts and editor
+// integrations over HTTP. Every exported method is callable as JSON-RPC 2.0
+// on /rpc and as POST /v1/<Service>/<Method>; /openapi.json describes them.
+// The server listens on the loopback interface only and every request must
+// carry the bearer token written to the info file.
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"blog-writer/internal/fsutil"
+	"blog-writer/internal/logging"
+)
+
+// InfoFile is the name of the file, in the user config directory, holding
+// the running server's URL and token.
+const InfoFile = "api.json"
+
+// maxBody bounds the size of a request body.
+const maxBody = 16 << 20
+
+// log reports API requests.
+var log = logging.For(logging.API)
+
+// Info tells clients where the server listens and how to authenticate.
+type Info struct {
+	URL   string `json:"url"`
+	Token string `json:"token"`
+	PID   int    `json:"pid"`
+}
+
+// Options configures a Server.
+type Options struct {
+	// Token is the bearer token clients must send. It is generated when
+	// empty.
+	Token string
+	// Version is reported in the OpenAPI document.
+	Version string
+	// ErrorFormatter converts method errors into the JSON sent to clients.
+	// By default only the message is sent.
+	ErrorFormatter func(error) any
+}
+
+// Server exposes registered services over HTTP.
+type Server struct {
+	token   string
+	version string
+	format  func(error) any
+	methods registry
+	ln      net.Listener
+	srv     *http.Server
+}
+
+// New constructs a Server with no services registered.
+func New(opts Options) (*Server, error) {
+	if opts.Token == "" {
+		t, err := NewToken()
+		if err != nil {
+			return nil, err
+		}
+		opts.Token = t
+	}
+	if opts.ErrorFormatter == nil {
+		opts.ErrorFormatter = func(err error) any { return map[string]string{"message": err.Error()} }
+	}
+	return &Server{token: opts.Token, version: opts.Version, format: opts.ErrorFormatter}, nil
+}
+
+// NewToken returns 32 random bytes, hex encoded.
+func NewToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Register exposes the listed methods of svc under name, so that svc.Open
+// becomes "<name>.Open". Methods must be listed explicitly so that new or
+// lifecycle methods such as Close are never reachable by accident.
+func (s *Server) Register(name string, svc any, methods ...string) error {
+	return s.methods.register(name, svc, methods)
+}
+
+// Methods returns the API names of the registered methods in order.
+func (s *Server) Methods() []string {
+	return s.methods.names()
+}
+
+// Token returns the bearer token clients must send.
+func (s *Server) Token() string {
+	return s.token
+}
+
+// Listen starts serving on 127.0.0.1 at port, or a free port when port is
+// zero.
+func (s *Server) Listen(port int) error {
+	ln, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		return err
+	}
+	s.ln = ln
+	s.srv = &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("serve api", "err", err)
+		}
+	}()
+	log.Info("api listening", "url", s.URL())
+	return nil
+}
+
+// URL returns the server's base URL, or an empty string before Listen.
+func (s *Server) URL() string {
+	if s.ln == nil {
+		return ""
+	}
+	return "http://" + s.ln.Addr().String()
+}
+
+// Close stops the server, waiting briefly for running calls.
+func (s *Server) Close() error {
+	if s.srv == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return s.srv.Shutdown(ctx)
+}
+
+// WriteInfo writes the server's Info to path, readable by the user only.
+func (s *Server) WriteInfo(path string) error {
+	b, err := json.MarshalIndent(Info{URL: s.URL(), Token: s.token, PID: os.Getpid()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(path, b, 0o600)
+}
+
+// Handler returns the HTTP handler serving the API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.OpenAPI())
+	})
+	mux.HandleFunc("POST /rpc", s.serveRPC)
+	mux.HandleFunc("POST /v1/{service}/{method}", s.serveCall)
+	return s.guard(mux)
+}
+
+// guard rejects requests that are not from a local, non-browser client
+// holding the token. Checking Host defeats DNS rebinding and refusing an
+// Origin header keeps web pages from calling the API.
+func (s *Server) guard(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !loopbackHost(r.Host) || r.Header.Get("Origin") != "" {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxBody)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// loopbackHost reports whether the Host header names the loopback interface.
+func loopbackHost(host string) bool {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(strings.Trim(host, "[]"))
+	return ip != nil && ip.IsLoopback()
+}
+
+// serveCall handles POST /v1/{service}/{method}; the body is a JSON array
+// of arguments.
+func (s *Server) serveCall(w http.ResponseWriter, r *http.Request) {
+	m, ok := s.methods.methods[r.PathValue("service")+"."+r.PathValue("method")]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "unknown method"})
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+	result, err := s.invoke(m, body)
+	switch {
+	case errors.Is(err, errInvalidParams):
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+	case err != nil:
+		writeJSON(w, http.StatusUnprocessableEntity, s.format(err))
+	default:
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+// invoke calls m with params, logging the outcome.
+func (s *Server) invoke(m *method, params json.RawMessage) (any, error) {
+	start := time.Now()
+	result, err := m.call(params)
+	if err != nil {
+		log.Warn("api call failed", "method", m.fullName(), "err", err)
+	} else {
+		log.Debug("api call", "method", m.fullName(), "duration", time.Since(start))
+	}
+	return result, err
+}
+
+// writeJSON writes v as a JSON response with status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Warn("write api response", "err", err)
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2025 blog-writer authors
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// note is a payload type used by testService.
+type note struct {
+	Title string   `json:"title"`
+	Tags  []string `json:"tags,omitempty"`
+	Next  *note    `json:"next,omitempty"`
+}
+
+// testService exercises the supported method shapes.
+type testService struct{ last string }
+
+// Echo returns its argument.
+func (s *testService) Echo(n note) note { return n }
+
+// Set stores v.
+func (s *testService) Set(v string) { s.last = v }
+
+// Fail always fails.
+func (s *testService) Fail() (string, error) { return "", errors.New("boom") }
+
+// Panic always panics.
+func (s *testService) Panic() error { panic("oops") }
+
+// Watch takes a callback and cannot be exposed.
+func (s *testService) Watch(func()) {}
+
+// newTestServer returns a Server exposing a testService as "Test".
+func newTestServer(t *testing.T) (*Server, *testService) {
+	t.Helper()
+	s, err := New(Options{Token: "secret"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	svc := &testService{}
+	if err := s.Register("Test", svc, "Echo", "Fail", "Panic", "Set"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	return s, svc
+}
+
+// do sends an authorised request to the server's handler.
+func do(t *testing.T, s *Server, method, path, body string) (int, string) {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Host = "localhost:9999"
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	return rec.Code, strings.TrimSpace(rec.Body.String())
+}
+
+// TestMethods ensures only listed, JSON-compatible methods are exposed.
+func TestMethods(t *testing.T) {
+	s, _ := newTestServer(t)
+	got := strings.Join(s.Methods(), ",")
+	if want := "Test.Echo,Test.Fail,Test.Panic,Test.Set"; got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+	other, err := New(Options{Token: "secret"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if err := other.Register("Test", &testService{}, "Echo"); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if got := strings.Join(other.Methods(), ","); got != "Test.Echo" {
+		t.Fatalf("expected only the listed method, got %s", got)
+	}
+	for _, name := range []string{"Watch", "Missing", "last"} {
+		if err := other.Register("Test", &testService{}, name); err == nil {
+			t.Errorf("expected error registering %s", name)
+		}
+	}
+}
+
+// TestGuard ensures requests need the token, a loopback host and no Origin.
+func TestGuard(t *testing.T) {
+	s, _ := newTestServer(t)
+	cases := []struct {
+		name   string
+		mutate func(*http.Request)
+		want   int
+	}{
+		{"no token", func(r *http.Request) { r.Header.Del("Authorization") }, http.StatusUnauthorized},
+		{"wrong token", func(r *http.Request) { r.Header.Set("Authorization", "Bearer nope") }, http.StatusUnauthorized},
+		{"foreign host", func(r *http.Request) { r.Host = "evil.example:1234" }, http.StatusForbidden},
+		{"browser", func(r *http.Request) { r.Header.Set("Origin", "http://evil.example") }, http.StatusForbidden},
+		{"ok", func(*http.Request) {}, http.StatusOK},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest("GET", "/openapi.json", nil)
+		req.Host = "127.0.0.1:9999"
+		req.Header.Set("Authorization", "Bearer secret")
+		c.mutate(req)
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, req)
+		if rec.Code != c.want {
+			t.Errorf("%s: expected %d, got %d", c.name, c.want, rec.Code)
+		}
+	}
+}
+
+// TestCall covers the /v1 endpoint.
+func TestCall(t *testing.T) {
+	s, svc := newTestServer(t)
+	code, body := do(t, s, "POST", "/v1/Test/Echo", `[{"title":"hi","tags":["a"]}]`)
+	if code != http.StatusOK || body != `{"title":"hi","tags":["a"]}` {
+		t.Fatalf("Echo: %d %s", code, body)
+	}
+	if code, _ := do(t, s, "POST", "/v1/Test/Set", `["x"]`); code != http.StatusOK || svc.last != "x" {
+		t.Fatalf("Set: %d, last %q", code, svc.last)
+	}
+	if code, _ := do(t, s, "POST", "/v1/Test/Set", `[]`); code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for missing argument, got %d", code)
+	}
+	if code, body := do(t, s, "POST", "/v1/Test/Fail", ``); code != http.StatusUnprocessableEntity || !strings.Contains(body, "boom") {
+		t.Fatalf("Fail: %d %s", code, body)
+	}
+	if code, body := do(t, s, "POST", "/v1/Test/Panic", ``); code != http.StatusUnprocessableEntity || !strings.Contains(body, "panicked") {
+		t.Fatalf("Panic: %d %s", code, body)
+	}
+	if code, _ := do(t, s, "POST", "/v1/Test/Watch", ``); code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unexposed method, got %d", code)
+	}
+}
+
+// TestRPC covers JSON-RPC requests, errors, notifications and batches.
+func TestRPC(t *testing.T) {
+	s, svc := newTestServer(t)
+	cases := []struct{ req, want string }{
+		{`{"jsonrpc":"2.0","id":1,"method":"Test.Echo","params":[{"title":"t"}]}`, `{"jsonrpc":"2.0","id":1,"result":{"title":"t"}}`},
+		{`{"jsonrpc":"2.0","id":2,"method":"Test.Set","params":["y"]}`, `{"jsonrpc":"2.0","id":2,"result":null}`},
+		{`{"jsonrpc":"2.0","id":3,"method":"Test.Nope"}`, `{"jsonrpc":"2.0","id":3,"error":{"code":-32601,"message":"method not found"}}`},
+		{`{"jsonrpc":"2.0","id":4,"method":"Test.Set","params":"y"}`, `{"jsonrpc":"2.0","id":4,"error":{"code":-32602,"message":"invalid params: params must be an array"}}`},
+		{`{"jsonrpc":"2.0","id":5,"method":"Test.Fail"}`, `{"jsonrpc":"2.0","id":5,"error":{"code":-32000,"message":"boom","data":{"message":"boom"}}}`},
+		{`{"id":6,"method":"Test.Fail"}`, `{"jsonrpc":"2.0","id":6,"error":{"code":-32600,"message":"invalid request"}}`},
+		{`{`, `{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"parse error"}}`},
+		{`[]`, `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"invalid batch"}}`},
+		{`[{"jsonrpc":"2.0","id":7,"method":"Test.Set","params":["z"]},{"jsonrpc":"2.0","method":"Test.Set","params":["w"]}]`, `[{"jsonrpc":"2.0","id":7,"result":null}]`},
+		{`{"jsonrpc":"2.0","method":"Test.Set","params":["v"]}`, ``},
+	}
+	for _, c := range cases {
+		_, body := do(t, s, "POST", "/rpc", c.req)
+		if body != c.want {
+			t.Errorf("%s:\n got %s\nwant %s", c.req, body, c.want)
+		}
+	}
+	if svc.last != "v" {
+		t.Fatalf("expected notifications to run, last %q", svc.last)
+	}
+}
+
+// TestOpenAPI ensures every method is described and recursive types are
+// emitted as components.
+func TestOpenAPI(t *testing.T) {
+	s, _ := newTestServer(t)
+	code, body := do(t, s, "GET", "/openapi.json", "")
+	if code != http.StatusOK {
+		t.Fatalf("status %d", code)
+	}
+	var doc struct {
+		Paths      map[string]map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]any `json:"properties"`
+				Required   []string                  `json:"required"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal([]byte(body), &doc); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	for _, m := range s.Methods() {
+		p := "/v1/" + strings.Replace(m, ".", "/", 1)
+		if doc.Paths[p]["post"]["operationId"] != m {
+			t.Errorf("missing operation %s", p)
+		}
+	}
+	n := doc.Components.Schemas["api.note"]
+	if n.Properties["next"]["$ref"] != "#/components/schemas/api.note" {
+		t.Errorf("expected recursive ref, got %v", n.Properties["next"])
+	}
+	if len(n.Required) != 1 || n.Required[0] != "title" {
+		t.Errorf("expected only title required, got %v", n.Required)
+	}
+}
+
+// TestListen ensures the server answers on loopback and writes its info
+// file privately.
+func TestListen(t *testing.T) {
+	s, _ := newTestServer(t)
+	if err := s.Listen(0); err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	defer s.Close()
+	path := filepath.Join(t.TempDir(), InfoFile)
+	if err := s.WriteInfo(path); err != nil {
+		t.Fatalf("WriteInfo: %v", err)
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat info: %v", err)
+	}
+	if runtime.GOOS != "windows" && fi.Mode().Perm() != 0o600 {
+		t.Fatalf("expected mode 0600, got %v", fi.Mode().Perm())
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read info: %v", err)
+	}
+	var info Info
+	if err := json.Unmarshal(b, &info); err != nil || info.Token != "secret" || info.URL != s.URL() {
+		t.Fatalf("unexpected info %s: %v", b, err)
+	}
+	req, _ := http.NewRequest("POST", info.URL+"/v1/Test/Set", strings.NewReader(`["live"]`))
+	req.Header.Set("Authorization", "Bearer "+info.Token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d", resp.StatusCode)
+	}
+}
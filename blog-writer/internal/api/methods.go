@@ -0,0 +1,157 @@
+// Copyright (c) 2025 blog-writer authors
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// errorType is the reflected type of the error interface.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// method is an exported service method callable through the API.
+type method struct {
+	service string
+	name    string
+	fn      reflect.Value
+	in      []reflect.Type
+	// out is the type of the non-error result, nil when there is none.
+	out reflect.Type
+	// hasErr reports whether the last result is an error.
+	hasErr bool
+}
+
+// fullName returns the method's API name, "Service.Method".
+func (m *method) fullName() string {
+	return m.service + "." + m.name
+}
+
+// errInvalidParams reports parameters that do not match the method.
+var errInvalidParams = errors.New("invalid params")
+
+// registry holds the callable methods by API name.
+type registry struct {
+	methods map[string]*method
+}
+
+// register adds the listed methods of svc under the service name. It fails
+// when svc has no such exported method, when its parameters or results
+// cannot travel as JSON, or when it returns anything other than (), (T),
+// (error) or (T, error).
+func (r *registry) register(name string, svc any, methods []string) error {
+	if r.methods == nil {
+		r.methods = map[string]*method{}
+	}
+	v := reflect.ValueOf(svc)
+	for _, n := range methods {
+		mt, ok := v.Type().MethodByName(n)
+		if !ok {
+			return fmt.Errorf("%s has no method %s", name, n)
+		}
+		m, ok := newMethod(name, n, v.Method(mt.Index))
+		if !ok {
+			return fmt.Errorf("%s.%s has a signature the API cannot expose", name, n)
+		}
+		r.methods[m.fullName()] = m
+	}
+	return nil
+}
+
+// newMethod describes fn as service.name if its signature is supported.
+func newMethod(service, name string, fn reflect.Value) (*method, bool) {
+	ft := fn.Type()
+	if ft.IsVariadic() {
+		return nil, false
+	}
+	m := &method{service: service, name: name, fn: fn}
+	for i := 0; i < ft.NumIn(); i++ {
+		if !jsonable(ft.In(i)) {
+			return nil, false
+		}
+		m.in = append(m.in, ft.In(i))
+	}
+	outs := make([]reflect.Type, 0, ft.NumOut())
+	for i := 0; i < ft.NumOut(); i++ {
+		outs = append(outs, ft.Out(i))
+	}
+	if n := len(outs); n > 0 && outs[n-1] == errorType {
+		m.hasErr = true
+		outs = outs[:n-1]
+	}
+	switch {
+	case len(outs) > 1:
+		return nil, false
+	case len(outs) == 1:
+		if !jsonable(outs[0]) {
+			return nil, false
+		}
+		m.out = outs[0]
+	}
+	return m, true
+}
+
+// jsonable reports whether values of t can be encoded as JSON.
+func jsonable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return false
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return jsonable(t.Elem())
+	case reflect.Map:
+		return jsonable(t.Elem())
+	}
+	return true
+}
+
+// names returns the API names of all methods in order.
+func (r *registry) names() []string {
+	names := make([]string, 0, len(r.methods))
+	for n := range r.methods {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// call decodes params, a JSON array of positional arguments (or null when
+// the method takes none), invokes m and returns its result. Decoding
+// failures wrap errInvalidParams; a panicking method is reported as an
+// error rather than crashing the app.
+func (m *method) call(params json.RawMessage) (result any, err error) {
+	var raw []json.RawMessage
+	if p := bytes.TrimSpace(params); len(p) > 0 && !bytes.Equal(p, []byte("null")) {
+		if err := json.Unmarshal(p, &raw); err != nil {
+			return nil, fmt.Errorf("%w: params must be an array", errInvalidParams)
+		}
+	}
+	if len(raw) != len(m.in) {
+		return nil, fmt.Errorf("%w: %s takes %d arguments, got %d", errInvalidParams, m.fullName(), len(m.in), len(raw))
+	}
+	args := make([]reflect.Value, len(m.in))
+	for i, t := range m.in {
+		v := reflect.New(t)
+		if err := json.Unmarshal(raw[i], v.Interface()); err != nil {
+			return nil, fmt.Errorf("%w: argument %d: %v", errInvalidParams, i+1, err)
+		}
+		args[i] = v.Elem()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, fmt.Errorf("%s panicked: %v", m.fullName(), r)
+		}
+	}()
+	outs := m.fn.Call(args)
+	if m.hasErr {
+		if e := outs[len(outs)-1]; !e.IsNil() {
+			return nil, e.Interface().(error)
+		}
+	}
+	if m.out != nil {
+		return outs[0].Interface(), nil
+	}
+	return nil, nil
+}
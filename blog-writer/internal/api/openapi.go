@@ -0,0 +1,245 @@
+// Copyright (c) 2025 blog-writer authors
+package api
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Reflected types given dedicated schemas.
+var (
+	timeType        = reflect.TypeOf(time.Time{})
+	rawMessageType  = reflect.TypeOf(json.RawMessage(nil))
+	marshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// object is a JSON object in the generated document.
+type object = map[string]any
+
+// schemaGen builds JSON Schemas for Go types, collecting named structs as
+// reusable components.
+type schemaGen struct {
+	defs object
+}
+
+// schema returns the schema of t, registering named structs in defs.
+func (g *schemaGen) schema(t reflect.Type) object {
+	switch {
+	case t == timeType:
+		return object{"type": "string", "format": "date-time"}
+	case t == rawMessageType || t.Kind() == reflect.Interface:
+		return object{}
+	case t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType):
+		return object{"description": "custom JSON encoding"}
+	case t.Implements(textMarshalType) || reflect.PointerTo(t).Implements(textMarshalType):
+		return object{"type": "string"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schema(t.Elem())
+	case reflect.Bool:
+		return object{"type": "boolean"}
+	case reflect.String:
+		return object{"type": "string"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return object{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return object{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return object{"type": "number"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return object{"type": "string", "contentEncoding": "base64"}
+		}
+		return object{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Array:
+		return object{"type": "array", "items": g.schema(t.Elem()), "minItems": t.Len(), "maxItems": t.Len()}
+	case reflect.Map:
+		return object{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name := typeName(t)
+		if _, ok := g.defs[name]; !ok {
+			g.defs[name] = object{} // placeholder for recursive types
+			g.defs[name] = g.structSchema(t)
+		}
+		return object{"$ref": "#/components/schemas/" + name}
+	}
+	return object{}
+}
+
+// structSchema returns the object schema of struct t following
+// encoding/json field rules.
+func (g *schemaGen) structSchema(t reflect.Type) object {
+	props := object{}
+	var required []string
+	g.fields(t, props, &required)
+	s := object{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+// fields adds the JSON properties of struct t, flattening embedded structs.
+func (g *schemaGen) fields(t reflect.Type, props object, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		if f.Anonymous && name == "" {
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.fields(ft, props, required)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s := g.schema(ft)
+		if strings.Contains(opts, "string") {
+			s = object{"type": "string"}
+		}
+		props[name] = s
+		if !strings.Contains(opts, "omitempty") && !strings.Contains(opts, "omitzero") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// typeName returns the component name of t, e.g. "services.Branch".
+func typeName(t reflect.Type) string {
+	return path.Base(t.PkgPath()) + "." + t.Name()
+}
+
+// OpenAPI returns an OpenAPI 3.1 description of the server's methods. Each
+// method is a POST /v1/<Service>/<Method> operation taking a JSON array of
+// positional arguments; /rpc accepts the same calls as JSON-RPC 2.0.
+func (s *Server) OpenAPI() map[string]any {
+	g := &schemaGen{defs: object{}}
+	g.defs["Error"] = object{
+		"type":        "object",
+		"description": "Error returned by a service method.",
+		"properties": object{
+			"code":      object{"type": "string"},
+			"message":   object{"type": "string"},
+			"details":   object{"type": "object"},
+			"retryable": object{"type": "boolean"},
+		},
+		"required": []string{"message"},
+	}
+	paths := object{}
+	names := s.methods.names()
+	for _, name := range names {
+		m := s.methods.methods[name]
+		items := make([]any, len(m.in))
+		for i, t := range m.in {
+			items[i] = g.schema(t)
+		}
+		params := object{"type": "array", "minItems": len(m.in), "maxItems": len(m.in)}
+		if len(items) > 0 {
+			params["prefixItems"] = items
+		}
+		result := object{"type": "null"}
+		if m.out != nil {
+			result = g.schema(m.out)
+		}
+		paths["/v1/"+m.service+"/"+m.name] = object{"post": object{
+			"operationId": name,
+			"tags":        []string{m.service},
+			"summary":     signature(m),
+			"requestBody": object{
+				"required": len(m.in) > 0,
+				"content":  object{"application/json": object{"schema": params}},
+			},
+			"responses": object{
+				"200": jsonResponse("Result.", result),
+				"400": jsonResponse("Invalid arguments.", errorRef),
+				"422": jsonResponse("The service returned an error.", errorRef),
+			},
+		}}
+	}
+	paths["/rpc"] = object{"post": object{
+		"operationId": "rpc",
+		"summary":     "JSON-RPC 2.0 endpoint accepting the same methods as the /v1 operations.",
+		"requestBody": object{
+			"required": true,
+			"content": object{"application/json": object{"schema": object{
+				"type": "object",
+				"properties": object{
+					"jsonrpc": object{"const": "2.0"},
+					"id":      object{"type": []string{"string", "integer", "null"}},
+					"method":  object{"enum": names},
+					"params":  object{"type": "array"},
+				},
+				"required": []string{"jsonrpc", "method"},
+			}}},
+		},
+		"responses": object{"200": jsonResponse("JSON-RPC response.", object{"type": "object"})},
+	}}
+	return object{
+		"openapi": "3.1.0",
+		"info":    object{"title": "Blog-Writer local API", "version": s.version},
+		"servers": []any{object{"url": s.URL()}},
+		"security": []any{
+			object{"bearer": []string{}},
+		},
+		"paths": paths,
+		"components": object{
+			"schemas":         g.defs,
+			"securitySchemes": object{"bearer": object{"type": "http", "scheme": "bearer"}},
+		},
+	}
+}
+
+// errorRef references the Error component.
+var errorRef = object{"$ref": "#/components/schemas/Error"}
+
+// jsonResponse describes a JSON response with schema.
+func jsonResponse(description string, schema object) object {
+	return object{
+		"description": description,
+		"content":     object{"application/json": object{"schema": schema}},
+	}
+}
+
+// signature renders m's Go signature, e.g. "Open(string) error".
+func signature(m *method) string {
+	in := make([]string, len(m.in))
+	for i, t := range m.in {
+		in[i] = t.String()
+	}
+	var out []string
+	if m.out != nil {
+		out = append(out, m.out.String())
+	}
+	if m.hasErr {
+		out = append(out, "error")
+	}
+	sig := fmt.Sprintf("%s(%s)", m.name, strings.Join(in, ", "))
+	switch len(out) {
+	case 0:
+		return sig
+	case 1:
+		return sig + " " + out[0]
+	}
+	return sig + " (" + strings.Join(out, ", ") + ")"
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025 blog-writer authors
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// JSON-RPC 2.0 error codes.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	// codeServiceError reports an error returned by a service method; the
+	// formatted error is in the data member.
+	codeServiceError = -32000
+)
+
+// rpcRequest is a JSON-RPC 2.0 request. A request without an id is a
+// notification and gets no response.
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response.
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// MarshalJSON encodes r with either a result, which may be null, or an
+// error member, never both.
+func (r rpcResponse) MarshalJSON() ([]byte, error) {
+	if r.Error != nil {
+		return json.Marshal(struct {
+			JSONRPC string          `json:"jsonrpc"`
+			ID      json.RawMessage `json:"id"`
+			Error   *rpcError       `json:"error"`
+		}{r.JSONRPC, r.ID, r.Error})
+	}
+	type response rpcResponse
+	return json.Marshal(response(r))
+}
+
+// rpcError is the error member of a failed JSON-RPC response.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+// serveRPC handles POST /rpc with a single request or a batch.
+func (s *Server) serveRPC(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeJSON(w, http.StatusOK, rpcFailure(nil, codeParseError, err.Error()))
+		return
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(body, &batch); err != nil || len(batch) == 0 {
+			writeJSON(w, http.StatusOK, rpcFailure(nil, codeInvalidRequest, "invalid batch"))
+			return
+		}
+		out := []rpcResponse{}
+		for _, raw := range batch {
+			if resp, ok := s.handleRPC(raw); ok {
+				out = append(out, resp)
+			}
+		}
+		if len(out) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, http.StatusOK, out)
+		return
+	}
+	resp, ok := s.handleRPC(body)
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleRPC executes one request. It reports false for notifications,
+// which get no response.
+func (s *Server) handleRPC(raw json.RawMessage) (rpcResponse, bool) {
+	var req rpcRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return rpcFailure(nil, codeParseError, "parse error"), true
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return rpcFailure(req.ID, codeInvalidRequest, "invalid request"), true
+	}
+	notify := len(req.ID) == 0
+	m, ok := s.methods.methods[req.Method]
+	if !ok {
+		return rpcFailure(req.ID, codeMethodNotFound, "method not found"), !notify
+	}
+	result, err := s.invoke(m, req.Params)
+	switch {
+	case errors.Is(err, errInvalidParams):
+		return rpcFailure(req.ID, codeInvalidParams, err.Error()), !notify
+	case err != nil:
+		resp := rpcFailure(req.ID, codeServiceError, err.Error())
+		resp.Error.Data = s.format(err)
+		return resp, !notify
+	}
+	return rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: result}, !notify
+}
+
+// rpcFailure builds an error response for id.
+func rpcFailure(id json.RawMessage, code int, message string) rpcResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return rpcResponse{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}}
+}
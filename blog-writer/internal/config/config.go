@@ -2,29 +2,131 @@
 package config
 
 import (
+	"bytes"
 	"errors"
 	"os"
 	"path/filepath"
-	"runtime"
+	"sync"
 
 	"gopkg.in/yaml.v3"
+
+	"blog-writer/internal/fsutil"
 )
 
 // Config represents application configuration stored on disk.
 type Config struct {
-	RecentlyOpened []string `yaml:"recently_opened"`
+	RecentlyOpened []RecentRepo `yaml:"recently_opened"`
+	RecentLimit    int          `yaml:"recent_limit,omitempty"`
+	Defaults       UserDefaults `yaml:"defaults,omitempty"`
+	Templates      []Template   `yaml:"templates,omitempty"`
+	Workspace      Workspace    `yaml:"workspace,omitempty"`
+	Window         WindowState  `yaml:"window,omitempty"`
+	UI             UIState      `yaml:"ui,omitempty"`
+	// Keybindings maps action IDs to shortcuts overriding the defaults. An
+	// empty shortcut unbinds the action.
+	Keybindings map[string]string `yaml:"keybindings,omitempty"`
+	// OnlineDocs makes Help > Read the docs open GitHub instead of the
+	// bundled documentation.
+	OnlineDocs bool    `yaml:"online_docs,omitempty"`
+	Logging    Logging `yaml:"logging,omitempty"`
+	API        API     `yaml:"api,omitempty"`
+	Plugins    Plugins `yaml:"plugins,omitempty"`
 }
 
-// DefaultPath returns the path to the configuration file depending on OS.
-func DefaultPath() (string, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
+// Plugins configures external plugins.
+type Plugins struct {
+	// TimeoutSeconds bounds each plugin call; zero uses the default.
+	TimeoutSeconds int `yaml:"timeout_seconds,omitempty"`
+	// TrustedRepos lists the repositories whose own .blog-writer/plugins
+	// may run. Plugins in the user config directory always run.
+	TrustedRepos []string `yaml:"trusted_repos,omitempty"`
+}
+
+// API configures the local scripting API. It is off unless Enabled is set.
+type API struct {
+	Enabled bool `yaml:"enabled,omitempty"`
+	// Port is the loopback port to listen on; zero picks a free port. The
+	// address and token are written to api.json next to the config file.
+	Port int `yaml:"port,omitempty"`
+}
+
+// Logging configures the application log. Levels are "debug", "info",
+// "warn" or "error".
+type Logging struct {
+	// Level applies to subsystems without an entry in Levels.
+	Level string `yaml:"level,omitempty" json:"level"`
+	// Levels overrides Level per subsystem (app, git, schema, image, fs, api,
+	// plugin).
+	Levels map[string]string `yaml:"levels,omitempty" json:"levels"`
+	// MaxSizeMB is the size at which the log file is rotated.
+	MaxSizeMB int `yaml:"max_size_mb,omitempty" json:"maxSizeMB"`
+	// MaxFiles is the number of rotated log files kept.
+	MaxFiles int `yaml:"max_files,omitempty" json:"maxFiles"`
+}
+
+// Workspace lists the repositories open side by side and the one currently
+// shown in the editor.
+type Workspace struct {
+	Repos  []string `yaml:"repos,omitempty"`
+	Active string   `yaml:"active,omitempty"`
+}
+
+// Add appends path to the workspace if it is not already present. The first
+// repository added becomes active.
+func (w *Workspace) Add(path string) {
+	for _, p := range w.Repos {
+		if p == path {
+			return
+		}
 	}
-	if runtime.GOOS == "windows" {
-		return filepath.Join(home, "blog-writer.yml"), nil
+	w.Repos = append(w.Repos, path)
+	if w.Active == "" {
+		w.Active = path
 	}
-	return filepath.Join(home, ".blog-writer.yml"), nil
+}
+
+// Remove drops path from the workspace. When the active repository is
+// removed the first remaining one becomes active.
+func (w *Workspace) Remove(path string) {
+	out := w.Repos[:0]
+	for _, p := range w.Repos {
+		if p != path {
+			out = append(out, p)
+		}
+	}
+	w.Repos = out
+	if w.Active == path {
+		w.Active = ""
+		if len(w.Repos) > 0 {
+			w.Active = w.Repos[0]
+		}
+	}
+}
+
+// Contains reports whether path is part of the workspace.
+func (w Workspace) Contains(path string) bool {
+	for _, p := range w.Repos {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// Template references a user repository template stored either as a
+// directory on disk (Path) or as a git repository (URL).
+type Template struct {
+	Name string `yaml:"name"`
+	Path string `yaml:"path,omitempty"`
+	URL  string `yaml:"url,omitempty"`
+}
+
+// UserDefaults holds user-level fallbacks for repository settings that are
+// left empty in .blog-writer/settings.json.
+type UserDefaults struct {
+	DefaultAuthor   string   `yaml:"default_author,omitempty"`
+	DefaultKeywords []string `yaml:"default_keywords,omitempty"`
+	DefaultBranch   string   `yaml:"default_branch,omitempty"`
 }
 
 // Load reads configuration from path. If the file does not exist, an empty config is returned.
@@ -43,11 +145,51 @@ func Load(path string) (Config, error) {
 	return cfg, nil
 }
 
-// Save writes configuration to the given path.
+// mu serializes writes to config files within the process.
+var mu sync.Mutex
+
+// Save atomically writes configuration to the given path.
 func Save(path string, cfg Config) error {
+	mu.Lock()
+	defer mu.Unlock()
+	return save(path, cfg)
+}
+
+// Update loads the configuration at path, applies fn and saves the result
+// while holding the package lock, so concurrent writers do not drop each
+// other's changes. Nothing is written when fn fails or leaves the
+// configuration unchanged.
+func Update(path string, fn func(cfg *Config) error) error {
+	mu.Lock()
+	defer mu.Unlock()
+	cfg, err := Load(path)
+	if err != nil {
+		return err
+	}
+	before, err := yaml.Marshal(&cfg)
+	if err != nil {
+		return err
+	}
+	if err := fn(&cfg); err != nil {
+		return err
+	}
+	after, err := yaml.Marshal(&cfg)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(before, after) {
+		return nil
+	}
+	return save(path, cfg)
+}
+
+func save(path string, cfg Config) error {
 	b, err := yaml.Marshal(&cfg)
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, b, 0o644)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(path, b, 0o644)
 }
@@ -2,25 +2,25 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
-	"runtime"
+	"sync"
 	"testing"
 )
 
 func TestDefaultPath(t *testing.T) {
+	t.Setenv(EnvConfig, "")
 	path, err := DefaultPath()
 	if err != nil {
 		t.Fatalf("DefaultPath: %v", err)
 	}
-	home, err := os.UserHomeDir()
+	dir, err := os.UserConfigDir()
 	if err != nil {
-		t.Fatalf("UserHomeDir: %v", err)
-	}
-	expected := filepath.Join(home, ".blog-writer.yml")
-	if runtime.GOOS == "windows" {
-		expected = filepath.Join(home, "blog-writer.yml")
+		t.Fatalf("UserConfigDir: %v", err)
 	}
+	expected := filepath.Join(dir, "blog-writer", "config.yml")
 	if path != expected {
 		t.Fatalf("expected %s, got %s", expected, path)
 	}
@@ -29,7 +29,7 @@ func TestDefaultPath(t *testing.T) {
 func TestLoadSave(t *testing.T) {
 	dir := t.TempDir()
 	path := filepath.Join(dir, "config.yml")
-	cfg := Config{RecentlyOpened: []string{"/a", "/b"}}
+	cfg := Config{RecentlyOpened: []RecentRepo{{Path: "/a"}, {Path: "/b"}}}
 	if err := Save(path, cfg); err != nil {
 		t.Fatalf("Save: %v", err)
 	}
@@ -37,7 +37,7 @@ func TestLoadSave(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Load: %v", err)
 	}
-	if len(got.RecentlyOpened) != 2 || got.RecentlyOpened[0] != "/a" {
+	if len(got.RecentlyOpened) != 2 || got.RecentlyOpened[0].Path != "/a" {
 		t.Fatalf("unexpected data: %+v", got)
 	}
 	// Loading non-existent file returns default
@@ -50,3 +50,75 @@ func TestLoadSave(t *testing.T) {
 		t.Fatalf("expected empty config, got %+v", cfg2)
 	}
 }
+
+// TestUpdateConcurrent ensures concurrent updates do not lose each other's
+// changes and that a failing update writes nothing.
+func TestUpdateConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := Update(path, func(cfg *Config) error {
+				cfg.RecentlyOpened = append(cfg.RecentlyOpened, RecentRepo{Path: fmt.Sprintf("/r%d", i)})
+				return nil
+			})
+			if err != nil {
+				t.Errorf("Update: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	boom := errors.New("boom")
+	err := Update(path, func(cfg *Config) error {
+		cfg.RecentlyOpened = nil
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected fn error, got %v", err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(got.RecentlyOpened) != 20 {
+		t.Fatalf("expected 20 entries, got %d", len(got.RecentlyOpened))
+	}
+}
+
+// TestLoadSaveDefaults ensures user-level setting defaults round trip.
+func TestLoadSaveDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	cfg := Config{Defaults: UserDefaults{DefaultAuthor: "Ada", DefaultKeywords: []string{"go"}, DefaultBranch: "trunk"}}
+	if err := Save(path, cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if got.Defaults.DefaultAuthor != "Ada" || got.Defaults.DefaultBranch != "trunk" || len(got.Defaults.DefaultKeywords) != 1 {
+		t.Fatalf("unexpected defaults: %+v", got.Defaults)
+	}
+}
+
+// TestWorkspace ensures repositories are added once and the active
+// repository moves on removal.
+func TestWorkspace(t *testing.T) {
+	var w Workspace
+	w.Add("/a")
+	w.Add("/b")
+	w.Add("/a")
+	if len(w.Repos) != 2 || w.Active != "/a" {
+		t.Fatalf("unexpected workspace: %+v", w)
+	}
+	w.Remove("/a")
+	if len(w.Repos) != 1 || w.Active != "/b" || w.Contains("/a") {
+		t.Fatalf("unexpected workspace after remove: %+v", w)
+	}
+	w.Remove("/b")
+	if w.Active != "" {
+		t.Fatalf("expected no active repo, got %q", w.Active)
+	}
+}
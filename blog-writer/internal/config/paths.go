@@ -0,0 +1,172 @@
+// Copyright (c) 2025 blog-writer authors
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"blog-writer/internal/fsutil"
+)
+
+// EnvConfig names the environment variable that overrides the config file path.
+const EnvConfig = "BLOG_WRITER_CONFIG"
+
+// appDir is the per-application directory inside the platform base directories.
+const appDir = "blog-writer"
+
+// DefaultPath returns the path to the configuration file. BLOG_WRITER_CONFIG
+// takes precedence; otherwise the file is config.yml in the platform config
+// directory: $XDG_CONFIG_HOME/blog-writer (~/.config/blog-writer) on Linux,
+// ~/Library/Application Support/blog-writer on macOS and
+// %AppData%\blog-writer on Windows.
+func DefaultPath() (string, error) {
+	if p := os.Getenv(EnvConfig); p != "" {
+		return p, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, appDir, "config.yml"), nil
+}
+
+// LegacyPath returns the pre-XDG location of the configuration file:
+// ~/.blog-writer.yml, or ~/blog-writer.yml on Windows.
+func LegacyPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(home, "blog-writer.yml"), nil
+	}
+	return filepath.Join(home, ".blog-writer.yml"), nil
+}
+
+// DataDir returns the directory for persistent application data:
+// $XDG_DATA_HOME/blog-writer (~/.local/share/blog-writer) on Linux,
+// ~/Library/Application Support/blog-writer on macOS and
+// %LocalAppData%\blog-writer on Windows.
+func DataDir() (string, error) {
+	switch runtime.GOOS {
+	case "windows":
+		return localAppData()
+	case "darwin":
+		return darwinSupportDir()
+	}
+	return xdgDir("XDG_DATA_HOME", ".local", "share")
+}
+
+// StateDir returns the directory for logs and other state that should
+// survive restarts but is not worth backing up:
+// $XDG_STATE_HOME/blog-writer (~/.local/state/blog-writer) on Linux and a
+// "state" folder inside DataDir elsewhere.
+func StateDir() (string, error) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		dir, err := DataDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "state"), nil
+	}
+	return xdgDir("XDG_STATE_HOME", ".local", "state")
+}
+
+// LogDir returns the directory holding the application log files.
+func LogDir() (string, error) {
+	dir, err := StateDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "logs"), nil
+}
+
+// CacheDir returns the directory for disposable cached data.
+func CacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, appDir), nil
+}
+
+// MigrateLegacy moves a configuration file from LegacyPath to DefaultPath
+// when only the legacy file exists. It reports whether a file was moved.
+// Nothing is moved when BLOG_WRITER_CONFIG selects the config file, so a
+// custom location never consumes the user's regular config.
+func MigrateLegacy() (bool, error) {
+	if os.Getenv(EnvConfig) != "" {
+		return false, nil
+	}
+	target, err := DefaultPath()
+	if err != nil {
+		return false, err
+	}
+	legacy, err := LegacyPath()
+	if err != nil {
+		return false, err
+	}
+	return migrateFile(legacy, target)
+}
+
+// migrateFile moves legacy to target unless target already exists.
+func migrateFile(legacy, target string) (bool, error) {
+	if legacy == target {
+		return false, nil
+	}
+	if _, err := os.Stat(target); err == nil {
+		return false, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+	b, err := os.ReadFile(legacy)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return false, err
+	}
+	if err := fsutil.WriteFileAtomic(target, b, 0o644); err != nil {
+		return false, err
+	}
+	return true, os.Remove(legacy)
+}
+
+// xdgDir returns $env/blog-writer, falling back to ~/<fallback...>/blog-writer
+// when env is unset or not absolute, as the XDG spec requires.
+func xdgDir(env string, fallback ...string) (string, error) {
+	if dir := os.Getenv(env); filepath.IsAbs(dir) {
+		return filepath.Join(dir, appDir), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(append(append([]string{home}, fallback...), appDir)...), nil
+}
+
+// localAppData returns %LocalAppData%\blog-writer.
+func localAppData() (string, error) {
+	if dir := os.Getenv("LocalAppData"); dir != "" {
+		return filepath.Join(dir, appDir), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "AppData", "Local", appDir), nil
+}
+
+// darwinSupportDir returns ~/Library/Application Support/blog-writer.
+func darwinSupportDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Library", "Application Support", appDir), nil
+}
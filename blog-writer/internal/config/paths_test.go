@@ -0,0 +1,134 @@
+// Copyright (c) 2025 blog-writer authors
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// TestDefaultPathEnv ensures BLOG_WRITER_CONFIG overrides the config path.
+func TestDefaultPathEnv(t *testing.T) {
+	want := filepath.Join(t.TempDir(), "custom.yml")
+	t.Setenv(EnvConfig, want)
+	got, err := DefaultPath()
+	if err != nil {
+		t.Fatalf("DefaultPath: %v", err)
+	}
+	if got != want {
+		t.Fatalf("expected %s, got %s", want, got)
+	}
+}
+
+// TestXDGDirs ensures the XDG base directory variables are honored on Linux
+// and relative values fall back to the home directory.
+func TestXDGDirs(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("XDG directories apply to Linux only")
+	}
+	home := t.TempDir()
+	xdg := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv(EnvConfig, "")
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(xdg, "config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(xdg, "data"))
+	t.Setenv("XDG_STATE_HOME", "relative")
+
+	cases := []struct {
+		name string
+		fn   func() (string, error)
+		want string
+	}{
+		{"config", DefaultPath, filepath.Join(xdg, "config", "blog-writer", "config.yml")},
+		{"data", DataDir, filepath.Join(xdg, "data", "blog-writer")},
+		{"state", StateDir, filepath.Join(home, ".local", "state", "blog-writer")},
+		{"logs", LogDir, filepath.Join(home, ".local", "state", "blog-writer", "logs")},
+	}
+	for _, c := range cases {
+		got, err := c.fn()
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if got != c.want {
+			t.Fatalf("%s: expected %s, got %s", c.name, c.want, got)
+		}
+	}
+}
+
+// TestMigrateFile ensures a legacy config is moved once and an existing
+// config is never overwritten.
+func TestMigrateFile(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, ".blog-writer.yml")
+	target := filepath.Join(dir, "xdg", "blog-writer", "config.yml")
+
+	moved, err := migrateFile(legacy, target)
+	if err != nil || moved {
+		t.Fatalf("expected no migration without legacy file, got %v %v", moved, err)
+	}
+
+	if err := os.WriteFile(legacy, []byte("recent_limit: 7\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	moved, err = migrateFile(legacy, target)
+	if err != nil || !moved {
+		t.Fatalf("expected migration, got %v %v", moved, err)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Fatalf("legacy file still present: %v", err)
+	}
+	cfg, err := Load(target)
+	if err != nil || cfg.RecentLimit != 7 {
+		t.Fatalf("unexpected migrated config: %+v %v", cfg, err)
+	}
+
+	if err := os.WriteFile(legacy, []byte("recent_limit: 3\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if moved, err := migrateFile(legacy, target); err != nil || moved {
+		t.Fatalf("expected existing config to win, got %v %v", moved, err)
+	}
+	cfg, _ = Load(target)
+	if cfg.RecentLimit != 7 {
+		t.Fatalf("existing config overwritten: %+v", cfg)
+	}
+}
+
+// TestMigrateLegacyEnvConfig ensures the legacy config is left in place
+// when BLOG_WRITER_CONFIG is set.
+func TestMigrateLegacyEnvConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	target := filepath.Join(t.TempDir(), "custom.yml")
+	t.Setenv(EnvConfig, target)
+	legacy, err := LegacyPath()
+	if err != nil {
+		t.Fatalf("LegacyPath: %v", err)
+	}
+	if err := os.WriteFile(legacy, []byte("recent_limit: 7\n"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	moved, err := MigrateLegacy()
+	if err != nil || moved {
+		t.Fatalf("expected no migration, got %v %v", moved, err)
+	}
+	if _, err := os.Stat(legacy); err != nil {
+		t.Fatalf("legacy file removed: %v", err)
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Fatalf("custom config created: %v", err)
+	}
+}
+
+// TestSaveCreatesDir ensures Save creates missing parent directories.
+func TestSaveCreatesDir(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a", "b", "config.yml")
+	if err := Save(path, Config{RecentLimit: 2}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025 blog-writer authors
+package config
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultRecentLimit is the number of unpinned recent repositories kept
+// when RecentLimit is not set.
+const DefaultRecentLimit = 5
+
+// RecentRepo records a recently opened repository.
+type RecentRepo struct {
+	Path       string    `yaml:"path" json:"path"`
+	Name       string    `yaml:"name,omitempty" json:"name"`
+	LastOpened time.Time `yaml:"last_opened,omitempty" json:"lastOpened"`
+	Pinned     bool      `yaml:"pinned,omitempty" json:"pinned"`
+	Remote     string    `yaml:"remote,omitempty" json:"remote"`
+}
+
+// UnmarshalYAML accepts both the current mapping form and the legacy form
+// where each entry was a bare path string.
+func (r *RecentRepo) UnmarshalYAML(n *yaml.Node) error {
+	if n.Kind == yaml.ScalarNode {
+		*r = RecentRepo{Path: n.Value, Name: filepath.Base(n.Value)}
+		return nil
+	}
+	type plain RecentRepo
+	return n.Decode((*plain)(r))
+}
+
+// Limit returns the configured cap on unpinned recent repositories.
+func (c Config) Limit() int {
+	if c.RecentLimit > 0 {
+		return c.RecentLimit
+	}
+	return DefaultRecentLimit
+}
+
+// SetRecentLimit sets the cap on unpinned recent repositories and evicts
+// entries beyond it. A limit below 1 restores the default.
+func (c *Config) SetRecentLimit(limit int) {
+	if limit < 1 {
+		limit = 0
+	}
+	c.RecentLimit = limit
+	c.trimRecent()
+}
+
+// Touch records that path was opened at now, moving it to the front and
+// evicting the oldest unpinned entries beyond Limit. Existing pin state and
+// display name are kept; remote is updated when non-empty.
+func (c *Config) Touch(path, remote string, now time.Time) {
+	entry := RecentRepo{Path: path, Name: filepath.Base(path)}
+	rest := make([]RecentRepo, 0, len(c.RecentlyOpened))
+	for _, e := range c.RecentlyOpened {
+		if e.Path == path {
+			entry = e
+			continue
+		}
+		rest = append(rest, e)
+	}
+	entry.LastOpened = now
+	if remote != "" {
+		entry.Remote = remote
+	}
+	c.RecentlyOpened = append([]RecentRepo{entry}, rest...)
+	c.sortRecent()
+	c.trimRecent()
+}
+
+// SetPinned pins or unpins path and reports whether it was found.
+func (c *Config) SetPinned(path string, pinned bool) bool {
+	for i := range c.RecentlyOpened {
+		if c.RecentlyOpened[i].Path == path {
+			c.RecentlyOpened[i].Pinned = pinned
+			c.sortRecent()
+			c.trimRecent()
+			return true
+		}
+	}
+	return false
+}
+
+// Forget removes path from the recent list and reports whether it was present.
+func (c *Config) Forget(path string) bool {
+	for i, e := range c.RecentlyOpened {
+		if e.Path == path {
+			c.RecentlyOpened = append(c.RecentlyOpened[:i], c.RecentlyOpened[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Prune drops entries for which valid returns false and reports whether any
+// entry was removed.
+func (c *Config) Prune(valid func(path string) bool) bool {
+	kept := c.RecentlyOpened[:0]
+	for _, e := range c.RecentlyOpened {
+		if valid(e.Path) {
+			kept = append(kept, e)
+		}
+	}
+	changed := len(kept) != len(c.RecentlyOpened)
+	c.RecentlyOpened = kept
+	return changed
+}
+
+// sortRecent orders pinned entries first, then by most recently opened.
+// Entries without a timestamp keep their relative order.
+func (c *Config) sortRecent() {
+	sort.SliceStable(c.RecentlyOpened, func(i, j int) bool {
+		a, b := c.RecentlyOpened[i], c.RecentlyOpened[j]
+		if a.Pinned != b.Pinned {
+			return a.Pinned
+		}
+		return a.LastOpened.After(b.LastOpened)
+	})
+}
+
+// trimRecent evicts unpinned entries beyond Limit.
+func (c *Config) trimRecent() {
+	limit := c.Limit()
+	kept := c.RecentlyOpened[:0]
+	unpinned := 0
+	for _, e := range c.RecentlyOpened {
+		if !e.Pinned {
+			if unpinned == limit {
+				continue
+			}
+			unpinned++
+		}
+		kept = append(kept, e)
+	}
+	c.RecentlyOpened = kept
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 blog-writer authors
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestLoadLegacyRecent ensures the old list-of-paths format is migrated on load and save.
+func TestLoadLegacyRecent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	legacy := "recently_opened:\n  - /home/me/blog\n  - /home/me/other\n"
+	if err := os.WriteFile(path, []byte(legacy), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(cfg.RecentlyOpened) != 2 || cfg.RecentlyOpened[0].Path != "/home/me/blog" || cfg.RecentlyOpened[0].Name != "blog" {
+		t.Fatalf("unexpected entries: %+v", cfg.RecentlyOpened)
+	}
+	cfg.RecentlyOpened[0].Pinned = true
+	if err := Save(path, cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !got.RecentlyOpened[0].Pinned || got.RecentlyOpened[1].Path != "/home/me/other" {
+		t.Fatalf("unexpected round trip: %+v", got.RecentlyOpened)
+	}
+}
+
+// TestTouchLimitAndPinning verifies ordering, eviction and pin handling.
+func TestTouchLimitAndPinning(t *testing.T) {
+	var cfg Config
+	cfg.SetRecentLimit(2)
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg.Touch("/a", "git@x:a.git", base)
+	cfg.Touch("/b", "", base.Add(time.Minute))
+	if !cfg.SetPinned("/a", true) {
+		t.Fatal("expected /a to be pinned")
+	}
+	cfg.Touch("/c", "", base.Add(2*time.Minute))
+	cfg.Touch("/d", "", base.Add(3*time.Minute))
+	want := []string{"/a", "/d", "/c"}
+	if len(cfg.RecentlyOpened) != len(want) {
+		t.Fatalf("expected %v, got %+v", want, cfg.RecentlyOpened)
+	}
+	for i, p := range want {
+		if cfg.RecentlyOpened[i].Path != p {
+			t.Fatalf("expected %v, got %+v", want, cfg.RecentlyOpened)
+		}
+	}
+	if cfg.RecentlyOpened[0].Remote != "git@x:a.git" || !cfg.RecentlyOpened[0].LastOpened.Equal(base) {
+		t.Fatalf("pinned entry lost data: %+v", cfg.RecentlyOpened[0])
+	}
+	if !cfg.Forget("/c") || cfg.Forget("/zzz") {
+		t.Fatal("unexpected Forget result")
+	}
+	if cfg.Prune(func(p string) bool { return p != "/a" }) != true || len(cfg.RecentlyOpened) != 1 {
+		t.Fatalf("expected /a pruned, got %+v", cfg.RecentlyOpened)
+	}
+	if cfg.Limit() != 2 {
+		t.Fatalf("expected limit 2, got %d", cfg.Limit())
+	}
+	cfg.SetRecentLimit(0)
+	if cfg.Limit() != DefaultRecentLimit {
+		t.Fatalf("expected default limit, got %d", cfg.Limit())
+	}
+}
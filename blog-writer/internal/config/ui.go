@@ -0,0 +1,48 @@
+// Copyright (c) 2025 blog-writer authors
+package config
+
+// UIState holds layout state restored on startup.
+type UIState struct {
+	// LastRepo is the repository that was open when the app last closed.
+	LastRepo string `yaml:"last_repo,omitempty" json:"lastRepo"`
+	// Tabs lists the open article tabs in display order.
+	Tabs []ArticleTab `yaml:"tabs,omitempty" json:"tabs"`
+	// ActiveTab is the index of the focused tab in Tabs.
+	ActiveTab int `yaml:"active_tab,omitempty" json:"activeTab"`
+	// Splits maps a panel splitter name to the fraction, between 0 and 1,
+	// taken by its first pane.
+	Splits map[string]float64 `yaml:"splits,omitempty" json:"splits"`
+	// Panels maps a panel name to whether it is shown. Panels missing from
+	// the map are shown.
+	Panels map[string]bool `yaml:"panels,omitempty" json:"panels"`
+}
+
+// PanelVisible reports whether the named panel is shown.
+func (u UIState) PanelVisible(name string) bool {
+	visible, ok := u.Panels[name]
+	return !ok || visible
+}
+
+// ArticleTab identifies an article open in a tab.
+type ArticleTab struct {
+	Repo string `yaml:"repo" json:"repo"`
+	ID   string `yaml:"id" json:"id"`
+}
+
+// Normalize drops empty tabs, keeps ActiveTab in range and clamps split
+// fractions to [0, 1].
+func (u *UIState) Normalize() {
+	tabs := u.Tabs[:0]
+	for _, t := range u.Tabs {
+		if t.Repo != "" && t.ID != "" {
+			tabs = append(tabs, t)
+		}
+	}
+	u.Tabs = tabs
+	if u.ActiveTab < 0 || u.ActiveTab >= len(u.Tabs) {
+		u.ActiveTab = 0
+	}
+	for k, v := range u.Splits {
+		u.Splits[k] = min(max(v, 0), 1)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2025 blog-writer authors
+package config
+
+// Default and minimum window dimensions in logical pixels.
+const (
+	DefaultWindowWidth  = 1024
+	DefaultWindowHeight = 768
+	MinWindowWidth      = 640
+	MinWindowHeight     = 480
+)
+
+// WindowState remembers the main window geometry. X and Y are relative to
+// the screen the window was on, whose size is kept in ScreenWidth and
+// ScreenHeight so a missing monitor can be detected on restore. The
+// geometry is the un-maximized one.
+type WindowState struct {
+	X            int  `yaml:"x"`
+	Y            int  `yaml:"y"`
+	Width        int  `yaml:"width"`
+	Height       int  `yaml:"height"`
+	Maximized    bool `yaml:"maximized,omitempty"`
+	ScreenWidth  int  `yaml:"screen_width,omitempty"`
+	ScreenHeight int  `yaml:"screen_height,omitempty"`
+}
+
+// Screen describes a monitor available when the window is restored.
+type Screen struct {
+	Width   int
+	Height  int
+	Current bool
+	Primary bool
+}
+
+// Size returns the remembered window size, or the default size when none
+// is stored, never smaller than the minimum.
+func (w WindowState) Size() (int, int) {
+	width, height := w.Width, w.Height
+	if width <= 0 || height <= 0 {
+		width, height = DefaultWindowWidth, DefaultWindowHeight
+	}
+	return max(width, MinWindowWidth), max(height, MinWindowHeight)
+}
+
+// Clamp fits the remembered geometry onto the available screens. When the
+// screen the window was on is still present the window keeps its position,
+// moved just enough to be fully visible. Otherwise it is centered on the
+// current (or primary) screen. The second result is false when no position
+// should be applied, e.g. nothing was remembered or no screen is known.
+func (w WindowState) Clamp(screens []Screen) (WindowState, bool) {
+	width, height := w.Size()
+	w.Width, w.Height = width, height
+	if len(screens) == 0 || w.ScreenWidth == 0 || w.ScreenHeight == 0 {
+		return w, false
+	}
+	target, found := screens[0], false
+	for _, s := range screens {
+		if s.Width == w.ScreenWidth && s.Height == w.ScreenHeight {
+			target, found = s, true
+			break
+		}
+	}
+	if !found {
+		for _, s := range screens {
+			if s.Current || s.Primary {
+				target = s
+				if s.Current {
+					break
+				}
+			}
+		}
+	}
+	w.Width = min(w.Width, target.Width)
+	w.Height = min(w.Height, target.Height)
+	if !found {
+		w.X = (target.Width - w.Width) / 2
+		w.Y = (target.Height - w.Height) / 2
+	}
+	w.X = min(max(w.X, 0), target.Width-w.Width)
+	w.Y = min(max(w.Y, 0), target.Height-w.Height)
+	w.ScreenWidth, w.ScreenHeight = target.Width, target.Height
+	return w, true
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025 blog-writer authors
+package config
+
+import "testing"
+
+// TestWindowSize ensures defaults and minimums apply to the stored size.
+func TestWindowSize(t *testing.T) {
+	if w, h := (WindowState{}).Size(); w != DefaultWindowWidth || h != DefaultWindowHeight {
+		t.Fatalf("unexpected default size %dx%d", w, h)
+	}
+	if w, h := (WindowState{Width: 100, Height: 900}).Size(); w != MinWindowWidth || h != 900 {
+		t.Fatalf("unexpected clamped size %dx%d", w, h)
+	}
+}
+
+// TestWindowClamp ensures windows stay visible on the remembered screen and
+// are centered when that screen is gone.
+func TestWindowClamp(t *testing.T) {
+	laptop := Screen{Width: 1440, Height: 900, Current: true, Primary: true}
+	monitor := Screen{Width: 2560, Height: 1440}
+
+	cases := []struct {
+		name    string
+		in      WindowState
+		screens []Screen
+		want    WindowState
+		apply   bool
+	}{
+		{
+			name:    "nothing remembered",
+			in:      WindowState{},
+			screens: []Screen{laptop},
+			want:    WindowState{Width: DefaultWindowWidth, Height: DefaultWindowHeight},
+		},
+		{
+			name:    "same screen",
+			in:      WindowState{X: 1800, Y: 100, Width: 1200, Height: 800, ScreenWidth: 2560, ScreenHeight: 1440},
+			screens: []Screen{laptop, monitor},
+			want:    WindowState{X: 1360, Y: 100, Width: 1200, Height: 800, ScreenWidth: 2560, ScreenHeight: 1440},
+			apply:   true,
+		},
+		{
+			name:    "monitor gone",
+			in:      WindowState{X: 1800, Y: 600, Width: 2000, Height: 1200, ScreenWidth: 2560, ScreenHeight: 1440},
+			screens: []Screen{laptop},
+			want:    WindowState{X: 0, Y: 0, Width: 1440, Height: 900, ScreenWidth: 1440, ScreenHeight: 900},
+			apply:   true,
+		},
+		{
+			name:    "centered on current",
+			in:      WindowState{X: -50, Y: -50, Width: 1040, Height: 700, ScreenWidth: 1920, ScreenHeight: 1080},
+			screens: []Screen{laptop},
+			want:    WindowState{X: 200, Y: 100, Width: 1040, Height: 700, ScreenWidth: 1440, ScreenHeight: 900},
+			apply:   true,
+		},
+	}
+	for _, c := range cases {
+		got, apply := c.in.Clamp(c.screens)
+		if got != c.want || apply != c.apply {
+			t.Fatalf("%s: expected %+v %v, got %+v %v", c.name, c.want, c.apply, got, apply)
+		}
+	}
+}
+
+// TestUIStateNormalize ensures invalid tabs and splits are corrected.
+func TestUIStateNormalize(t *testing.T) {
+	u := UIState{
+		Tabs:      []ArticleTab{{Repo: "/a", ID: "1"}, {Repo: "", ID: "2"}},
+		ActiveTab: 3,
+		Splits:    map[string]float64{"sidebar": 1.5, "preview": -1, "editor": 0.4},
+	}
+	u.Normalize()
+	if len(u.Tabs) != 1 || u.ActiveTab != 0 {
+		t.Fatalf("unexpected tabs: %+v", u)
+	}
+	if u.Splits["sidebar"] != 1 || u.Splits["preview"] != 0 || u.Splits["editor"] != 0.4 {
+		t.Fatalf("unexpected splits: %+v", u.Splits)
+	}
+}
+
+// TestUIStatePanelVisible ensures panels default to visible.
+func TestUIStatePanelVisible(t *testing.T) {
+	u := UIState{Panels: map[string]bool{"preview": false}}
+	if !u.PanelVisible("sidebar") || u.PanelVisible("preview") {
+		t.Fatalf("unexpected visibility: %+v", u.Panels)
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025 blog-writer authors
+
+// Package events provides an in-process publish/subscribe bus that services
+// use to announce state changes without knowing who listens. The main
+// package bridges the bus to the frontend as a single Wails event stream.
+package events
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"blog-writer/internal/logging"
+)
+
+// Topic names a kind of event. Topics are dot-separated, most general
+// segment first, so subscribers can match a whole family with a prefix.
+type Topic string
+
+// Topics published by the services.
+const (
+	// ArticleSaved is published after an article is written; Data is an
+	// ArticleSavedData.
+	ArticleSaved Topic = "article.saved"
+	// GitStatusChanged is published after a git operation changes the
+	// working tree or history; Data is a GitChange.
+	GitStatusChanged Topic = "git.status.changed"
+	// GitBranchChanged is published after the current branch changes; Data
+	// is a GitChange.
+	GitBranchChanged Topic = "git.branch.changed"
+	// SettingsUpdated is published after repository settings are written;
+	// Data is the new services.Settings.
+	SettingsUpdated Topic = "settings.updated"
+	// RepoOpened is published after a repository is opened; Data is nil.
+	RepoOpened Topic = "repo.opened"
+	// RepoProgress is published for each step of creating a repository;
+	// Data is a services.RepoProgress.
+	RepoProgress Topic = "repo.progress"
+	// RepoLocked is published when an opened repository is already open in
+	// another process; Data is a services.RepoLockWarning.
+	RepoLocked Topic = "repo.locked"
+	// GitProgress is published with transfer progress parsed from git's
+	// --progress output; Data is a services.GitProgress.
+	GitProgress Topic = "git.progress"
+	// KeybindingsChanged is published after the keyboard shortcuts change;
+	// Data is the new []keymap.Binding.
+	KeybindingsChanged Topic = "keybindings.changed"
+	// RepoChanged is published when the workspace watcher notices articles
+	// changing on disk; Data is nil.
+	RepoChanged Topic = "repo.changed"
+	// WorkspaceActiveChanged is published when the active workspace
+	// repository changes; Data is nil.
+	WorkspaceActiveChanged Topic = "workspace.active.changed"
+)
+
+// WailsEvent is the name of the frontend event every bus event is
+// forwarded on.
+const WailsEvent = "app:event"
+
+// Event is a single notification published on a Bus.
+type Event struct {
+	Topic Topic     `json:"topic"`
+	Repo  string    `json:"repo,omitempty"`
+	Time  time.Time `json:"time"`
+	Data  any       `json:"data,omitempty"`
+}
+
+// ArticleSavedData describes an ArticleSaved event.
+type ArticleSavedData struct {
+	ID        string `json:"id"`
+	Path      string `json:"path"`
+	Created   bool   `json:"created"`
+	Committed bool   `json:"committed"`
+}
+
+// GitChange describes a GitStatusChanged or GitBranchChanged event.
+type GitChange struct {
+	Op     string `json:"op"`
+	Branch string `json:"branch,omitempty"`
+}
+
+// Handler receives published events.
+type Handler func(Event)
+
+// subscription is a registered handler and the pattern it matches.
+type subscription struct {
+	id      int
+	pattern string
+	fn      Handler
+}
+
+// Bus delivers published events to matching subscribers. The zero value is
+// ready to use and a nil *Bus discards everything, so services can publish
+// unconditionally.
+type Bus struct {
+	mu     sync.Mutex
+	nextID int
+	subs   []subscription
+}
+
+// New returns an empty Bus.
+func New() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers fn for events whose topic matches pattern and returns
+// a function that removes the subscription. A pattern is an exact topic,
+// a prefix ending in ".*" such as "git.*", or "*" for every event.
+func (b *Bus) Subscribe(pattern string, fn Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.nextID++
+	id := b.nextID
+	b.subs = append(b.subs, subscription{id: id, pattern: pattern, fn: fn})
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for i, s := range b.subs {
+			if s.id == id {
+				b.subs = append(b.subs[:i:i], b.subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish delivers an event with topic, repo and data to every matching
+// subscriber, synchronously and in subscription order. A panicking
+// subscriber is logged and does not prevent delivery to the others.
+func (b *Bus) Publish(topic Topic, repo string, data any) {
+	if b == nil {
+		return
+	}
+	e := Event{Topic: topic, Repo: repo, Time: time.Now().UTC(), Data: data}
+	b.mu.Lock()
+	subs := make([]subscription, 0, len(b.subs))
+	for _, s := range b.subs {
+		if Match(s.pattern, topic) {
+			subs = append(subs, s)
+		}
+	}
+	b.mu.Unlock()
+	for _, s := range subs {
+		deliver(s.fn, e)
+	}
+}
+
+// deliver calls fn with e, recovering from panics.
+func deliver(fn Handler, e Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			logging.For(logging.App).Error("event handler panicked", "topic", e.Topic, "panic", r)
+		}
+	}()
+	fn(e)
+}
+
+// Match reports whether topic matches the subscription pattern.
+func Match(pattern string, topic Topic) bool {
+	if pattern == "*" || pattern == string(topic) {
+		return true
+	}
+	prefix, ok := strings.CutSuffix(pattern, "*")
+	return ok && strings.HasSuffix(prefix, ".") && strings.HasPrefix(string(topic), prefix)
+}
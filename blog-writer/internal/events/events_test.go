@@ -0,0 +1,65 @@
+// Copyright (c) 2025 blog-writer authors
+package events
+
+import "testing"
+
+// TestMatch covers exact, prefix and wildcard patterns.
+func TestMatch(t *testing.T) {
+	cases := []struct {
+		pattern string
+		topic   Topic
+		want    bool
+	}{
+		{"*", ArticleSaved, true},
+		{"article.saved", ArticleSaved, true},
+		{"article.saved", GitStatusChanged, false},
+		{"git.*", GitStatusChanged, true},
+		{"git.*", GitBranchChanged, true},
+		{"git.*", ArticleSaved, false},
+		{"gi*", GitStatusChanged, false},
+	}
+	for _, c := range cases {
+		if got := Match(c.pattern, c.topic); got != c.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", c.pattern, c.topic, got, c.want)
+		}
+	}
+}
+
+// TestBusPublish ensures matching subscribers receive events in order and
+// unsubscribed handlers do not.
+func TestBusPublish(t *testing.T) {
+	b := New()
+	var got []string
+	b.Subscribe("*", func(e Event) { got = append(got, "all:"+string(e.Topic)) })
+	stop := b.Subscribe("git.*", func(e Event) { got = append(got, "git:"+e.Repo) })
+	b.Publish(GitStatusChanged, "/r", GitChange{Op: "pull"})
+	stop()
+	b.Publish(GitBranchChanged, "/r", GitChange{Op: "checkout"})
+	want := []string{"all:git.status.changed", "git:/r", "all:git.branch.changed"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+// TestBusPanic ensures a panicking subscriber does not block the others.
+func TestBusPanic(t *testing.T) {
+	b := New()
+	called := false
+	b.Subscribe("*", func(Event) { panic("boom") })
+	b.Subscribe("*", func(Event) { called = true })
+	b.Publish(SettingsUpdated, "", nil)
+	if !called {
+		t.Fatal("second subscriber not called")
+	}
+}
+
+// TestNilBus ensures publishing on a nil bus is a no-op.
+func TestNilBus(t *testing.T) {
+	var b *Bus
+	b.Publish(ArticleSaved, "", nil)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025 blog-writer authors
+
+// Package fsutil provides crash-safe filesystem helpers.
+package fsutil
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"blog-writer/internal/logging"
+)
+
+// log reports failed writes.
+var log = logging.For(logging.FS)
+
+// WriteFileAtomic writes data to path so that readers observe either the old
+// contents or the new contents, never a truncated file.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	return WriteAtomic(path, bytes.NewReader(data), perm)
+}
+
+// WriteAtomic streams r into a temporary file in the same directory as path,
+// fsyncs it, renames it over path and finally fsyncs the directory. On any
+// failure the temporary file is removed and path is left untouched.
+func WriteAtomic(path string, r io.Reader, perm os.FileMode) (err error) {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmpName)
+			log.Warn("atomic write failed", "path", path, "err", err)
+		}
+	}()
+	if _, err = io.Copy(tmp, r); err != nil {
+		return err
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpName, path); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+// syncDir flushes directory metadata so a completed rename survives a crash.
+// Windows does not support fsync on directories, so it is a no-op there.
+func syncDir(dir string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
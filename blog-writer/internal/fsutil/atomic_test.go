@@ -0,0 +1,105 @@
+// Copyright (c) 2025 blog-writer authors
+package fsutil
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// failingReader yields n bytes then fails, simulating a write interrupted mid-stream.
+type failingReader struct {
+	data []byte
+	n    int
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	if f.n <= 0 {
+		return 0, errors.New("simulated crash")
+	}
+	if len(p) > f.n {
+		p = p[:f.n]
+	}
+	c := copy(p, f.data)
+	f.data = f.data[c:]
+	f.n -= c
+	return c, nil
+}
+
+// TestWriteFileAtomic verifies a successful write replaces the contents.
+func TestWriteFileAtomic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.json")
+	if err := os.WriteFile(path, []byte("old"), 0o644); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	if err := WriteFileAtomic(path, []byte("new"), 0o644); err != nil {
+		t.Fatalf("WriteFileAtomic: %v", err)
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(got) != "new" {
+		t.Fatalf("expected new, got %q", got)
+	}
+	assertNoTemp(t, filepath.Dir(path))
+}
+
+// TestWriteAtomicPartialWrite ensures an interrupted write leaves the original file intact.
+func TestWriteAtomicPartialWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	original := "recently_opened:\n  - /a\n"
+	if err := os.WriteFile(path, []byte(original), 0o644); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	r := &failingReader{data: []byte(strings.Repeat("x", 64)), n: 10}
+	if err := WriteAtomic(path, r, 0o644); err == nil {
+		t.Fatal("expected error from interrupted write")
+	}
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if string(got) != original {
+		t.Fatalf("original content modified: %q", got)
+	}
+	assertNoTemp(t, filepath.Dir(path))
+}
+
+// TestWriteAtomicPartialWriteNewFile ensures an interrupted write never creates the target.
+func TestWriteAtomicPartialWriteNewFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "1755288225.json")
+	r := io.MultiReader(strings.NewReader(`{"version":`), &failingReader{})
+	if err := WriteAtomic(path, r, 0o644); err == nil {
+		t.Fatal("expected error from interrupted write")
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected target to be absent, got %v", err)
+	}
+	assertNoTemp(t, filepath.Dir(path))
+}
+
+// TestWriteFileAtomicMissingDir ensures errors surface when the directory does not exist.
+func TestWriteFileAtomicMissingDir(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing", "file.json")
+	if err := WriteFileAtomic(path, []byte("x"), 0o644); err == nil {
+		t.Fatal("expected error for missing directory")
+	}
+}
+
+// assertNoTemp fails if any temporary files remain in dir.
+func assertNoTemp(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("readdir: %v", err)
+	}
+	for _, e := range entries {
+		if strings.Contains(e.Name(), ".tmp-") {
+			t.Fatalf("temporary file left behind: %s", e.Name())
+		}
+	}
+}
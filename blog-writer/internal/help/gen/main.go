@@ -0,0 +1,54 @@
+// Copyright (c) 2025 blog-writer authors
+
+// Command gen copies the repository's docs/*.md into internal/help/docs so
+// they can be embedded. It replaces a shell cp so that
+// "go generate ./internal/help" also works on Windows.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"blog-writer/internal/fsutil"
+)
+
+// main is the entry point for the help docs generator.
+func main() {
+	src := flag.String("src", filepath.Join("..", "..", "..", "docs"), "directory holding the Markdown docs")
+	dst := flag.String("dst", "docs", "directory receiving the copies")
+	flag.Parse()
+	if _, err := copyDocs(*src, *dst); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// copyDocs copies every *.md file in src to dst and returns the names of
+// the copied files.
+func copyDocs(src, dst string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(src, "*.md"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Markdown files in %s", src)
+	}
+	if err := os.MkdirAll(dst, 0o755); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		name := filepath.Base(f)
+		if err := fsutil.WriteFileAtomic(filepath.Join(dst, name), b, 0o644); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2025 blog-writer authors
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestCopyDocs ensures only Markdown files are copied, byte for byte.
+func TestCopyDocs(t *testing.T) {
+	src := t.TempDir()
+	dst := filepath.Join(t.TempDir(), "docs")
+	for name, body := range map[string]string{"README.md": "# Docs\r\n", "guide.md": "text", "notes.txt": "skip"} {
+		if err := os.WriteFile(filepath.Join(src, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	names, err := copyDocs(src, dst)
+	if err != nil {
+		t.Fatalf("copyDocs: %v", err)
+	}
+	if !slices.Equal(names, []string{"README.md", "guide.md"}) {
+		t.Fatalf("unexpected files %v", names)
+	}
+	b, err := os.ReadFile(filepath.Join(dst, "README.md"))
+	if err != nil || string(b) != "# Docs\r\n" {
+		t.Fatalf("README.md = %q, %v", b, err)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "notes.txt")); !os.IsNotExist(err) {
+		t.Fatalf("notes.txt should not be copied: %v", err)
+	}
+	if _, err := copyDocs(t.TempDir(), dst); err == nil {
+		t.Fatal("expected an error for a directory without docs")
+	}
+}
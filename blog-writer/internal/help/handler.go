@@ -0,0 +1,103 @@
+// Copyright (c) 2025 blog-writer authors
+package help
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// Prefix is the URL path under which Handler serves the documentation.
+const Prefix = "/help/"
+
+// layout is the page shell with the table of contents and search box.
+var layout = template.Must(template.New("help").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}} – Blog Writer Help</title>
+<style>
+body{margin:0;display:flex;font:15px/1.5 system-ui,sans-serif;color:#1b2636;background:#fff}
+nav{width:16rem;flex:none;height:100vh;overflow:auto;padding:1rem;box-sizing:border-box;background:#f3f5f8;border-right:1px solid #dde2ea}
+nav ul{list-style:none;padding-left:0;margin:.25rem 0}nav ul ul{padding-left:1rem;font-size:.9em}
+nav a{color:inherit;text-decoration:none}nav a.current{font-weight:600}
+nav input{width:100%;box-sizing:border-box;padding:.3rem}
+main{flex:1;height:100vh;overflow:auto;padding:1rem 2rem;box-sizing:border-box;max-width:60rem}
+pre{background:#f3f5f8;padding:.75rem;overflow:auto}code{font-family:ui-monospace,monospace}
+table{border-collapse:collapse}td,th{border:1px solid #dde2ea;padding:.25rem .5rem}
+.result p{margin:.25rem 0 1rem}footer{margin-top:2rem;font-size:.85em;color:#5b6678}
+</style>
+</head>
+<body>
+<nav>
+<form action="/help/search"><input type="search" name="q" value="{{.Query}}" placeholder="Search help"></form>
+<ul>
+{{- range .Pages}}
+<li><a href="/help/{{.Name}}"{{if eq .Name $.Current}} class="current"{{end}}>{{.Title}}</a>
+{{- if eq .Name $.Current}}
+<ul>{{range .Headings}}{{if and (gt .Level 1) (lt .Level 4)}}<li><a href="#{{.ID}}">{{.Text}}</a></li>{{end}}{{end}}</ul>
+{{- end}}</li>
+{{- end}}
+</ul>
+</nav>
+<main>
+{{- if .Results}}
+<h1>Search results for “{{.Query}}”</h1>
+{{range .Results}}<div class="result"><a href="/help/{{.Page}}#{{.Anchor}}">{{.Title}} › {{.Section}}</a><p>{{.Snippet}}</p></div>
+{{end}}
+{{- else if .Query}}
+<h1>No results for “{{.Query}}”</h1>
+{{- else}}
+{{.Body}}
+<footer><a href="{{.Online}}" target="_blank" rel="noopener">View this page online</a></footer>
+{{- end}}
+</main>
+</body>
+</html>
+`))
+
+// view is the data passed to layout.
+type view struct {
+	Title   string
+	Current string
+	Pages   []Page
+	Body    template.HTML
+	Online  string
+	Query   string
+	Results []SearchResult
+}
+
+// Handler serves the documentation: Prefix shows the index, Prefix+name a
+// page and Prefix+"search?q=" search results.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, Prefix)
+		if !strings.HasPrefix(r.URL.Path, Prefix) {
+			http.NotFound(w, r)
+			return
+		}
+		v := view{Pages: Pages()}
+		switch name {
+		case "search":
+			v.Title = "Search"
+			v.Query = strings.TrimSpace(r.URL.Query().Get("q"))
+			v.Results = Search(v.Query)
+		default:
+			if name == "" {
+				name = "index"
+			}
+			page, err := Load(name)
+			if err != nil {
+				http.NotFound(w, r)
+				return
+			}
+			v.Title, v.Current, v.Online = page.Title, page.Name, OnlineURL+page.Source
+			v.Body = template.HTML(page.HTML) // rendered from trusted, escaped Markdown
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Security-Policy", "default-src 'self'; style-src 'unsafe-inline'")
+		if err := layout.Execute(w, v); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
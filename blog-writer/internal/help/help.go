@@ -0,0 +1,208 @@
+// Copyright (c) 2025 blog-writer authors
+
+// Package help bundles the user documentation into the binary and renders
+// it to HTML for the in-app help viewer, so help works offline.
+package help
+
+//go:generate go run ./gen
+
+import (
+	"embed"
+	"errors"
+	"path"
+	"strings"
+)
+
+// docsFS holds copies of the repository's docs/*.md files. Run
+// "go generate ./internal/help" after editing the documentation.
+//
+//go:embed docs/*.md
+var docsFS embed.FS
+
+// OnlineURL is the published documentation, used as a fallback when the
+// user prefers the browser.
+const OnlineURL = "https://github.com/asymmetric-effort/blog-writer/blob/main/docs/"
+
+// ErrNotFound is returned for unknown pages.
+var ErrNotFound = errors.New("help page not found")
+
+// pageOrder lists the pages in table-of-contents order. "index" is the
+// docs README.
+var pageOrder = []struct{ name, file string }{
+	{"index", "README.md"},
+	{"user-guide", "user-guide.md"},
+	{"developer-guide", "developer-guide.md"},
+	{"build-and-test", "build-and-test.md"},
+}
+
+// Page is a rendered documentation page.
+type Page struct {
+	Name     string    `json:"name"`
+	Title    string    `json:"title"`
+	HTML     string    `json:"html"`
+	Headings []Heading `json:"headings"`
+	// Source is the Markdown file name, used to link to the online copy.
+	Source string `json:"source"`
+
+	text string
+}
+
+// SearchResult is a section matching a search query.
+type SearchResult struct {
+	Page    string `json:"page"`
+	Title   string `json:"title"`
+	Section string `json:"section"`
+	Anchor  string `json:"anchor"`
+	Snippet string `json:"snippet"`
+}
+
+// File returns the Markdown file name of the page name, falling back to
+// the docs README for unknown pages.
+func File(name string) string {
+	for _, p := range pageOrder {
+		if p.name == name {
+			return p.file
+		}
+	}
+	return pageOrder[0].file
+}
+
+// Pages returns every page in table-of-contents order.
+func Pages() []Page {
+	out := make([]Page, 0, len(pageOrder))
+	for _, p := range pageOrder {
+		if page, err := Load(p.name); err == nil {
+			out = append(out, page)
+		}
+	}
+	return out
+}
+
+// Load renders the page with the given name.
+func Load(name string) (Page, error) {
+	for _, p := range pageOrder {
+		if p.name != name {
+			continue
+		}
+		src, err := docsFS.ReadFile(path.Join("docs", p.file))
+		if err != nil {
+			return Page{}, err
+		}
+		body, headings := Render(string(src), Link)
+		page := Page{Name: name, Title: name, HTML: body, Headings: headings, Source: p.file, text: string(src)}
+		if len(headings) > 0 {
+			page.Title = headings[0].Text
+		}
+		return page, nil
+	}
+	return Page{}, ErrNotFound
+}
+
+// Link rewrites links between documentation files to help viewer routes,
+// e.g. "user-guide.md#menus" becomes "/help/user-guide#menus". Other links
+// are returned unchanged.
+func Link(href string) string {
+	file, anchor, _ := strings.Cut(href, "#")
+	if strings.Contains(file, "://") || !strings.HasSuffix(file, ".md") {
+		return href
+	}
+	for _, p := range pageOrder {
+		if path.Base(file) == p.file && !strings.Contains(file, "..") {
+			if anchor != "" {
+				return "/help/" + p.name + "#" + anchor
+			}
+			return "/help/" + p.name
+		}
+	}
+	return strings.TrimSuffix(OnlineURL, "docs/") + strings.TrimPrefix(path.Clean(path.Join("docs", file)), "/")
+}
+
+// Search returns the sections of all pages containing every word of query,
+// ignoring case.
+func Search(query string) []SearchResult {
+	words := strings.Fields(strings.ToLower(query))
+	results := []SearchResult{}
+	if len(words) == 0 {
+		return results
+	}
+	for _, page := range Pages() {
+		for _, sec := range sections(page) {
+			lower := strings.ToLower(sec.text)
+			match := true
+			for _, w := range words {
+				if !strings.Contains(lower, w) {
+					match = false
+					break
+				}
+			}
+			if match {
+				results = append(results, SearchResult{
+					Page:    page.Name,
+					Title:   page.Title,
+					Section: sec.heading.Text,
+					Anchor:  sec.heading.ID,
+					Snippet: snippet(sec.text, strings.Index(lower, words[0])),
+				})
+			}
+		}
+	}
+	return results
+}
+
+// section is the plain text below one heading.
+type section struct {
+	heading Heading
+	text    string
+}
+
+// sections splits the page source at its headings. Headings are matched to
+// the rendered ones in order so anchors line up.
+func sections(p Page) []section {
+	var out []section
+	cur := section{heading: Heading{Text: p.Title}}
+	var text []string
+	n := 0
+	inFence := false
+	for _, line := range strings.Split(commentRe.ReplaceAllString(p.text, ""), "\n") {
+		t := strings.TrimSpace(line)
+		if strings.HasPrefix(t, "```") || strings.HasPrefix(t, "~~~") {
+			inFence = !inFence
+		}
+		if !inFence && headingRe.MatchString(line) && n < len(p.Headings) {
+			cur.text = strings.Join(text, " ")
+			out = append(out, cur)
+			cur, text = section{heading: p.Headings[n]}, nil
+			n++
+			continue
+		}
+		if t != "" {
+			text = append(text, plainText(t))
+		}
+	}
+	cur.text = strings.Join(text, " ")
+	out = append(out, cur)
+	return out
+}
+
+// snippet returns up to 60 bytes of context around pos.
+func snippet(text string, pos int) string {
+	if pos < 0 {
+		pos = 0
+	}
+	start := max(pos-60, 0)
+	end := min(pos+60, len(text))
+	for start > 0 && text[start]&0xC0 == 0x80 {
+		start--
+	}
+	for end < len(text) && text[end]&0xC0 == 0x80 {
+		end++
+	}
+	s := strings.TrimSpace(text[start:end])
+	if start > 0 {
+		s = "…" + s
+	}
+	if end < len(text) {
+		s += "…"
+	}
+	return s
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025 blog-writer authors
+package help
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestDocsInSync verifies the embedded copies match the repository docs.
+// Run `go generate ./internal/help` after editing docs/.
+func TestDocsInSync(t *testing.T) {
+	for _, p := range pageOrder {
+		want, err := os.ReadFile(filepath.Join("..", "..", "..", "docs", p.file))
+		if errors.Is(err, os.ErrNotExist) {
+			t.Skip("repository docs not available")
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := docsFS.ReadFile("docs/" + p.file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is out of date; run go generate ./internal/help", p.file)
+		}
+	}
+}
+
+// TestPages verifies every page loads with a title.
+func TestPages(t *testing.T) {
+	pages := Pages()
+	if len(pages) != len(pageOrder) {
+		t.Fatalf("expected %d pages, got %d", len(pageOrder), len(pages))
+	}
+	for _, p := range pages {
+		if p.Title == "" || p.HTML == "" {
+			t.Errorf("page %s is empty", p.Name)
+		}
+	}
+	if _, err := Load("missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestLink verifies documentation links map to help routes.
+func TestLink(t *testing.T) {
+	cases := map[string]string{
+		"user-guide.md":         "/help/user-guide",
+		"./user-guide.md#menus": "/help/user-guide#menus",
+		"README.md":             "/help/index",
+		"../README.md":          "https://github.com/asymmetric-effort/blog-writer/blob/main/README.md",
+		"https://example.com":   "https://example.com",
+		"#local":                "#local",
+	}
+	for in, want := range cases {
+		if got := Link(in); got != want {
+			t.Errorf("Link(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// TestFile verifies page names map to Markdown files.
+func TestFile(t *testing.T) {
+	if got := File("user-guide"); got != "user-guide.md" {
+		t.Fatalf("unexpected file %q", got)
+	}
+	if got := File("missing"); got != "README.md" {
+		t.Fatalf("unexpected fallback %q", got)
+	}
+}
+
+// TestSearch verifies search finds sections containing every word.
+func TestSearch(t *testing.T) {
+	results := Search("shortcut Save")
+	if len(results) == 0 {
+		t.Fatal("expected results")
+	}
+	for _, r := range results {
+		if r.Page == "" || r.Snippet == "" {
+			t.Fatalf("incomplete result %+v", r)
+		}
+	}
+	if len(Search("")) != 0 || len(Search("zzqxnotaword")) != 0 {
+		t.Fatal("expected no results")
+	}
+}
+
+// TestHandler verifies the help pages, search and unknown routes.
+func TestHandler(t *testing.T) {
+	h := Handler()
+	get := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+	rec := get("/help/user-guide")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), OnlineURL+"user-guide.md") {
+		t.Fatalf("unexpected page response %d", rec.Code)
+	}
+	if rec.Header().Get("Content-Security-Policy") == "" {
+		t.Fatal("missing content security policy")
+	}
+	if rec := get("/help/"); rec.Code != http.StatusOK {
+		t.Fatalf("index returned %d", rec.Code)
+	}
+	if rec := get("/help/search?q=commit"); rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "/help/") {
+		t.Fatalf("search returned %d", rec.Code)
+	}
+	if rec := get("/help/missing"); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+	if rec := get("/other"); rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
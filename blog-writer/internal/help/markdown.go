@@ -0,0 +1,386 @@
+// Copyright (c) 2025 blog-writer authors
+package help
+
+import (
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Heading is a section heading of a rendered document.
+type Heading struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+	ID    string `json:"id"`
+}
+
+// LinkFunc rewrites link targets while rendering.
+type LinkFunc func(href string) string
+
+var (
+	commentRe   = regexp.MustCompile(`(?s)<!--.*?-->`)
+	headingRe   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	ruleRe      = regexp.MustCompile(`^\s{0,3}(\*\s*){3,}$|^\s{0,3}(-\s*){3,}$|^\s{0,3}(_\s*){3,}$`)
+	itemRe      = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	tableSepRe  = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	plainLinkRe = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+)
+
+// Render converts the Markdown subset used by the documentation to HTML:
+// ATX headings, paragraphs, nested lists, block quotes, fenced code,
+// tables, rules, and inline code, emphasis, links and images. Raw HTML is
+// escaped and comments are dropped. Headings get unique IDs and are
+// returned for building a table of contents.
+func Render(src string, link LinkFunc) (string, []Heading) {
+	if link == nil {
+		link = func(h string) string { return h }
+	}
+	r := &renderer{out: &strings.Builder{}, link: link, ids: map[string]int{}}
+	src = commentRe.ReplaceAllString(strings.ReplaceAll(src, "\r\n", "\n"), "")
+	r.blocks(strings.Split(src, "\n"))
+	return r.out.String(), r.headings
+}
+
+// renderer accumulates HTML output.
+type renderer struct {
+	out      *strings.Builder
+	headings []Heading
+	ids      map[string]int
+	link     LinkFunc
+}
+
+// blocks renders a sequence of block-level lines.
+func (r *renderer) blocks(lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			i++
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			i = r.fence(lines, i)
+		case headingRe.MatchString(line):
+			m := headingRe.FindStringSubmatch(line)
+			r.heading(len(m[1]), m[2])
+			i++
+		case ruleRe.MatchString(line):
+			r.out.WriteString("<hr>\n")
+			i++
+		case strings.HasPrefix(trimmed, ">"):
+			i = r.quote(lines, i)
+		case strings.HasPrefix(trimmed, "|") && i+1 < len(lines) && tableSepRe.MatchString(lines[i+1]):
+			i = r.table(lines, i)
+		case itemRe.MatchString(line):
+			i = r.list(lines, i)
+		default:
+			i = r.paragraph(lines, i)
+		}
+	}
+}
+
+// heading renders a heading and records it.
+func (r *renderer) heading(level int, text string) {
+	plain := plainText(text)
+	id := slug(plain)
+	if n := r.ids[id]; n > 0 {
+		r.ids[id]++
+		id += "-" + strconv.Itoa(n)
+	} else {
+		r.ids[id] = 1
+	}
+	r.headings = append(r.headings, Heading{Level: level, Text: plain, ID: id})
+	tag := "h" + strconv.Itoa(level)
+	r.out.WriteString("<" + tag + ` id="` + id + `">` + r.inline(text) + "</" + tag + ">\n")
+}
+
+// fence renders a fenced code block starting at lines[i].
+func (r *renderer) fence(lines []string, i int) int {
+	open := strings.TrimSpace(lines[i])
+	marker := open[:3]
+	lang := strings.TrimSpace(strings.TrimLeft(open, marker[:1]))
+	var code []string
+	for i++; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), marker) {
+			i++
+			break
+		}
+		code = append(code, lines[i])
+	}
+	r.out.WriteString("<pre><code")
+	if lang != "" {
+		r.out.WriteString(` class="language-` + html.EscapeString(lang) + `"`)
+	}
+	r.out.WriteString(">" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+	return i
+}
+
+// quote renders a block quote starting at lines[i].
+func (r *renderer) quote(lines []string, i int) int {
+	var inner []string
+	for ; i < len(lines); i++ {
+		t := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(t, ">") {
+			break
+		}
+		inner = append(inner, strings.TrimPrefix(strings.TrimPrefix(t, ">"), " "))
+	}
+	r.out.WriteString("<blockquote>\n")
+	r.blocks(inner)
+	r.out.WriteString("</blockquote>\n")
+	return i
+}
+
+// table renders a pipe table starting at lines[i].
+func (r *renderer) table(lines []string, i int) int {
+	r.out.WriteString("<table>\n<thead><tr>")
+	for _, c := range cells(lines[i]) {
+		r.out.WriteString("<th>" + r.inline(c) + "</th>")
+	}
+	r.out.WriteString("</tr></thead>\n<tbody>\n")
+	for i += 2; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+		r.out.WriteString("<tr>")
+		for _, c := range cells(lines[i]) {
+			r.out.WriteString("<td>" + r.inline(c) + "</td>")
+		}
+		r.out.WriteString("</tr>\n")
+	}
+	r.out.WriteString("</tbody>\n</table>\n")
+	return i
+}
+
+// cells splits a table row into trimmed cells.
+func cells(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	parts := strings.Split(row, "|")
+	for i, p := range parts {
+		parts[i] = strings.TrimSpace(p)
+	}
+	return parts
+}
+
+// list renders a list starting at lines[i], including nested lists, and
+// returns the index of the first line after it.
+func (r *renderer) list(lines []string, i int) int {
+	m := itemRe.FindStringSubmatch(lines[i])
+	indent := len(m[1])
+	ordered := m[2][0] >= '0' && m[2][0] <= '9'
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+		if n, err := strconv.Atoi(strings.TrimRight(m[2], ".)")); err == nil && n != 1 {
+			r.out.WriteString(`<ol start="` + strconv.Itoa(n) + `">` + "\n")
+		} else {
+			r.out.WriteString("<ol>\n")
+		}
+	} else {
+		r.out.WriteString("<ul>\n")
+	}
+	for i < len(lines) {
+		m := itemRe.FindStringSubmatch(lines[i])
+		if m == nil || len(m[1]) != indent {
+			break
+		}
+		text := []string{m[3]}
+		i++
+		var nested strings.Builder
+		for i < len(lines) {
+			line := lines[i]
+			if strings.TrimSpace(line) == "" {
+				j := i
+				for j < len(lines) && strings.TrimSpace(lines[j]) == "" {
+					j++
+				}
+				if j < len(lines) && leading(lines[j]) > indent {
+					i = j
+					continue
+				}
+				break
+			}
+			if sub := itemRe.FindStringSubmatch(line); sub != nil {
+				if len(sub[1]) <= indent {
+					break
+				}
+				saved := r.out
+				r.out = &nested
+				i = r.list(lines, i)
+				r.out = saved
+				continue
+			}
+			if leading(line) <= indent {
+				break
+			}
+			text = append(text, strings.TrimSpace(line))
+			i++
+		}
+		r.out.WriteString("<li>" + r.inline(strings.Join(text, " ")))
+		if nested.Len() > 0 {
+			r.out.WriteString("\n" + nested.String())
+		}
+		r.out.WriteString("</li>\n")
+		// Skip blank lines between items of the same list.
+		j := i
+		for j < len(lines) && strings.TrimSpace(lines[j]) == "" {
+			j++
+		}
+		if j < len(lines) && j != i {
+			if m := itemRe.FindStringSubmatch(lines[j]); m != nil && len(m[1]) == indent {
+				i = j
+			}
+		}
+	}
+	r.out.WriteString("</" + tag + ">\n")
+	return i
+}
+
+// paragraph renders consecutive text lines as a paragraph.
+func (r *renderer) paragraph(lines []string, i int) int {
+	var text []string
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		t := strings.TrimSpace(line)
+		if t == "" || headingRe.MatchString(line) || strings.HasPrefix(t, "```") || strings.HasPrefix(t, ">") || (len(text) > 0 && itemRe.MatchString(line)) {
+			break
+		}
+		text = append(text, t)
+	}
+	r.out.WriteString("<p>" + r.inline(strings.Join(text, "\n")) + "</p>\n")
+	return i
+}
+
+// inline renders inline Markdown in s.
+func (r *renderer) inline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && strings.IndexByte("\\`*_[]()#+-.!|<>", s[i+1]) >= 0:
+			b.WriteString(html.EscapeString(s[i+1 : i+2]))
+			i += 2
+			continue
+		case c == '`':
+			if end := strings.IndexByte(s[i+1:], '`'); end >= 0 {
+				b.WriteString("<code>" + html.EscapeString(s[i+1:i+1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+		case c == '!' && i+1 < len(s) && s[i+1] == '[':
+			if text, href, n, ok := linkAt(s[i+1:]); ok {
+				b.WriteString(`<img src="` + html.EscapeString(r.link(href)) + `" alt="` + html.EscapeString(text) + `">`)
+				i += n + 1
+				continue
+			}
+		case c == '[':
+			if text, href, n, ok := linkAt(s[i:]); ok {
+				target := r.link(href)
+				b.WriteString(`<a href="` + html.EscapeString(target) + `"`)
+				if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+					b.WriteString(` target="_blank" rel="noopener"`)
+				}
+				b.WriteString(">" + r.inline(text) + "</a>")
+				i += n
+				continue
+			}
+		case (c == '*' || c == '_') && i+1 < len(s) && s[i+1] == c:
+			delim := s[i : i+2]
+			if end := strings.Index(s[i+2:], delim); end > 0 {
+				b.WriteString("<strong>" + r.inline(s[i+2:i+2+end]) + "</strong>")
+				i += end + 4
+				continue
+			}
+		case c == '*' || (c == '_' && (i == 0 || !isWord(s[i-1]))):
+			if end := strings.IndexByte(s[i+1:], c); end > 0 && s[i+1] != ' ' {
+				after := i + 1 + end + 1
+				if c == '*' || after >= len(s) || !isWord(s[after]) {
+					b.WriteString("<em>" + r.inline(s[i+1:i+1+end]) + "</em>")
+					i = after
+					continue
+				}
+			}
+		case c == '\n':
+			b.WriteString("\n")
+			i++
+			continue
+		}
+		b.WriteString(html.EscapeString(s[i : i+1]))
+		i++
+	}
+	return b.String()
+}
+
+// linkAt parses "[text](href)" at the start of s and returns the parts and
+// the number of bytes consumed.
+func linkAt(s string) (text, href string, n int, ok bool) {
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				if i+1 >= len(s) || s[i+1] != '(' {
+					return "", "", 0, false
+				}
+				end := strings.IndexByte(s[i+2:], ')')
+				if end < 0 {
+					return "", "", 0, false
+				}
+				href = strings.TrimSpace(s[i+2 : i+2+end])
+				if sp := strings.IndexByte(href, ' '); sp >= 0 {
+					href = href[:sp]
+				}
+				return s[1:i], href, i + 3 + end, true
+			}
+		}
+	}
+	return "", "", 0, false
+}
+
+// isWord reports whether c is an ASCII letter or digit.
+func isWord(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// leading counts the leading spaces of s, treating a tab as four.
+func leading(s string) int {
+	n := 0
+	for _, c := range s {
+		switch c {
+		case ' ':
+			n++
+		case '\t':
+			n += 4
+		default:
+			return n
+		}
+	}
+	return n
+}
+
+// plainText strips inline Markdown from s.
+func plainText(s string) string {
+	s = plainLinkRe.ReplaceAllString(s, "$1")
+	return strings.NewReplacer("**", "", "__", "", "`", "", "*", "").Replace(s)
+}
+
+// slug derives an anchor ID from heading text.
+func slug(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, c := range strings.ToLower(s) {
+		switch {
+		case unicode.IsLetter(c) || unicode.IsDigit(c):
+			b.WriteRune(c)
+			dash = false
+		case c == ' ' || c == '-' || c == '_':
+			if !dash && b.Len() > 0 {
+				b.WriteByte('-')
+				dash = true
+			}
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
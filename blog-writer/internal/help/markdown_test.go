@@ -0,0 +1,74 @@
+// Copyright (c) 2025 blog-writer authors
+package help
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRenderBlocks verifies the block-level Markdown constructs.
+func TestRenderBlocks(t *testing.T) {
+	src := "# Title\n\nSome *text* with `code`.\n\n## Usage\n\n- one\n- two\n  1. nested\n\n```go\na < b\n```\n\n> quoted\n\n| A | B |\n|---|---|\n| 1 | 2 |\n\n---\n"
+	html, headings := Render(src, nil)
+	for _, want := range []string{
+		`<h1 id="title">Title</h1>`,
+		`<p>Some <em>text</em> with <code>code</code>.</p>`,
+		`<h2 id="usage">Usage</h2>`,
+		`<li>one</li>`,
+		`<ol>`,
+		`a &lt; b`,
+		`<blockquote>`,
+		`<th>A</th>`,
+		`<td>2</td>`,
+		`<hr>`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("missing %q in\n%s", want, html)
+		}
+	}
+	if len(headings) != 2 || headings[1].ID != "usage" || headings[1].Level != 2 {
+		t.Fatalf("unexpected headings %+v", headings)
+	}
+}
+
+// TestRenderUniqueIDs verifies repeated headings get distinct anchors.
+func TestRenderUniqueIDs(t *testing.T) {
+	_, headings := Render("## Notes\n\n## Notes\n", nil)
+	if len(headings) != 2 || headings[0].ID == headings[1].ID {
+		t.Fatalf("expected unique ids, got %+v", headings)
+	}
+}
+
+// TestRenderLinks verifies links are rewritten and external links open in
+// a new window.
+func TestRenderLinks(t *testing.T) {
+	html, _ := Render("See [guide](user-guide.md#menus) and [site](https://example.com).", Link)
+	if !strings.Contains(html, `href="/help/user-guide#menus"`) {
+		t.Fatalf("internal link not rewritten: %s", html)
+	}
+	if !strings.Contains(html, `href="https://example.com"`) || !strings.Contains(html, `target="_blank"`) {
+		t.Fatalf("external link not rendered: %s", html)
+	}
+}
+
+// TestRenderEscapes verifies raw HTML in the source is escaped.
+func TestRenderEscapes(t *testing.T) {
+	html, _ := Render("<script>alert(1)</script>", nil)
+	if strings.Contains(html, "<script>") {
+		t.Fatalf("raw html not escaped: %s", html)
+	}
+}
+
+// TestSlug verifies anchor derivation.
+func TestSlug(t *testing.T) {
+	cases := map[string]string{
+		"Menus and Shortcuts": "menus-and-shortcuts",
+		"Backend (Go)":        "backend-go",
+		"  Trim - me  ":       "trim-me",
+	}
+	for in, want := range cases {
+		if got := slug(in); got != want {
+			t.Errorf("slug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
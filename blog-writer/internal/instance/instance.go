@@ -0,0 +1,126 @@
+// Copyright (c) 2025 blog-writer authors
+
+// Package instance keeps a single running copy of the application. The
+// first instance holds a lock file and listens on a local socket; later
+// launches forward their command-line arguments over the socket and exit.
+//
+// The socket is a unix domain socket on every platform. Windows supports
+// them from Windows 10 version 1803; on older releases Start fails and the
+// application runs without forwarding.
+package instance
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"blog-writer/internal/lockfile"
+)
+
+// File names inside the instance directory.
+const (
+	LockFile   = "instance.lock"
+	SocketFile = "instance.sock"
+)
+
+// ErrForwarded is returned by Start when another instance is running and
+// has received the arguments.
+var ErrForwarded = errors.New("arguments forwarded to running instance")
+
+// dialTimeout bounds how long a second instance waits for the first.
+const dialTimeout = 2 * time.Second
+
+// Message carries the arguments of a later launch.
+type Message struct {
+	Args []string `json:"args"`
+	// Dir is the working directory relative arguments are resolved against.
+	Dir string `json:"dir"`
+}
+
+// Instance is the running primary instance.
+type Instance struct {
+	dir  string
+	ln   net.Listener
+	once sync.Once
+}
+
+// Start makes the calling process the primary instance using lock and
+// socket files in dir. When another live instance holds the lock, msg is
+// sent to it and ErrForwarded is returned.
+func Start(dir string, msg Message) (*Instance, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	lock := filepath.Join(dir, LockFile)
+	sock := filepath.Join(dir, SocketFile)
+	if _, err := lockfile.Acquire(lock); err != nil {
+		if !errors.Is(err, lockfile.ErrLocked) {
+			return nil, err
+		}
+		if err := send(sock, msg); err != nil {
+			return nil, err
+		}
+		return nil, ErrForwarded
+	}
+	// A socket file left by a crashed instance blocks Listen.
+	if err := os.Remove(sock); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, errors.Join(err, lockfile.Release(lock))
+	}
+	ln, err := net.Listen("unix", sock)
+	if err != nil {
+		return nil, errors.Join(err, lockfile.Release(lock))
+	}
+	return &Instance{dir: dir, ln: ln}, nil
+}
+
+// Serve calls handle for each message from later launches until Close.
+func (i *Instance) Serve(handle func(Message)) {
+	for {
+		conn, err := i.ln.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			if err := conn.SetReadDeadline(time.Now().Add(dialTimeout)); err != nil {
+				return
+			}
+			var msg Message
+			if err := json.NewDecoder(conn).Decode(&msg); err != nil {
+				return
+			}
+			handle(msg)
+		}()
+	}
+}
+
+// Close stops listening and releases the lock.
+func (i *Instance) Close() error {
+	var err error
+	i.once.Do(func() {
+		err = i.ln.Close()
+		// Closing the listener usually unlinks the socket already.
+		if rerr := os.Remove(filepath.Join(i.dir, SocketFile)); rerr != nil && !errors.Is(rerr, os.ErrNotExist) {
+			err = errors.Join(err, rerr)
+		}
+		err = errors.Join(err, lockfile.Release(filepath.Join(i.dir, LockFile)))
+	})
+	return err
+}
+
+// send delivers msg to the instance listening on sock.
+func send(sock string, msg Message) error {
+	conn, err := net.DialTimeout("unix", sock, dialTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetWriteDeadline(time.Now().Add(dialTimeout)); err != nil {
+		return err
+	}
+	return json.NewEncoder(conn).Encode(msg)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 blog-writer authors
+package instance
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// shortDir returns a temporary directory with a path short enough for a
+// unix socket.
+func shortDir(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "bw")
+	if err != nil {
+		t.Fatalf("MkdirTemp: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+// TestStartForwards ensures a second launch forwards its arguments to the
+// running instance.
+func TestStartForwards(t *testing.T) {
+	dir := shortDir(t)
+	first, err := Start(dir, Message{})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer first.Close()
+	got := make(chan Message, 1)
+	go first.Serve(func(m Message) { got <- m })
+
+	// The lock belongs to this process, so simulate another owner by
+	// rewriting the lock with the parent process ID.
+	host, _ := os.Hostname()
+	writeLock(t, filepath.Join(dir, LockFile), os.Getppid(), host)
+
+	_, err = Start(dir, Message{Args: []string{"/repo"}, Dir: "/work"})
+	if !errors.Is(err, ErrForwarded) {
+		t.Fatalf("expected ErrForwarded, got %v", err)
+	}
+	select {
+	case m := <-got:
+		if len(m.Args) != 1 || m.Args[0] != "/repo" || m.Dir != "/work" {
+			t.Fatalf("unexpected message: %+v", m)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("message not received")
+	}
+}
+
+// TestStartStale ensures leftover lock and socket files from a crashed
+// instance do not block startup.
+func TestStartStale(t *testing.T) {
+	dir := shortDir(t)
+	host, _ := os.Hostname()
+	writeLock(t, filepath.Join(dir, LockFile), 1<<22, host)
+	if err := os.WriteFile(filepath.Join(dir, SocketFile), nil, 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	inst, err := Start(dir, Message{})
+	if err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if err := inst.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, LockFile)); !os.IsNotExist(err) {
+		t.Fatalf("lock not released: %v", err)
+	}
+}
+
+// writeLock stores a lock file owned by pid on host.
+func writeLock(t *testing.T, path string, pid int, host string) {
+	t.Helper()
+	data := `{"pid":` + strconv.Itoa(pid) + `,"host":"` + host + `","since":"2025-01-01T00:00:00Z"}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatalf("write lock: %v", err)
+	}
+}
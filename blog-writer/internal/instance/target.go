@@ -0,0 +1,78 @@
+// Copyright (c) 2025 blog-writer authors
+package instance
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	"blog-writer/pkg/article"
+)
+
+// ErrNoRepo is returned when a path is not inside a git repository.
+var ErrNoRepo = errors.New("not inside a git repository")
+
+// Target is a repository, and optionally an article in it, to open.
+type Target struct {
+	Repo    string `json:"repo"`
+	Article string `json:"article,omitempty"`
+}
+
+// Targets resolves the arguments of msg, skipping flags and paths that are
+// neither a repository nor an article inside one.
+func Targets(msg Message) []Target {
+	var out []Target
+	for _, arg := range msg.Args {
+		if arg == "" || arg[0] == '-' {
+			continue
+		}
+		if t, err := ParseTarget(msg.Dir, arg); err == nil {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// ParseTarget resolves arg relative to dir. A directory resolves to the
+// repository containing it and an article file (blog/<id>.json) to its
+// repository and ID.
+func ParseTarget(dir, arg string) (Target, error) {
+	p := arg
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(dir, p)
+	}
+	p = filepath.Clean(p)
+	fi, err := os.Stat(p)
+	if err != nil {
+		return Target{}, err
+	}
+	var t Target
+	start := p
+	if !fi.IsDir() {
+		if !article.IsArticleFile(fi.Name()) {
+			return Target{}, errors.New("not an article file")
+		}
+		t.Article = article.ID(p)
+		start = filepath.Dir(p)
+	}
+	repo, err := repoRoot(start)
+	if err != nil {
+		return Target{}, err
+	}
+	t.Repo = repo
+	return t, nil
+}
+
+// repoRoot walks up from dir to the directory containing .git.
+func repoRoot(dir string) (string, error) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ErrNoRepo
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2025 blog-writer authors
+package instance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestTargets ensures repository and article arguments resolve relative to
+// the sender's working directory.
+func TestTargets(t *testing.T) {
+	repo := t.TempDir()
+	for _, d := range []string{".git", filepath.Join("blog", "go")} {
+		if err := os.MkdirAll(filepath.Join(repo, d), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+	art := filepath.Join(repo, "blog", "go", "123.json")
+	if err := os.WriteFile(art, []byte("{}"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	outside := t.TempDir()
+
+	got := Targets(Message{
+		Dir:  repo,
+		Args: []string{"--verbose", repo, filepath.Join("blog", "go", "123.json"), filepath.Join(repo, "blog"), outside, "missing"},
+	})
+	want := []Target{{Repo: repo}, {Repo: repo, Article: "123"}, {Repo: repo}}
+	if len(got) != len(want) {
+		t.Fatalf("expected %+v, got %+v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("target %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 2025 blog-writer authors
+
+// Package keymap defines the keyboard shortcuts of the application, merges
+// user overrides over the defaults and detects conflicting bindings. The
+// same keymap drives the native menu accelerators and the editor.
+package keymap
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/wailsapp/wails/v2/pkg/menu/keys"
+)
+
+// Action is a command that can be bound to a shortcut.
+type Action struct {
+	ID      string `json:"id"`
+	Label   string `json:"label"`
+	Default string `json:"default"`
+}
+
+// Actions lists every bindable action. Menu actions come first, followed by
+// editor commands handled by the frontend.
+var Actions = []Action{
+	{ID: "file.newArticle", Label: "New Article", Default: "CmdOrCtrl+N"},
+	{ID: "file.openRepo", Label: "Open Repository", Default: "CmdOrCtrl+O"},
+	{ID: "file.save", Label: "Save", Default: "CmdOrCtrl+S"},
+	{ID: "file.saveAll", Label: "Save All", Default: "CmdOrCtrl+Shift+S"},
+	{ID: "edit.find", Label: "Find", Default: "CmdOrCtrl+F"},
+	{ID: "edit.replace", Label: "Replace in Repository", Default: "CmdOrCtrl+Shift+F"},
+	{ID: "git.pull", Label: "Pull", Default: "CmdOrCtrl+Shift+L"},
+	{ID: "git.push", Label: "Push", Default: "CmdOrCtrl+Shift+P"},
+	{ID: "git.commit", Label: "Commit", Default: "CmdOrCtrl+K"},
+	{ID: "view.sidebar", Label: "Toggle Sidebar", Default: "CmdOrCtrl+1"},
+	{ID: "view.preview", Label: "Toggle JSON Preview", Default: "CmdOrCtrl+2"},
+	{ID: "view.git", Label: "Toggle Git Panel", Default: "CmdOrCtrl+3"},
+	{ID: "view.diagnostics", Label: "Toggle Diagnostics", Default: "CmdOrCtrl+4"},
+	{ID: "editor.bold", Label: "Bold", Default: "CmdOrCtrl+B"},
+	{ID: "editor.italic", Label: "Italic", Default: "CmdOrCtrl+I"},
+	{ID: "editor.underline", Label: "Underline", Default: "CmdOrCtrl+U"},
+	{ID: "editor.code", Label: "Inline Code", Default: "CmdOrCtrl+E"},
+	{ID: "editor.undo", Label: "Undo", Default: "CmdOrCtrl+Z"},
+	{ID: "editor.redo", Label: "Redo", Default: "CmdOrCtrl+Shift+Z"},
+}
+
+// Find returns the action with the given ID.
+func Find(id string) (Action, bool) {
+	for _, a := range Actions {
+		if a.ID == id {
+			return a, true
+		}
+	}
+	return Action{}, false
+}
+
+// Binding is the effective shortcut of an action.
+type Binding struct {
+	Action
+	// Shortcut is the effective shortcut; empty when the action is unbound.
+	Shortcut string `json:"shortcut"`
+	// Customized is true when the user overrode the default.
+	Customized bool `json:"customized"`
+	// Conflicts lists other actions bound to the same shortcut.
+	Conflicts []string `json:"conflicts"`
+}
+
+// Keymap maps action IDs to their effective shortcuts.
+type Keymap map[string]string
+
+// Resolve merges overrides over the defaults. Overrides for unknown actions
+// or with invalid shortcuts are ignored; an empty override unbinds the
+// action.
+func Resolve(overrides map[string]string) Keymap {
+	k := Keymap{}
+	for _, a := range Actions {
+		k[a.ID] = Normalize(a.Default)
+		if s, ok := overrides[a.ID]; ok {
+			if n, err := Canonical(s); err == nil {
+				k[a.ID] = n
+			}
+		}
+	}
+	return k
+}
+
+// Bindings returns the effective binding of every action in Actions order.
+func Bindings(overrides map[string]string, goos string) []Binding {
+	k := Resolve(overrides)
+	conflicts := k.Conflicts(goos)
+	out := make([]Binding, 0, len(Actions))
+	for _, a := range Actions {
+		b := Binding{Action: a, Shortcut: k[a.ID], Conflicts: []string{}}
+		b.Customized = b.Shortcut != Normalize(a.Default)
+		for _, group := range conflicts {
+			if slices.Contains(group, a.ID) {
+				for _, other := range group {
+					if other != a.ID {
+						b.Conflicts = append(b.Conflicts, other)
+					}
+				}
+			}
+		}
+		out = append(out, b)
+	}
+	return out
+}
+
+// Conflicts returns groups of actions sharing a shortcut on goos. Each
+// group is in Actions order.
+func (k Keymap) Conflicts(goos string) [][]string {
+	byKey := map[string][]string{}
+	var order []string
+	for _, a := range Actions {
+		s := k[a.ID]
+		if s == "" {
+			continue
+		}
+		key := platformKey(s, goos)
+		if _, seen := byKey[key]; !seen {
+			order = append(order, key)
+		}
+		byKey[key] = append(byKey[key], a.ID)
+	}
+	var groups [][]string
+	for _, key := range order {
+		if len(byKey[key]) > 1 {
+			groups = append(groups, byKey[key])
+		}
+	}
+	return groups
+}
+
+// Accelerator returns the menu accelerator for action id, or nil when the
+// action is unbound or its shortcut is already taken by an earlier action
+// on goos, so the first action in Actions order wins a conflict.
+func (k Keymap) Accelerator(id, goos string) *keys.Accelerator {
+	s := k[id]
+	if s == "" {
+		return nil
+	}
+	for _, group := range k.Conflicts(goos) {
+		if slices.Contains(group, id) && group[0] != id {
+			return nil
+		}
+	}
+	acc, err := keys.Parse(s)
+	if err != nil {
+		return nil
+	}
+	return acc
+}
+
+// Canonical validates shortcut and returns it in canonical form, e.g.
+// "shift+cmdorctrl+s" becomes "CmdOrCtrl+Shift+S". An empty shortcut is
+// returned unchanged.
+func Canonical(shortcut string) (string, error) {
+	shortcut = strings.TrimSpace(shortcut)
+	if shortcut == "" {
+		return "", nil
+	}
+	acc, err := keys.Parse(shortcut)
+	if err != nil {
+		return "", fmt.Errorf("invalid shortcut %q: %w", shortcut, err)
+	}
+	return format(acc), nil
+}
+
+// Normalize returns the canonical form of a shortcut known to be valid.
+func Normalize(shortcut string) string {
+	n, err := Canonical(shortcut)
+	if err != nil {
+		return shortcut
+	}
+	return n
+}
+
+// modifierNames orders and names modifiers in canonical shortcuts.
+var modifierNames = []struct {
+	mod  keys.Modifier
+	name string
+}{
+	{keys.CmdOrCtrlKey, "CmdOrCtrl"},
+	{keys.ControlKey, "Ctrl"},
+	{keys.OptionOrAltKey, "OptionOrAlt"},
+	{keys.ShiftKey, "Shift"},
+}
+
+// format renders acc canonically.
+func format(acc *keys.Accelerator) string {
+	var parts []string
+	for _, m := range modifierNames {
+		for _, have := range acc.Modifiers {
+			if have == m.mod {
+				parts = append(parts, m.name)
+				break
+			}
+		}
+	}
+	key := acc.Key
+	switch {
+	case key == "+":
+		key = "Plus"
+	case len(key) == 1:
+		key = strings.ToUpper(key)
+	default:
+		words := strings.Fields(key)
+		for i, w := range words {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+		key = strings.Join(words, " ")
+	}
+	return strings.Join(append(parts, key), "+")
+}
+
+// platformKey returns the key used to compare shortcuts on goos, where
+// CmdOrCtrl and Ctrl are the same outside macOS.
+func platformKey(shortcut, goos string) string {
+	if goos == "darwin" {
+		return shortcut
+	}
+	parts := strings.Split(shortcut, "+")
+	mods := parts[:len(parts)-1]
+	for i, m := range mods {
+		if m == "Ctrl" {
+			mods[i] = "CmdOrCtrl"
+		}
+	}
+	sort.Strings(mods)
+	mods = dedupe(mods)
+	return strings.Join(append(mods, parts[len(parts)-1]), "+")
+}
+
+// dedupe removes adjacent duplicates from a sorted slice.
+func dedupe(s []string) []string {
+	out := s[:0]
+	for i, v := range s {
+		if i == 0 || v != s[i-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2025 blog-writer authors
+package keymap
+
+import "testing"
+
+// TestCanonical ensures shortcuts are validated and normalized.
+func TestCanonical(t *testing.T) {
+	cases := map[string]string{
+		"shift+cmdorctrl+s":     "CmdOrCtrl+Shift+S",
+		"ctrl+plus":             "Ctrl+Plus",
+		"optionoralt+page down": "OptionOrAlt+Page Down",
+		"":                      "",
+	}
+	for in, want := range cases {
+		got, err := Canonical(in)
+		if err != nil || got != want {
+			t.Fatalf("Canonical(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := Canonical("Hyper+S"); err == nil {
+		t.Fatal("expected error for unknown modifier")
+	}
+}
+
+// TestDefaultsHaveNoConflicts ensures the built-in keymap is consistent.
+func TestDefaultsHaveNoConflicts(t *testing.T) {
+	for _, goos := range []string{"linux", "darwin", "windows"} {
+		if c := Resolve(nil).Conflicts(goos); len(c) != 0 {
+			t.Fatalf("%s: default conflicts %v", goos, c)
+		}
+	}
+}
+
+// TestResolveOverrides ensures overrides replace defaults, empty overrides
+// unbind and invalid ones are ignored.
+func TestResolveOverrides(t *testing.T) {
+	k := Resolve(map[string]string{
+		"file.save":     "ctrl+shift+w",
+		"git.commit":    "",
+		"edit.find":     "nonsense+x",
+		"does.notExist": "CmdOrCtrl+Q",
+	})
+	if k["file.save"] != "Ctrl+Shift+W" || k["git.commit"] != "" || k["edit.find"] != "CmdOrCtrl+F" {
+		t.Fatalf("unexpected keymap: %v", k)
+	}
+	if _, ok := k["does.notExist"]; ok {
+		t.Fatal("unknown action kept")
+	}
+	if k.Accelerator("git.commit", "linux") != nil {
+		t.Fatal("unbound action has an accelerator")
+	}
+}
+
+// TestConflicts ensures conflicts respect platform modifier equivalence and
+// the earlier action keeps the accelerator.
+func TestConflicts(t *testing.T) {
+	overrides := map[string]string{"editor.bold": "Ctrl+S"}
+	k := Resolve(overrides)
+	if c := k.Conflicts("darwin"); len(c) != 0 {
+		t.Fatalf("Ctrl and Cmd differ on macOS, got %v", c)
+	}
+	c := k.Conflicts("linux")
+	if len(c) != 1 || c[0][0] != "file.save" || c[0][1] != "editor.bold" {
+		t.Fatalf("unexpected conflicts %v", c)
+	}
+	if k.Accelerator("file.save", "linux") == nil || k.Accelerator("editor.bold", "linux") != nil {
+		t.Fatal("the first action should win the conflict")
+	}
+	for _, b := range Bindings(overrides, "linux") {
+		switch b.ID {
+		case "editor.bold":
+			if !b.Customized || len(b.Conflicts) != 1 || b.Conflicts[0] != "file.save" {
+				t.Fatalf("unexpected binding %+v", b)
+			}
+		case "file.save":
+			if b.Customized || len(b.Conflicts) != 1 {
+				t.Fatalf("unexpected binding %+v", b)
+			}
+		}
+	}
+}
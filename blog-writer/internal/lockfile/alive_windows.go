@@ -0,0 +1,30 @@
+// Copyright (c) 2025 blog-writer authors
+//go:build windows
+
+package lockfile
+
+import "syscall"
+
+// processQueryLimitedInformation is the access right needed to read a
+// process exit code.
+const processQueryLimitedInformation = 0x1000
+
+// stillActive is the exit code reported for running processes.
+const stillActive = 259
+
+// alive reports whether a process with the given PID exists.
+func alive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	h, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer syscall.CloseHandle(h)
+	var code uint32
+	if err := syscall.GetExitCodeProcess(h, &code); err != nil {
+		return false
+	}
+	return code == stillActive
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2025 blog-writer authors
+
+// Package lockfile implements advisory lock files that record the owning
+// process so stale locks left by crashed processes can be taken over.
+package lockfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ErrLocked is returned when the lock is held by another live process.
+var ErrLocked = errors.New("locked by another process")
+
+// Owner describes the process holding a lock.
+type Owner struct {
+	PID   int       `json:"pid"`
+	Host  string    `json:"host"`
+	Since time.Time `json:"since"`
+}
+
+// String formats the owner for messages.
+func (o Owner) String() string {
+	return fmt.Sprintf("process %d on %s since %s", o.PID, o.Host, o.Since.Format(time.RFC3339))
+}
+
+// Acquire creates the lock file at path for the current process. When the
+// file is held by another live process the owner is returned together with
+// ErrLocked. Locks left by dead processes on this host are replaced. Locks
+// from other hosts are always treated as held.
+func Acquire(path string) (Owner, error) {
+	self := current()
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			err = json.NewEncoder(f).Encode(self)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(path)
+				return Owner{}, err
+			}
+			return self, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return Owner{}, err
+		}
+		owner, err := Read(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			// An unreadable lock is most likely a partial write from a crash.
+			owner = Owner{Host: self.Host}
+		}
+		if err == nil && owner.PID == self.PID && owner.Host == self.Host {
+			return owner, nil
+		}
+		if err == nil && (owner.Host != self.Host || alive(owner.PID)) {
+			return owner, ErrLocked
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return Owner{}, err
+		}
+	}
+	owner, _ := Read(path)
+	return owner, ErrLocked
+}
+
+// Read returns the owner recorded in the lock file at path.
+func Read(path string) (Owner, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Owner{}, err
+	}
+	var o Owner
+	if err := json.Unmarshal(b, &o); err != nil {
+		return Owner{}, err
+	}
+	return o, nil
+}
+
+// Release removes the lock file at path if it is owned by the current
+// process.
+func Release(path string) error {
+	owner, err := Read(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	self := current()
+	if owner.PID != self.PID || owner.Host != self.Host {
+		return nil
+	}
+	return os.Remove(path)
+}
+
+// current describes the calling process.
+func current() Owner {
+	host, _ := os.Hostname()
+	return Owner{PID: os.Getpid(), Host: host, Since: time.Now().UTC().Truncate(time.Second)}
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2025 blog-writer authors
+package lockfile
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeOwner stores a lock file claiming to belong to owner.
+func writeOwner(t *testing.T, path string, owner Owner) {
+	t.Helper()
+	b, _ := json.Marshal(owner)
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+// TestAcquireRelease ensures a lock can be taken, re-taken by its owner and
+// released.
+func TestAcquireRelease(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "lock")
+	owner, err := Acquire(path)
+	if err != nil || owner.PID != os.Getpid() {
+		t.Fatalf("Acquire: %+v %v", owner, err)
+	}
+	if _, err := Acquire(path); err != nil {
+		t.Fatalf("re-acquire by owner: %v", err)
+	}
+	if err := Release(path); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("lock still present: %v", err)
+	}
+}
+
+// TestAcquireHeld ensures locks of live processes and other hosts are
+// respected and never released by others.
+func TestAcquireHeld(t *testing.T) {
+	host, _ := os.Hostname()
+	path := filepath.Join(t.TempDir(), "lock")
+	for _, owner := range []Owner{{PID: os.Getppid(), Host: host}, {PID: 1, Host: host + "-elsewhere"}} {
+		writeOwner(t, path, owner)
+		got, err := Acquire(path)
+		if !errors.Is(err, ErrLocked) || got.PID != owner.PID {
+			t.Fatalf("expected ErrLocked by %+v, got %+v %v", owner, got, err)
+		}
+		if err := Release(path); err != nil {
+			t.Fatalf("Release: %v", err)
+		}
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("foreign lock removed: %v", err)
+		}
+	}
+}
+
+// TestAcquireStale ensures locks of dead processes and corrupt lock files
+// are replaced.
+func TestAcquireStale(t *testing.T) {
+	host, _ := os.Hostname()
+	path := filepath.Join(t.TempDir(), "lock")
+	writeOwner(t, path, Owner{PID: 1 << 22, Host: host})
+	if owner, err := Acquire(path); err != nil || owner.PID != os.Getpid() {
+		t.Fatalf("expected stale lock takeover, got %+v %v", owner, err)
+	}
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := Acquire(path); err != nil {
+		t.Fatalf("expected corrupt lock takeover, got %v", err)
+	}
+}
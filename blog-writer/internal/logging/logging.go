@@ -0,0 +1,318 @@
+// Copyright (c) 2025 blog-writer authors
+
+// Package logging provides the application's structured log: JSON lines in
+// a rotating file, per-subsystem levels, secret redaction and a buffer of
+// recent entries for the in-app log viewer.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Subsystems with independently configurable levels.
+const (
+	App    = "app"
+	Git    = "git"
+	Schema = "schema"
+	Image  = "image"
+	FS     = "fs"
+	API    = "api"
+	Plugin = "plugin"
+)
+
+// Subsystems lists every subsystem in display order.
+var Subsystems = []string{App, Git, Schema, Image, FS, API, Plugin}
+
+// Defaults for Options.
+const (
+	FileName        = "blog-writer.log"
+	DefaultMaxSize  = 5 << 20
+	DefaultMaxFiles = 5
+	recentLimit     = 1000
+)
+
+// Options configures a Logger.
+type Options struct {
+	// Level is the minimum level of subsystems without an entry in Levels.
+	// It defaults to "info".
+	Level string
+	// Levels overrides Level per subsystem, e.g. {"git": "debug"}.
+	Levels map[string]string
+	// MaxSize is the size in bytes at which the log file is rotated.
+	MaxSize int64
+	// MaxFiles is the number of rotated files kept besides the current one.
+	MaxFiles int
+}
+
+// Entry is a log record as shown in the log viewer.
+type Entry struct {
+	Time      time.Time         `json:"time"`
+	Level     string            `json:"level"`
+	Subsystem string            `json:"subsystem"`
+	Message   string            `json:"message"`
+	Attrs     map[string]string `json:"attrs,omitempty"`
+}
+
+// Logger writes JSON log lines and keeps the most recent entries.
+type Logger struct {
+	out    slog.Handler
+	closer io.Closer
+
+	mu       sync.Mutex
+	level    slog.Level
+	levels   map[string]slog.Level
+	recent   []Entry
+	next     int
+	subs     map[int]func(Entry)
+	nextSubs int
+}
+
+// std is the logger used by loggers returned from For.
+var std atomic.Pointer[Logger]
+
+func init() {
+	l, _ := New(os.Stderr, Options{})
+	std.Store(l)
+}
+
+// New returns a Logger writing JSON lines to w.
+func New(w io.Writer, opts Options) (*Logger, error) {
+	l := &Logger{
+		out:    slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		levels: map[string]slog.Level{},
+		subs:   map[int]func(Entry){},
+	}
+	if err := l.Configure(opts.Level, opts.Levels); err != nil {
+		return l, err
+	}
+	return l, nil
+}
+
+// Open returns a Logger writing to FileName in dir, rotating the file when
+// it exceeds opts.MaxSize.
+func Open(dir string, opts Options) (*Logger, error) {
+	f, err := OpenRotating(filepath.Join(dir, FileName), opts.MaxSize, opts.MaxFiles)
+	if err != nil {
+		return nil, err
+	}
+	l, err := New(f, opts)
+	l.closer = f
+	return l, err
+}
+
+// Init opens the log in dir and makes it the target of every logger
+// returned by For. Invalid levels are reported but do not prevent logging.
+func Init(dir string, opts Options) (*Logger, error) {
+	l, err := Open(dir, opts)
+	if l == nil {
+		return nil, err
+	}
+	SetDefault(l)
+	return l, err
+}
+
+// SetDefault makes l the target of every logger returned by For and
+// returns the previous default.
+func SetDefault(l *Logger) *Logger {
+	return std.Swap(l)
+}
+
+// Default returns the logger targeted by For.
+func Default() *Logger {
+	return std.Load()
+}
+
+// For returns a logger for subsystem writing to the default Logger.
+func For(subsystem string) *slog.Logger {
+	return slog.New(&handler{subsystem: subsystem})
+}
+
+// Close closes the underlying log file, if any.
+func (l *Logger) Close() error {
+	if l.closer == nil {
+		return nil
+	}
+	return l.closer.Close()
+}
+
+// ParseLevel parses "debug", "info", "warn" or "error", ignoring case.
+func ParseLevel(s string) (slog.Level, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return slog.LevelInfo, fmt.Errorf("invalid log level %q", s)
+	}
+	return lvl, nil
+}
+
+// Configure replaces the default and per-subsystem levels. An empty level
+// means "info". On error the valid levels are still applied.
+func (l *Logger) Configure(level string, levels map[string]string) error {
+	var errs []string
+	def := slog.LevelInfo
+	if level != "" {
+		lvl, err := ParseLevel(level)
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			def = lvl
+		}
+	}
+	parsed := map[string]slog.Level{}
+	for sub, s := range levels {
+		lvl, err := ParseLevel(s)
+		if err != nil {
+			errs = append(errs, sub+": "+err.Error())
+			continue
+		}
+		parsed[sub] = lvl
+	}
+	l.mu.Lock()
+	l.level, l.levels = def, parsed
+	l.mu.Unlock()
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// SetLevel sets the minimum level of subsystem.
+func (l *Logger) SetLevel(subsystem string, level slog.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.levels[subsystem] = level
+}
+
+// Level returns the minimum level of subsystem.
+func (l *Logger) Level(subsystem string) slog.Level {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lvl, ok := l.levels[subsystem]; ok {
+		return lvl
+	}
+	return l.level
+}
+
+// Recent returns up to limit of the most recent entries, oldest first. A
+// limit of zero or less returns every buffered entry.
+func (l *Logger) Recent(limit int) []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	n := len(l.recent)
+	out := make([]Entry, 0, n)
+	if n == recentLimit {
+		out = append(out, l.recent[l.next:]...)
+		out = append(out, l.recent[:l.next]...)
+	} else {
+		out = append(out, l.recent...)
+	}
+	if limit > 0 && len(out) > limit {
+		out = out[len(out)-limit:]
+	}
+	return out
+}
+
+// Subscribe calls fn for every entry logged from now on and returns a
+// function that stops the subscription.
+func (l *Logger) Subscribe(fn func(Entry)) func() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	id := l.nextSubs
+	l.nextSubs++
+	l.subs[id] = fn
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.subs, id)
+	}
+}
+
+// record buffers e and passes it to the subscribers.
+func (l *Logger) record(e Entry) {
+	l.mu.Lock()
+	if len(l.recent) < recentLimit {
+		l.recent = append(l.recent, e)
+	} else {
+		l.recent[l.next] = e
+	}
+	l.next = (l.next + 1) % recentLimit
+	subs := make([]func(Entry), 0, len(l.subs))
+	for _, fn := range l.subs {
+		subs = append(subs, fn)
+	}
+	l.mu.Unlock()
+	for _, fn := range subs {
+		fn(e)
+	}
+}
+
+// handler is the slog.Handler behind For. It resolves the default Logger
+// on every call so loggers created before Init follow it.
+type handler struct {
+	subsystem string
+	prefix    string
+	attrs     []slog.Attr
+}
+
+// Enabled implements slog.Handler.
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= Default().Level(h.subsystem)
+}
+
+// Handle implements slog.Handler. The message and attributes are redacted
+// before they are written.
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	l := Default()
+	out := slog.NewRecord(r.Time, r.Level, Redact(r.Message), r.PC)
+	out.AddAttrs(slog.String("subsystem", h.subsystem))
+	out.AddAttrs(h.attrs...)
+	r.Attrs(func(a slog.Attr) bool {
+		out.AddAttrs(redactAttr(h.prefix, a))
+		return true
+	})
+	e := Entry{Time: r.Time, Level: r.Level.String(), Subsystem: h.subsystem, Message: out.Message}
+	out.Attrs(func(a slog.Attr) bool {
+		if a.Key == "subsystem" {
+			return true
+		}
+		if e.Attrs == nil {
+			e.Attrs = map[string]string{}
+		}
+		e.Attrs[a.Key] = a.Value.String()
+		return true
+	})
+	err := l.out.Handle(ctx, out)
+	l.record(e)
+	return err
+}
+
+// WithAttrs implements slog.Handler.
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.attrs = append([]slog.Attr(nil), h.attrs...)
+	for _, a := range attrs {
+		c.attrs = append(c.attrs, redactAttr(h.prefix, a))
+	}
+	return &c
+}
+
+// WithGroup implements slog.Handler. Groups are flattened into dotted
+// attribute keys.
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	c := *h
+	c.prefix = h.prefix + name + "."
+	return &c
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025 blog-writer authors
+
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestRotatingFile ensures files rotate at the size limit and only
+// maxFiles old files are kept.
+func TestRotatingFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	r, err := OpenRotating(path, 10, 2)
+	if err != nil {
+		t.Fatalf("OpenRotating: %v", err)
+	}
+	for _, s := range []string{"aaaaaa\n", "bbbbbb\n", "cccccc\n", "dddddd\n"} {
+		if _, err := r.Write([]byte(s)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	want := map[string]string{"app.log": "dddddd\n", "app.1.log": "cccccc\n", "app.2.log": "bbbbbb\n"}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != len(want) {
+		t.Fatalf("unexpected files %v", entries)
+	}
+	for name, content := range want {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || string(b) != content {
+			t.Fatalf("%s: got %q, %v", name, b, err)
+		}
+	}
+	if _, err := r.Write([]byte("x")); err == nil {
+		t.Fatal("expected error after Close")
+	}
+}
+
+// TestInit ensures Init routes For loggers into the log file.
+func TestInit(t *testing.T) {
+	dir := t.TempDir()
+	prev := Default()
+	l, err := Init(dir, Options{})
+	if err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	defer func() {
+		std.Store(prev)
+		l.Close()
+	}()
+	For(FS).Warn("disk full")
+	b, err := os.ReadFile(filepath.Join(dir, FileName))
+	if err != nil || !strings.Contains(string(b), `"msg":"disk full"`) {
+		t.Fatalf("unexpected log %q, %v", b, err)
+	}
+}
@@ -0,0 +1,359 @@
+// Copyright (c) 2025 blog-writer authors
+
+// Package plugins discovers and runs external plugins speaking the protocol
+// of blog-writer/pkg/plugin. Every plugin runs in its own process; calls
+// are bounded by a timeout, a hung plugin is killed, and a plugin that
+// crashes is restarted on its next call until it has failed maxFailures
+// times in a row.
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"blog-writer/internal/logging"
+	"blog-writer/pkg/plugin"
+)
+
+// DefaultTimeout bounds a plugin call when Options.Timeout is zero.
+const DefaultTimeout = 10 * time.Second
+
+// maxFailures is the number of consecutive crashes or timeouts after which
+// a plugin is disabled until the host is restarted.
+const maxFailures = 3
+
+// Errors reported by plugin calls.
+var (
+	ErrTimeout           = errors.New("plugin timed out")
+	ErrCrashed           = errors.New("plugin exited unexpectedly")
+	ErrDisabled          = errors.New("plugin disabled after repeated failures")
+	ErrUnknownCapability = errors.New("unknown plugin capability")
+	ErrProtocol          = errors.New("unsupported plugin protocol version")
+)
+
+// Source tells where a plugin was found.
+type Source string
+
+// Plugin sources.
+const (
+	SourceRepo Source = "repo"
+	SourceUser Source = "user"
+)
+
+// Dir is a directory searched for plugins.
+type Dir struct {
+	Path   string
+	Source Source
+}
+
+// Options configures a Host.
+type Options struct {
+	// Dir is the working directory of plugins, normally the repository.
+	Dir string
+	// Timeout bounds each call, including startup. Zero uses DefaultTimeout.
+	Timeout time.Duration
+	// HostVersion is sent to plugins on initialize.
+	HostVersion string
+}
+
+// Info describes a discovered plugin.
+type Info struct {
+	Name     string           `json:"name"`
+	Path     string           `json:"path"`
+	Source   Source           `json:"source"`
+	Manifest *plugin.Manifest `json:"manifest,omitempty"`
+	// Error is set when the plugin failed to start or was disabled.
+	Error string `json:"error,omitempty"`
+}
+
+// Discover lists the plugin executables in dirs. A plugin's name is its
+// file name without extension; when several directories hold the same
+// name, the first wins. Missing directories are skipped.
+func Discover(dirs ...Dir) []Info {
+	var found []Info
+	seen := map[string]bool{}
+	for _, d := range dirs {
+		entries, err := os.ReadDir(d.Path)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			path := filepath.Join(d.Path, e.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			name := strings.TrimSuffix(e.Name(), filepath.Ext(e.Name()))
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			found = append(found, Info{Name: name, Path: path, Source: d.Source})
+		}
+	}
+	return found
+}
+
+// isExecutable reports whether path is a regular file the user may run.
+func isExecutable(path string) bool {
+	fi, err := os.Stat(path)
+	if err != nil || !fi.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(path))
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd"
+	}
+	return fi.Mode().Perm()&0o111 != 0
+}
+
+// Host runs a set of plugins.
+type Host struct {
+	plugins []*Plugin
+}
+
+// Start discovers the plugins in dirs and initializes them concurrently.
+// Plugins that fail to start are kept with their error so they can be
+// listed.
+func Start(ctx context.Context, dirs []Dir, opts Options) *Host {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	h := &Host{}
+	var wg sync.WaitGroup
+	for _, info := range Discover(dirs...) {
+		p := &Plugin{info: info, opts: opts, log: logging.For(logging.Plugin).With("plugin", info.Name)}
+		h.plugins = append(h.plugins, p)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			if err := p.ensure(ctx); err != nil {
+				p.log.Warn("start plugin", "err", err)
+			}
+		}()
+	}
+	wg.Wait()
+	return h
+}
+
+// Plugins describes every discovered plugin.
+func (h *Host) Plugins() []Info {
+	out := make([]Info, 0, len(h.plugins))
+	for _, p := range h.plugins {
+		out = append(out, p.Info())
+	}
+	return out
+}
+
+// Close shuts down every plugin.
+func (h *Host) Close() {
+	for _, p := range h.plugins {
+		p.close()
+	}
+}
+
+// Export runs the exporter ref, "<plugin>/<capability>", on req.
+func (h *Host) Export(ctx context.Context, ref string, req plugin.ExportRequest) (plugin.ExportResult, error) {
+	var res plugin.ExportResult
+	p, id, err := h.resolve(ref, plugin.KindExporter)
+	if err != nil {
+		return res, err
+	}
+	req.Capability = id
+	return res, p.call(ctx, plugin.MethodExport, req, &res)
+}
+
+// Import runs the importer ref on req.
+func (h *Host) Import(ctx context.Context, ref string, req plugin.ImportRequest) (plugin.ImportResult, error) {
+	var res plugin.ImportResult
+	p, id, err := h.resolve(ref, plugin.KindImporter)
+	if err != nil {
+		return res, err
+	}
+	req.Capability = id
+	return res, p.call(ctx, plugin.MethodImport, req, &res)
+}
+
+// Command runs the command ref with req.
+func (h *Host) Command(ctx context.Context, ref string, req plugin.CommandRequest) (plugin.CommandResult, error) {
+	var res plugin.CommandResult
+	p, id, err := h.resolve(ref, plugin.KindCommand)
+	if err != nil {
+		return res, err
+	}
+	req.Capability = id
+	return res, p.call(ctx, plugin.MethodCommand, req, &res)
+}
+
+// Lint runs every linter on req and returns their diagnostics ordered by
+// path, each tagged with its capability reference. A failing linter is
+// reported as an error diagnostic instead of failing the others.
+func (h *Host) Lint(ctx context.Context, req plugin.LintRequest) []plugin.Diagnostic {
+	diags := []plugin.Diagnostic{}
+	for _, p := range h.plugins {
+		for _, c := range p.capabilities(plugin.KindLinter) {
+			ref := p.info.Name + "/" + c.ID
+			req.Capability = c.ID
+			var res plugin.LintResult
+			if err := p.call(ctx, plugin.MethodLint, req, &res); err != nil {
+				diags = append(diags, plugin.Diagnostic{Severity: plugin.SeverityError, Message: err.Error(), Source: ref})
+				continue
+			}
+			for _, d := range res.Diagnostics {
+				d.Source = ref
+				diags = append(diags, d)
+			}
+		}
+	}
+	sort.SliceStable(diags, func(i, j int) bool { return diags[i].Path < diags[j].Path })
+	return diags
+}
+
+// resolve finds the plugin and capability ID named by ref with kind.
+func (h *Host) resolve(ref string, kind plugin.Kind) (*Plugin, string, error) {
+	name, id, ok := strings.Cut(ref, "/")
+	if ok {
+		for _, p := range h.plugins {
+			if p.info.Name != name {
+				continue
+			}
+			for _, c := range p.capabilities(kind) {
+				if c.ID == id {
+					return p, id, nil
+				}
+			}
+		}
+	}
+	return nil, "", fmt.Errorf("%w: %s %q", ErrUnknownCapability, kind, ref)
+}
+
+// Plugin is a discovered plugin and its process, started on demand.
+type Plugin struct {
+	info Info
+	opts Options
+	log  *slog.Logger
+
+	mu       sync.Mutex
+	proc     *process
+	manifest *plugin.Manifest
+	failures int
+	err      error
+}
+
+// Info describes the plugin's current state.
+func (p *Plugin) Info() Info {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	info := p.info
+	info.Manifest = p.manifest
+	if p.err != nil {
+		info.Error = p.err.Error()
+	}
+	return info
+}
+
+// capabilities returns the plugin's capabilities of kind.
+func (p *Plugin) capabilities(kind plugin.Kind) []plugin.Capability {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var caps []plugin.Capability
+	if p.manifest != nil {
+		for _, c := range p.manifest.Capabilities {
+			if c.Kind == kind {
+				caps = append(caps, c)
+			}
+		}
+	}
+	return caps
+}
+
+// call invokes method on the plugin, (re)starting it if needed. Calls to a
+// plugin are serialized.
+func (p *Plugin) call(ctx context.Context, method string, params, result any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if err := p.ensure(ctx); err != nil {
+		return fmt.Errorf("plugin %s: %w", p.info.Name, err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.opts.Timeout)
+	defer cancel()
+	if err := p.proc.call(ctx, method, params, result); err != nil {
+		p.fail(err)
+		return fmt.Errorf("plugin %s: %w", p.info.Name, err)
+	}
+	p.failures = 0
+	return nil
+}
+
+// ensure starts and initializes the plugin unless it is running. The
+// caller holds p.mu.
+func (p *Plugin) ensure(ctx context.Context) error {
+	if p.proc != nil && !p.proc.exited() {
+		return nil
+	}
+	if p.failures >= maxFailures {
+		return ErrDisabled
+	}
+	proc, err := startProcess(p.info.Path, p.opts.Dir, p.log)
+	if err != nil {
+		p.failures = maxFailures
+		p.err = err
+		return err
+	}
+	p.proc = proc
+	ctx, cancel := context.WithTimeout(ctx, p.opts.Timeout)
+	defer cancel()
+	var m plugin.Manifest
+	params := plugin.InitializeParams{ProtocolVersion: plugin.ProtocolVersion, HostVersion: p.opts.HostVersion}
+	if err := proc.call(ctx, plugin.MethodInitialize, params, &m); err != nil {
+		p.fail(err)
+		return err
+	}
+	if m.ProtocolVersion != plugin.ProtocolVersion {
+		err := fmt.Errorf("%w: %d", ErrProtocol, m.ProtocolVersion)
+		proc.kill()
+		p.proc, p.failures, p.err = nil, maxFailures, err
+		return err
+	}
+	p.manifest, p.err = &m, nil
+	p.log.Info("plugin started", "version", m.Version, "capabilities", len(m.Capabilities))
+	return nil
+}
+
+// fail records a failed call, discarding the process when it crashed or
+// hung. The caller holds p.mu.
+func (p *Plugin) fail(err error) {
+	if !errors.Is(err, ErrCrashed) && !errors.Is(err, ErrTimeout) && !p.proc.exited() {
+		return
+	}
+	if !p.proc.exited() {
+		p.proc.kill()
+	}
+	p.proc = nil
+	p.failures++
+	p.err = err
+	if p.failures >= maxFailures {
+		p.err = fmt.Errorf("%w: %v", ErrDisabled, err)
+	}
+	p.log.Warn("plugin failed", "err", err, "failures", p.failures)
+}
+
+// close stops the plugin's process, if any.
+func (p *Plugin) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.proc != nil {
+		p.proc.stop()
+		p.proc = nil
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2025 blog-writer authors
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"blog-writer/pkg/plugin"
+)
+
+// envTestPlugin makes the test binary act as a plugin.
+const envTestPlugin = "BLOG_WRITER_TEST_PLUGIN"
+
+// TestMain runs the test plugin when the binary is started as one.
+func TestMain(m *testing.M) {
+	if os.Getenv(envTestPlugin) != "" {
+		if err := testPlugin().Run(); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// testPlugin exercises every capability kind and failure mode.
+func testPlugin() *plugin.Plugin {
+	p := plugin.New("test", "1.0.0")
+	p.Exporter(plugin.Capability{ID: "title", Extension: ".txt"}, func(_ context.Context, req plugin.ExportRequest) (plugin.ExportResult, error) {
+		return plugin.ExportResult{Data: []byte(strings.ToUpper(req.Article.Metadata.Title)), MediaType: "text/plain"}, nil
+	})
+	p.Linter(plugin.Capability{ID: "author"}, func(_ context.Context, req plugin.LintRequest) (plugin.LintResult, error) {
+		if req.Article.Metadata.Author == "" {
+			return plugin.LintResult{Diagnostics: []plugin.Diagnostic{{Path: "/metadata/author", Severity: plugin.SeverityWarning, Message: "no author"}}}, nil
+		}
+		return plugin.LintResult{}, nil
+	})
+	p.Command(plugin.Capability{ID: "pid"}, func(context.Context, plugin.CommandRequest) (plugin.CommandResult, error) {
+		return plugin.CommandResult{Message: fmt.Sprint(os.Getpid())}, nil
+	})
+	p.Command(plugin.Capability{ID: "crash"}, func(context.Context, plugin.CommandRequest) (plugin.CommandResult, error) {
+		os.Exit(3)
+		return plugin.CommandResult{}, nil
+	})
+	p.Command(plugin.Capability{ID: "hang"}, func(context.Context, plugin.CommandRequest) (plugin.CommandResult, error) {
+		time.Sleep(time.Hour)
+		return plugin.CommandResult{}, nil
+	})
+	return p
+}
+
+// installTestPlugin links the test binary into a new plugin directory as
+// name.
+func installTestPlugin(t *testing.T, name string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin tests need symlinks")
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatalf("executable: %v", err)
+	}
+	dir := t.TempDir()
+	if err := os.Symlink(exe, filepath.Join(dir, name)); err != nil {
+		t.Fatalf("symlink: %v", err)
+	}
+	t.Setenv(envTestPlugin, "1")
+	return dir
+}
+
+// TestDiscover ensures only executables are found and earlier directories
+// win name clashes.
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("executable bits are not used on Windows")
+	}
+	repo, user := t.TempDir(), t.TempDir()
+	write := func(dir, name string, mode os.FileMode) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"), mode); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	write(repo, "hugo.sh", 0o755)
+	write(repo, "notes.txt", 0o644)
+	write(user, "hugo", 0o755)
+	write(user, "lint", 0o755)
+	got := Discover(Dir{repo, SourceRepo}, Dir{filepath.Join(repo, "missing"), SourceRepo}, Dir{user, SourceUser})
+	if len(got) != 2 || got[0].Name != "hugo" || got[0].Source != SourceRepo || got[1].Name != "lint" {
+		t.Fatalf("unexpected plugins: %+v", got)
+	}
+}
+
+// TestHost covers starting plugins and calling each capability kind.
+func TestHost(t *testing.T) {
+	dir := installTestPlugin(t, "demo")
+	h := Start(context.Background(), []Dir{{dir, SourceUser}}, Options{Dir: t.TempDir()})
+	defer h.Close()
+	infos := h.Plugins()
+	if len(infos) != 1 || infos[0].Manifest == nil || infos[0].Error != "" {
+		t.Fatalf("unexpected plugins: %+v", infos)
+	}
+	ctx := context.Background()
+	doc := plugin.Article{Metadata: plugin.Metadata{Title: "Hello"}}
+	res, err := h.Export(ctx, "demo/title", plugin.ExportRequest{Article: doc})
+	if err != nil || string(res.Data) != "HELLO" {
+		t.Fatalf("Export: %q, %v", res.Data, err)
+	}
+	diags := h.Lint(ctx, plugin.LintRequest{Article: doc})
+	if len(diags) != 1 || diags[0].Source != "demo/author" || diags[0].Path != "/metadata/author" {
+		t.Fatalf("unexpected diagnostics: %+v", diags)
+	}
+	if _, err := h.Export(ctx, "demo/author", plugin.ExportRequest{}); !errors.Is(err, ErrUnknownCapability) {
+		t.Fatalf("expected ErrUnknownCapability, got %v", err)
+	}
+}
+
+// TestHostIsolation ensures crashes and hangs are contained, restarted and
+// eventually disabled.
+func TestHostIsolation(t *testing.T) {
+	dir := installTestPlugin(t, "demo")
+	h := Start(context.Background(), []Dir{{dir, SourceUser}}, Options{Dir: t.TempDir(), Timeout: 2 * time.Second})
+	defer h.Close()
+	ctx := context.Background()
+	pid := func() string {
+		t.Helper()
+		res, err := h.Command(ctx, "demo/pid", plugin.CommandRequest{})
+		if err != nil {
+			t.Fatalf("pid: %v", err)
+		}
+		return res.Message
+	}
+	first := pid()
+	if _, err := h.Command(ctx, "demo/crash", plugin.CommandRequest{}); !errors.Is(err, ErrCrashed) {
+		t.Fatalf("expected ErrCrashed, got %v", err)
+	}
+	if second := pid(); second == first {
+		t.Fatal("expected the plugin to be restarted")
+	}
+
+	h.plugins[0].opts.Timeout = 100 * time.Millisecond
+	start := time.Now()
+	for i := 0; i < maxFailures; i++ {
+		if _, err := h.Command(ctx, "demo/hang", plugin.CommandRequest{}); !errors.Is(err, ErrTimeout) {
+			t.Fatalf("expected ErrTimeout, got %v", err)
+		}
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("timeouts took too long")
+	}
+	if _, err := h.Command(ctx, "demo/pid", plugin.CommandRequest{}); !errors.Is(err, ErrDisabled) {
+		t.Fatalf("expected ErrDisabled, got %v", err)
+	}
+	if info := h.Plugins()[0]; !strings.Contains(info.Error, "disabled") {
+		t.Fatalf("expected disabled plugin, got %+v", info)
+	}
+}
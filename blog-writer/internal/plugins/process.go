@@ -0,0 +1,222 @@
+// Copyright (c) 2025 blog-writer authors
+package plugins
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"blog-writer/pkg/plugin"
+)
+
+// waitDelay bounds how long a stopped plugin's pipes are drained before it
+// is abandoned.
+const waitDelay = 2 * time.Second
+
+// process is a running plugin executable.
+type process struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	log   *slog.Logger
+	next  int64
+
+	resp     chan plugin.Message
+	done     chan struct{}
+	quit     chan struct{}
+	quitOnce sync.Once
+	// err is the exit status, valid once done is closed.
+	err error
+}
+
+// startProcess runs the executable at path in dir.
+func startProcess(path, dir string, log *slog.Logger) (*process, error) {
+	cmd := exec.Command(path)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), plugin.EnvProtocol+"="+strconv.Itoa(plugin.ProtocolVersion))
+	cmd.Stderr = &lineWriter{log: log}
+	cmd.WaitDelay = waitDelay
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	p := &process{
+		cmd:   cmd,
+		stdin: stdin,
+		log:   log,
+		resp:  make(chan plugin.Message, 1),
+		done:  make(chan struct{}),
+		quit:  make(chan struct{}),
+	}
+	go p.read(stdout)
+	return p, nil
+}
+
+// read consumes the plugin's stdout, logging notifications and handing
+// responses to call, until the plugin exits.
+func (p *process) read(r io.Reader) {
+	defer close(p.done)
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			p.receive(line)
+		}
+		if err != nil {
+			break
+		}
+	}
+	p.err = p.cmd.Wait()
+}
+
+// receive handles one line of plugin output.
+func (p *process) receive(line []byte) {
+	var m plugin.Message
+	if err := json.Unmarshal(line, &m); err != nil {
+		p.log.Warn("invalid plugin output", "line", string(bytes.TrimSpace(line)))
+		return
+	}
+	if m.Method == plugin.MethodLog {
+		var params plugin.LogParams
+		_ = json.Unmarshal(m.Params, &params)
+		p.log.Log(context.Background(), logLevel(params.Level), params.Message)
+		return
+	}
+	if m.Method != "" {
+		return
+	}
+	select {
+	case p.resp <- m:
+	case <-p.quit:
+	}
+}
+
+// logLevel maps a protocol log level to slog.
+func logLevel(level string) slog.Level {
+	switch level {
+	case "debug":
+		return slog.LevelDebug
+	case "warn":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
+
+// call sends method with params and decodes the response into result. The
+// plugin is killed when ctx ends first.
+func (p *process) call(ctx context.Context, method string, params, result any) error {
+	p.next++
+	id := p.next
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(plugin.Message{JSONRPC: "2.0", ID: &id, Method: method, Params: raw})
+	if err != nil {
+		return err
+	}
+	written := make(chan error, 1)
+	go func() {
+		_, err := p.stdin.Write(append(b, '\n'))
+		written <- err
+	}()
+	for {
+		select {
+		case err := <-written:
+			if err != nil {
+				p.kill()
+				return fmt.Errorf("%w: %v", ErrCrashed, err)
+			}
+			written = nil
+		case m := <-p.resp:
+			if m.ID == nil || *m.ID != id {
+				continue
+			}
+			if m.Error != nil {
+				return m.Error
+			}
+			if result == nil {
+				return nil
+			}
+			return json.Unmarshal(m.Result, result)
+		case <-p.done:
+			return fmt.Errorf("%w: %v", ErrCrashed, p.err)
+		case <-ctx.Done():
+			p.kill()
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return ErrTimeout
+			}
+			return ctx.Err()
+		}
+	}
+}
+
+// exited reports whether the process has stopped.
+func (p *process) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// stop asks the plugin to shut down and kills it if it has not exited
+// within waitDelay.
+func (p *process) stop() {
+	b, _ := json.Marshal(plugin.Message{JSONRPC: "2.0", Method: plugin.MethodShutdown})
+	_, _ = p.stdin.Write(append(b, '\n'))
+	_ = p.stdin.Close()
+	select {
+	case <-p.done:
+	case <-time.After(waitDelay):
+		p.kill()
+	}
+}
+
+// kill terminates the process and waits for it to be reaped.
+func (p *process) kill() {
+	p.quitOnce.Do(func() { close(p.quit) })
+	_ = p.cmd.Process.Kill()
+	<-p.done
+}
+
+// lineWriter logs each line written to it.
+type lineWriter struct {
+	log *slog.Logger
+	buf []byte
+}
+
+// Write logs every complete line in b and buffers the rest.
+func (w *lineWriter) Write(b []byte) (int, error) {
+	w.buf = append(w.buf, b...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		if line := bytes.TrimSpace(w.buf[:i]); len(line) > 0 {
+			w.log.Info("plugin stderr", "line", string(line))
+		}
+		w.buf = w.buf[i+1:]
+	}
+	return len(b), nil
+}
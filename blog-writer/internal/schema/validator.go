@@ -1,54 +1,92 @@
 // Copyright (c) 2024 blog-writer authors
-// Package schema provides JSON validation utilities.
 
+// Package schema provides JSON validation utilities.
 package schema
 
 import (
 	"bytes"
+	_ "embed"
 	"encoding/json"
-	"os"
-	"path/filepath"
-	"runtime"
 	"sync"
 
 	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"blog-writer/internal/logging"
+	"blog-writer/pkg/article"
 )
 
+// settingsSchemaFile names the settings schema. The article schema is
+// embedded in blog-writer/pkg/article.
+const settingsSchemaFile = "settings.schema.json"
+
+// settingsSchemaJSON is the settings schema, maintained in this package.
+//
+//go:embed settings.schema.json
+var settingsSchemaJSON []byte
+
+// compiledSchema caches the result of compiling a single embedded schema.
+type compiledSchema struct {
+	data   []byte
+	once   sync.Once
+	schema *jsonschema.Schema
+	err    error
+}
+
 var (
-	compiled   *jsonschema.Schema
-	compileErr error
-	once       sync.Once
+	settingsSchema = compiledSchema{data: settingsSchemaJSON}
+
+	log = logging.For(logging.Schema)
 )
 
-// getSchema compiles the JSON schema once.
-func getSchema() (*jsonschema.Schema, error) {
-	once.Do(func() {
-		_, file, _, _ := runtime.Caller(0)
-		path := filepath.Join(filepath.Dir(file), "..", "..", "src", "schema", "article.schema.json")
-		data, err := os.ReadFile(path)
-		if err != nil {
-			compileErr = err
-			return
-		}
+// get compiles the schema once, using name as its URL.
+func (c *compiledSchema) get(name string) (*jsonschema.Schema, error) {
+	c.once.Do(func() {
 		compiler := jsonschema.NewCompiler()
-		if err := compiler.AddResource(path, bytes.NewReader(data)); err != nil {
-			compileErr = err
+		if err := compiler.AddResource(name, bytes.NewReader(c.data)); err != nil {
+			c.err = err
 			return
 		}
-		compiled, compileErr = compiler.Compile(path)
+		c.schema, c.err = compiler.Compile(name)
 	})
-	return compiled, compileErr
+	if c.err != nil {
+		log.Error("schema unavailable", "schema", name, "err", c.err)
+	}
+	return c.schema, c.err
 }
 
-// Validate checks the provided JSON document against the article schema.
-func Validate(data []byte) error {
-	schema, err := getSchema()
+// ArticleSchema returns the raw article JSON schema.
+func ArticleSchema() []byte {
+	return article.Schema()
+}
+
+// validate checks data against schema s.
+func validate(s *compiledSchema, name string, data []byte) error {
+	schema, err := s.get(name)
 	if err != nil {
 		return err
 	}
 	var v interface{}
 	if err := json.Unmarshal(data, &v); err != nil {
+		log.Debug("invalid JSON", "schema", name, "err", err)
+		return err
+	}
+	if err := schema.Validate(v); err != nil {
+		log.Debug("validation failed", "schema", name, "err", err)
+		return err
+	}
+	return nil
+}
+
+// Validate checks the provided JSON document against the article schema.
+func Validate(data []byte) error {
+	if err := article.Validate(data); err != nil {
+		log.Debug("validation failed", "schema", "article.schema.json", "err", err)
 		return err
 	}
-	return schema.Validate(v)
+	return nil
+}
+
+// ValidateSettings checks the provided JSON document against the repository settings schema.
+func ValidateSettings(data []byte) error {
+	return validate(&settingsSchema, settingsSchemaFile, data)
 }
@@ -44,3 +44,26 @@ func TestValidateInvalid(t *testing.T) {
 		t.Fatalf("expected validation error")
 	}
 }
+
+const validSettings = `{
+  "schemaVersion":1,
+  "defaultAuthor":"",
+  "defaultKeywords":[],
+  "defaultBranch":"main",
+  "remote":"",
+  "preCommitValidate":true,
+  "maxEmbeddedSvgBytes":10485760,
+  "maxSvgNodeCount":100000,
+  "imageVectorization":{"mode":"auto","threshold":0.6,"colors":8},
+  "autosave":{"enabled":true,"intervalMs":15000}
+}`
+
+// TestValidateSettings ensures the settings schema accepts defaults and rejects bad values.
+func TestValidateSettings(t *testing.T) {
+	if err := ValidateSettings([]byte(validSettings)); err != nil {
+		t.Fatalf("expected valid settings to pass: %v", err)
+	}
+	if err := ValidateSettings([]byte(`{"schemaVersion":1}`)); err == nil {
+		t.Fatalf("expected missing fields to fail")
+	}
+}
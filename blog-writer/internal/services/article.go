@@ -0,0 +1,213 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"blog-writer/internal/events"
+	"blog-writer/internal/fsutil"
+	"blog-writer/internal/schema"
+	"blog-writer/pkg/article"
+	"blog-writer/pkg/delta"
+)
+
+// ArticleService reads and writes articles in a blog repository.
+type ArticleService struct {
+	// Events, if set, receives an events.ArticleSaved event after each save.
+	Events *events.Bus
+	// now returns the current time; it is replaced in tests.
+	now func() time.Time
+}
+
+// NewArticleService constructs an ArticleService.
+func NewArticleService() *ArticleService {
+	return &ArticleService{now: time.Now}
+}
+
+// Load reads the article with the given ID from repo. Articles written with
+// an older envelope version are upgraded in memory; the file on disk is left
+// untouched until the article is saved or `migrate` is run.
+func (a *ArticleService) Load(repo, id string) (article.Article, error) {
+	path, err := article.Find(repo, id)
+	if err != nil {
+		return article.Article{}, err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return article.Article{}, err
+	}
+	doc, err := article.Parse(b)
+	if err != nil {
+		return article.Article{}, err
+	}
+	if _, err := article.Migrate(&doc); err != nil {
+		return article.Article{}, err
+	}
+	return doc, nil
+}
+
+// Save validates doc and atomically writes it as the article id in repo,
+// returning the article's ID. An empty id allocates a new one from the
+// current Unix time, stepping forward a second at a time past existing
+// articles; a new article is written to blog/<id>.json and an existing one
+// is rewritten where it was found. When commit is true the file is
+// committed with the message `chore(article): <id> <title> [create|update]`.
+func (a *ArticleService) Save(repo, id string, doc article.Article, commit bool) (string, error) {
+	path, created, err := a.articlePath(repo, id)
+	if err != nil {
+		return "", err
+	}
+	id = article.ID(path)
+	b, err := article.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	if err := schema.Validate(b); err != nil {
+		return "", validationError("invalid article", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	if err := fsutil.WriteFileAtomic(path, b, 0o644); err != nil {
+		return "", err
+	}
+	if commit {
+		if err := commitArticle(repo, path, id, doc.Metadata.Title, created); err != nil {
+			return id, err
+		}
+	}
+	a.Events.Publish(events.ArticleSaved, repo, events.ArticleSavedData{
+		ID:        id,
+		Path:      path,
+		Created:   created,
+		Committed: commit,
+	})
+	return id, nil
+}
+
+// LoadDelta returns the document of article id in repo as a Quill Delta
+// for the editor.
+func (a *ArticleService) LoadDelta(repo, id string) (delta.Delta, error) {
+	doc, err := a.Load(repo, id)
+	if err != nil {
+		return delta.Delta{}, err
+	}
+	return delta.FromNodes(doc.Document), nil
+}
+
+// SaveDelta replaces the document of article id in repo with the editor's
+// Delta, keeps the metadata apart from the updated date and saves it like
+// Save.
+func (a *ArticleService) SaveDelta(repo, id string, d delta.Delta, commit bool) (string, error) {
+	doc, err := a.Load(repo, id)
+	if err != nil {
+		return "", err
+	}
+	nodes, err := delta.ToNodes(d)
+	if err != nil {
+		return "", newError(CodeInvalidArgument, "%v", err)
+	}
+	doc.Document = nodes
+	doc.Metadata.UpdatedDate = a.clock().UTC().Format(time.RFC3339)
+	return a.Save(repo, id, doc, commit)
+}
+
+// Replace substitutes replacement for every occurrence of find in the text
+// of all articles in repo, saving each changed article like Save, and
+// returns how many articles changed.
+func (a *ArticleService) Replace(repo, find, replacement string, commit bool) (int, error) {
+	if find == "" {
+		return 0, newError(CodeInvalidArgument, "search text required")
+	}
+	paths, err := article.Scan(repo)
+	if err != nil {
+		return 0, err
+	}
+	changed := 0
+	for _, p := range paths {
+		id := article.ID(p)
+		doc, err := a.Load(repo, id)
+		if err != nil {
+			return changed, err
+		}
+		found := false
+		article.Walk(doc.Document, func(n *article.Node, _ int) bool {
+			if n.Content.IsText() && strings.Contains(*n.Content.Text, find) {
+				n.Content = article.Text(strings.ReplaceAll(*n.Content.Text, find, replacement))
+				found = true
+			}
+			return true
+		})
+		if !found {
+			continue
+		}
+		doc.Metadata.UpdatedDate = a.clock().UTC().Format(time.RFC3339)
+		if _, err := a.Save(repo, id, doc, commit); err != nil {
+			return changed, err
+		}
+		changed++
+	}
+	return changed, nil
+}
+
+// clock returns the current time, using the test clock if set.
+func (a *ArticleService) clock() time.Time {
+	if a.now != nil {
+		return a.now()
+	}
+	return time.Now()
+}
+
+// articlePath resolves the file for article id in repo and reports whether
+// it does not exist yet. An empty id allocates a fresh one.
+func (a *ArticleService) articlePath(repo, id string) (string, bool, error) {
+	if id == "" {
+		for n := a.clock().Unix(); ; n++ {
+			id = strconv.FormatInt(n, 10)
+			if _, err := article.Find(repo, id); errors.Is(err, os.ErrNotExist) {
+				return newArticlePath(repo, id), true, nil
+			} else if err != nil {
+				return "", false, err
+			}
+		}
+	}
+	if !article.IsArticleFile(id + ".json") {
+		return "", false, newError(CodeInvalidArgument, "invalid article id %q", id)
+	}
+	path, err := article.Find(repo, id)
+	if errors.Is(err, os.ErrNotExist) {
+		return newArticlePath(repo, id), true, nil
+	}
+	return path, false, err
+}
+
+// newArticlePath returns the location of a new article with id in repo.
+func newArticlePath(repo, id string) string {
+	return filepath.Join(repo, article.BlogDir, id+".json")
+}
+
+// commitArticle stages and commits the article file at path.
+func commitArticle(repo, path, id, title string, created bool) error {
+	rel, err := filepath.Rel(repo, path)
+	if err != nil {
+		return err
+	}
+	action := "update"
+	if created {
+		action = "create"
+	}
+	ctx, done := gitOps.start(repo)
+	defer done()
+	if _, err := runGit(ctx, repo, "add", "--", rel); err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("chore(article): %s %s [%s]", id, title, action)
+	_, err = runGit(ctx, repo, "commit", "-m", msg, "--", rel)
+	return err
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"blog-writer/internal/events"
+	"blog-writer/pkg/article"
+)
+
+// writeArticle stores raw article JSON at blog/<rel> inside repo.
+func writeArticle(t *testing.T, repo, rel, data string) {
+	t.Helper()
+	p := filepath.Join(repo, "blog", rel)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(p, []byte(data), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+// TestArticleLoad ensures articles are found in subject folders and parsed.
+func TestArticleLoad(t *testing.T) {
+	repo := t.TempDir()
+	writeArticle(t, repo, filepath.Join("subject", "1755288225.json"),
+		`{"version":"1.0.0","metadata":{"title":"T","author":"A","description":"","publicationDate":"2025-01-01T00:00:00Z","updatedDate":"2025-01-01T00:00:00Z","keywords":[]},"document":[{"tag":"p","content":[{"tag":"span","content":"hi"}]}]}`)
+	a, err := NewArticleService().Load(repo, "1755288225")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if a.Metadata.Title != "T" || a.Document[0].Tag != "p" {
+		t.Fatalf("unexpected article: %+v", a)
+	}
+}
+
+// TestArticleLoadTooNew ensures articles from a newer schema are refused.
+func TestArticleLoadTooNew(t *testing.T) {
+	repo := t.TempDir()
+	writeArticle(t, repo, "1755288225.json", `{"version":"99.0.0","metadata":{},"document":[]}`)
+	if _, err := NewArticleService().Load(repo, "1755288225"); !errors.Is(err, article.ErrVersionTooNew) {
+		t.Fatalf("expected ErrVersionTooNew, got %v", err)
+	}
+}
+
+// testArticle returns a minimal valid article titled title.
+func testArticle(title string) article.Article {
+	return article.Article{
+		Version: article.CurrentVersion,
+		Metadata: article.Metadata{
+			Title:           title,
+			Author:          "A",
+			PublicationDate: "2025-01-01T00:00:00Z",
+			UpdatedDate:     "2025-01-01T00:00:00Z",
+		},
+		Document: []article.Node{{Tag: "p", Content: article.Children(article.Node{Tag: "span", Content: article.Text("hi")})}},
+	}
+}
+
+// TestArticleSaveAllocatesID ensures new articles get the next free epoch
+// ID, are committed and announced on the event bus.
+func TestArticleSaveAllocatesID(t *testing.T) {
+	requireGit(t)
+	repo := filepath.Join(t.TempDir(), "repo")
+	if err := NewRepoServiceWithPath(filepath.Join(t.TempDir(), "config.yml")).Create("", repo); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	writeArticle(t, repo, filepath.Join("subject", "1755288225.json"), "{}")
+	svc := NewArticleService()
+	svc.now = func() time.Time { return time.Unix(1755288225, 0) }
+	svc.Events = events.New()
+	var got []events.Event
+	svc.Events.Subscribe(string(events.ArticleSaved), func(e events.Event) { got = append(got, e) })
+
+	id, err := svc.Save(repo, "", testArticle("First"), true)
+	if err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if id != "1755288226" {
+		t.Fatalf("expected next free id, got %q", id)
+	}
+	if _, err := os.Stat(filepath.Join(repo, "blog", id+".json")); err != nil {
+		t.Fatalf("article not written: %v", err)
+	}
+	out, err := exec.Command("git", "-C", repo, "log", "-1", "--format=%s").Output()
+	if err != nil {
+		t.Fatalf("git log: %v", err)
+	}
+	if want := "chore(article): 1755288226 First [create]"; strings.TrimSpace(string(out)) != want {
+		t.Fatalf("expected commit %q, got %q", want, out)
+	}
+	if len(got) != 1 || got[0].Repo != repo {
+		t.Fatalf("expected one article.saved event, got %+v", got)
+	}
+	data := got[0].Data.(events.ArticleSavedData)
+	if data.ID != id || !data.Created || !data.Committed {
+		t.Fatalf("unexpected event data: %+v", data)
+	}
+}
+
+// TestArticleSaveUpdate ensures existing articles are rewritten in place
+// and invalid ones are refused without publishing.
+func TestArticleSaveUpdate(t *testing.T) {
+	repo := t.TempDir()
+	svc := NewArticleService()
+	if _, err := svc.Save(repo, "1755288225", testArticle("T"), false); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	svc.Events = events.New()
+	var got []events.Event
+	svc.Events.Subscribe("*", func(e events.Event) { got = append(got, e) })
+	if _, err := svc.Save(repo, "1755288225", testArticle("Updated"), false); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	a, err := svc.Load(repo, "1755288225")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if a.Metadata.Title != "Updated" {
+		t.Fatalf("expected updated title, got %q", a.Metadata.Title)
+	}
+	if len(got) != 1 || got[0].Data.(events.ArticleSavedData).Created {
+		t.Fatalf("expected one update event, got %+v", got)
+	}
+
+	bad := testArticle("")
+	bad.Document = []article.Node{{Tag: "script"}}
+	if _, err := svc.Save(repo, "1755288225", bad, false); !errors.Is(err, ErrValidationFailed) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if _, err := svc.Save(repo, "../x", testArticle("T"), false); !errors.Is(err, &Error{Code: CodeInvalidArgument}) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("failed saves must not publish, got %+v", got)
+	}
+}
+
+// TestArticleDeltaRoundTrip ensures the editor's Delta replaces the document
+// while the metadata is kept and the updated date advances.
+func TestArticleDeltaRoundTrip(t *testing.T) {
+	repo := t.TempDir()
+	svc := NewArticleService()
+	svc.now = func() time.Time { return time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC) }
+	if _, err := svc.Save(repo, "1755288225", testArticle("T"), false); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	d, err := svc.LoadDelta(repo, "1755288225")
+	if err != nil {
+		t.Fatalf("LoadDelta: %v", err)
+	}
+	if len(d.Ops) == 0 || d.Ops[0].Insert != "hi\n" {
+		t.Fatalf("unexpected delta: %+v", d)
+	}
+	d.Ops[0].Insert = "hello\n"
+	if _, err := svc.SaveDelta(repo, "1755288225", d, false); err != nil {
+		t.Fatalf("SaveDelta: %v", err)
+	}
+	a, err := svc.Load(repo, "1755288225")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if a.Metadata.Title != "T" || a.Metadata.UpdatedDate != "2025-02-01T00:00:00Z" {
+		t.Fatalf("unexpected metadata: %+v", a.Metadata)
+	}
+	if got := articleText(a); got != "hello" {
+		t.Fatalf("expected edited text, got %q", got)
+	}
+}
+
+// TestArticleReplace ensures text is replaced across every article and only
+// changed articles are rewritten.
+func TestArticleReplace(t *testing.T) {
+	repo := t.TempDir()
+	svc := NewArticleService()
+	for _, id := range []string{"1755288225", "1755288226"} {
+		if _, err := svc.Save(repo, id, testArticle("T"), false); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+	}
+	other := testArticle("T")
+	other.Document = []article.Node{{Tag: "p", Content: article.Text("bye")}}
+	if _, err := svc.Save(repo, "1755288227", other, false); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	n, err := svc.Replace(repo, "hi", "hey", false)
+	if err != nil {
+		t.Fatalf("Replace: %v", err)
+	}
+	if n != 2 {
+		t.Fatalf("expected 2 changed articles, got %d", n)
+	}
+	a, _ := svc.Load(repo, "1755288226")
+	if got := articleText(a); got != "hey" {
+		t.Fatalf("expected replaced text, got %q", got)
+	}
+	if _, err := svc.Replace(repo, "", "x", false); !errors.Is(err, &Error{Code: CodeInvalidArgument}) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
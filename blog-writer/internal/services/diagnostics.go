@@ -0,0 +1,354 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"blog-writer/internal/about"
+	"blog-writer/internal/config"
+	"blog-writer/internal/fsutil"
+	"blog-writer/internal/logging"
+)
+
+// maxLogBytes caps how much of each log file goes into a diagnostic bundle.
+const maxLogBytes = 256 << 10
+
+// emailRe matches e-mail addresses.
+var emailRe = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+// DiagnosticOptions selects what goes into a diagnostic bundle.
+type DiagnosticOptions struct {
+	// Repo is the current repository; its settings and validation results
+	// are included when set.
+	Repo string `json:"repo"`
+	// Redact replaces file system paths and e-mail addresses with
+	// placeholders. Credentials are always removed.
+	Redact bool `json:"redact"`
+	// WebView describes the frontend's web view, usually its user agent.
+	WebView string `json:"webview"`
+}
+
+// DiagnosticEnvironment describes the machine the app runs on.
+type DiagnosticEnvironment struct {
+	OS         string `json:"os"`
+	Arch       string `json:"arch"`
+	CPUs       int    `json:"cpus"`
+	WebView    string `json:"webview,omitempty"`
+	GitVersion string `json:"gitVersion"`
+}
+
+// DiagnosticBundle describes a written diagnostic bundle.
+type DiagnosticBundle struct {
+	Path  string   `json:"path"`
+	Files []string `json:"files"`
+	// IssueBody is a Markdown summary of the environment for a bug report.
+	IssueBody string `json:"issueBody"`
+}
+
+// DiagnosticsService collects version, environment, configuration, logs and
+// validation results into a zip file to attach to bug reports.
+type DiagnosticsService struct {
+	mu      sync.Mutex
+	cfgPath string
+	logDir  string
+	outDir  string
+	now     func() time.Time
+}
+
+// NewDiagnosticsService constructs a DiagnosticsService reading the user's
+// config and logs and writing bundles to the "diagnostics" folder of the
+// state directory.
+func NewDiagnosticsService() (*DiagnosticsService, error) {
+	p, err := config.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	logs, err := config.LogDir()
+	if err != nil {
+		return nil, err
+	}
+	state, err := config.StateDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewDiagnosticsServiceWithPaths(p, logs, filepath.Join(state, "diagnostics")), nil
+}
+
+// NewDiagnosticsServiceWithPaths constructs a DiagnosticsService with custom
+// config, log and output locations. Mainly used for tests.
+func NewDiagnosticsServiceWithPaths(cfgPath, logDir, outDir string) *DiagnosticsService {
+	return &DiagnosticsService{cfgPath: cfgPath, logDir: logDir, outDir: outDir, now: time.Now}
+}
+
+// Create writes a diagnostic bundle and returns its location and contents.
+// Parts that cannot be collected are recorded in errors.txt instead of
+// failing the whole bundle.
+func (d *DiagnosticsService) Create(opts DiagnosticOptions) (DiagnosticBundle, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	cfg, cfgErr := config.Load(d.cfgPath)
+	red := newRedactor(opts, cfg)
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	bundle := DiagnosticBundle{Files: []string{}}
+	var problems []string
+	add := func(name string, data []byte) error {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: d.now()})
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte(red.String(string(data)))); err != nil {
+			return err
+		}
+		bundle.Files = append(bundle.Files, name)
+		return nil
+	}
+	addJSON := func(name string, v any) error {
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		return add(name, append(b, '\n'))
+	}
+	fail := func(part string, err error) {
+		problems = append(problems, fmt.Sprintf("%s: %v", part, err))
+	}
+
+	build := about.BuildInfo()
+	env := DiagnosticEnvironment{OS: runtime.GOOS, Arch: runtime.GOARCH, CPUs: runtime.NumCPU(), WebView: opts.WebView}
+	if v, err := runGit(context.Background(), "", "--version"); err != nil {
+		fail("git version", err)
+	} else {
+		env.GitVersion = strings.TrimSpace(v)
+	}
+	if err := addJSON("build.json", build); err != nil {
+		return DiagnosticBundle{}, err
+	}
+	if err := addJSON("environment.json", env); err != nil {
+		return DiagnosticBundle{}, err
+	}
+
+	if cfgErr != nil {
+		fail("config", cfgErr)
+	} else if b, err := yaml.Marshal(cfg); err != nil {
+		fail("config", err)
+	} else if err := add("config.yml", b); err != nil {
+		return DiagnosticBundle{}, err
+	}
+
+	var results []ValidationResult
+	if opts.Repo != "" {
+		if s, err := loadSettings(opts.Repo); err != nil {
+			fail("settings", err)
+		} else if err := addJSON("settings.json", s); err != nil {
+			return DiagnosticBundle{}, err
+		}
+		var err error
+		if results, err = NewArticleIndex(opts.Repo).Validate(); err != nil {
+			fail("validation", err)
+		} else if err := addJSON("validation.json", results); err != nil {
+			return DiagnosticBundle{}, err
+		}
+	}
+
+	logs, err := d.logFiles()
+	if err != nil {
+		fail("logs", err)
+	}
+	for _, p := range logs {
+		b, err := readTail(p, maxLogBytes)
+		if err != nil {
+			fail("logs", err)
+			continue
+		}
+		if err := add("logs/"+filepath.Base(p), b); err != nil {
+			return DiagnosticBundle{}, err
+		}
+	}
+
+	if len(problems) > 0 {
+		if err := add("errors.txt", []byte(strings.Join(problems, "\n")+"\n")); err != nil {
+			return DiagnosticBundle{}, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return DiagnosticBundle{}, err
+	}
+	if err := os.MkdirAll(d.outDir, 0o755); err != nil {
+		return DiagnosticBundle{}, err
+	}
+	bundle.Path = filepath.Join(d.outDir, "blog-writer-diagnostics-"+d.now().UTC().Format("20060102-150405")+".zip")
+	if err := fsutil.WriteFileAtomic(bundle.Path, buf.Bytes(), 0o600); err != nil {
+		return DiagnosticBundle{}, err
+	}
+	bundle.IssueBody = red.String(issueBody(build, env, results, bundle.Path))
+	return bundle, nil
+}
+
+// logFiles returns the *.log files in the log directory, oldest first:
+// rotated files (name.N.log) by descending N, then the current files.
+func (d *DiagnosticsService) logFiles() ([]string, error) {
+	entries, err := os.ReadDir(d.logDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".log") {
+			paths = append(paths, filepath.Join(d.logDir, e.Name()))
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if ri, rj := rotationIndex(paths[i]), rotationIndex(paths[j]); ri != rj {
+			return ri > rj
+		}
+		return paths[i] < paths[j]
+	})
+	return paths, nil
+}
+
+// rotationIndex returns N for a rotated log file name.N.log and 0 for any
+// other file.
+func rotationIndex(path string) int {
+	name := strings.TrimSuffix(filepath.Base(path), ".log")
+	i := strings.LastIndexByte(name, '.')
+	if i < 0 {
+		return 0
+	}
+	n, err := strconv.Atoi(name[i+1:])
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+// readTail returns at most the last n bytes of the file at path.
+func readTail(path string, n int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > n {
+		if _, err := f.Seek(-n, io.SeekEnd); err != nil {
+			return nil, err
+		}
+	}
+	return io.ReadAll(f)
+}
+
+// issueBody summarizes the bundle for a GitHub issue.
+func issueBody(build about.Build, env DiagnosticEnvironment, results []ValidationResult, path string) string {
+	var b strings.Builder
+	b.WriteString("**Environment**\n\n")
+	fmt.Fprintf(&b, "- Blog Writer: %s", build.Version)
+	if build.Commit != "" {
+		fmt.Fprintf(&b, " (%s)", build.Commit)
+	}
+	fmt.Fprintf(&b, "\n- Go: %s\n- OS: %s/%s\n- Git: %s\n", build.GoVersion, env.OS, env.Arch, env.GitVersion)
+	if env.WebView != "" {
+		fmt.Fprintf(&b, "- WebView: %s\n", env.WebView)
+	}
+	if results != nil {
+		invalid := 0
+		for _, r := range results {
+			if !r.Valid {
+				invalid++
+			}
+		}
+		fmt.Fprintf(&b, "- Articles: %d (%d invalid)\n", len(results), invalid)
+	}
+	fmt.Fprintf(&b, "\nDiagnostic bundle: `%s` (please attach it to this issue)\n", filepath.Base(path))
+	return b.String()
+}
+
+// redactor removes sensitive values from bundle contents.
+type redactor struct {
+	paths  *strings.Replacer
+	emails bool
+}
+
+// newRedactor builds a redactor for opts. With Redact set, the repository
+// path and every repository listed in cfg become "<repo>" and the home
+// directory "~".
+func newRedactor(opts DiagnosticOptions, cfg config.Config) redactor {
+	if !opts.Redact {
+		return redactor{}
+	}
+	var pairs []string
+	replace := func(path, with string) {
+		// JSON escapes the separators of Windows paths.
+		if escaped := strings.ReplaceAll(path, `\`, `\\`); escaped != path {
+			pairs = append(pairs, escaped, with)
+		}
+		pairs = append(pairs, path, with)
+	}
+	with := map[string]string{}
+	repos := append([]string{opts.Repo}, cfg.Workspace.Repos...)
+	for _, r := range cfg.RecentlyOpened {
+		repos = append(repos, r.Path)
+	}
+	for _, r := range repos {
+		if r != "" {
+			with[r] = "<repo>"
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil && home != "" && home != "/" {
+		if _, ok := with[home]; !ok {
+			with[home] = "~"
+		}
+	}
+	// The replacer prefers earlier pairs, so longer paths go first and a
+	// repository inside another one or in the home directory stays whole.
+	paths := make([]string, 0, len(with))
+	for p := range with {
+		paths = append(paths, p)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if len(paths[i]) != len(paths[j]) {
+			return len(paths[i]) > len(paths[j])
+		}
+		return paths[i] < paths[j]
+	})
+	for _, p := range paths {
+		replace(p, with[p])
+	}
+	return redactor{paths: strings.NewReplacer(pairs...), emails: true}
+}
+
+// String returns s with credentials, and optionally paths and e-mail
+// addresses, replaced.
+func (r redactor) String(s string) string {
+	s = logging.Redact(s)
+	if r.paths != nil {
+		s = r.paths.Replace(s)
+	}
+	if r.emails {
+		s = emailRe.ReplaceAllString(s, "<email>")
+	}
+	return s
+}
@@ -2,7 +2,7 @@
 package services
 
 import (
-	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
@@ -43,17 +43,20 @@ func (d *DirectoryService) List(path string) ([]string, error) {
 // Create makes a new directory named name inside path after validation.
 func (d *DirectoryService) Create(path, name string) error {
 	if name == "" {
-		return errors.New("name required")
+		return newError(CodeInvalidArgument, "name required")
+	}
+	if !filepath.IsLocal(name) {
+		return fmt.Errorf("%w: %s", ErrPathOutsideRepo, name)
 	}
 	if strings.Contains(name, "..") {
-		return errors.New("invalid directory name")
+		return newError(CodeInvalidArgument, "invalid directory name")
 	}
 	if strings.ContainsAny(name, "/\\") {
-		return errors.New("invalid directory name")
+		return newError(CodeInvalidArgument, "invalid directory name")
 	}
 	cleaned := filepath.Clean(name)
 	if cleaned != name || filepath.Base(cleaned) != name {
-		return errors.New("invalid directory name")
+		return newError(CodeInvalidArgument, "invalid directory name")
 	}
 	target := filepath.Join(path, name)
 	return os.Mkdir(target, 0o755)
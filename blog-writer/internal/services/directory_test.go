@@ -2,6 +2,7 @@
 package services
 
 import (
+    "errors"
     "os"
     "path/filepath"
     "testing"
@@ -49,5 +50,10 @@ func TestDirectoryServiceCreate(t *testing.T) {
             t.Fatalf("expected error for %q", name)
         }
     }
+    for _, name := range []string{"..", "../etc"} {
+        if err := svc.Create(tmp, name); !errors.Is(err, ErrPathOutsideRepo) {
+            t.Fatalf("expected ErrPathOutsideRepo for %q, got %v", name, err)
+        }
+    }
 }
 
@@ -1,10 +1,196 @@
 // Copyright (c) 2024 blog-writer authors
 package services
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+
+	"blog-writer/internal/logging"
+)
+
+// ErrorCode is a stable identifier for a class of service error that the
+// frontend can branch on.
+type ErrorCode string
+
+// Error codes reported to the frontend.
+const (
+	CodeNotGitRepo         ErrorCode = "NOT_GIT_REPO"
+	CodeValidationFailed   ErrorCode = "VALIDATION_FAILED"
+	CodeMergeConflict      ErrorCode = "MERGE_CONFLICT"
+	CodeAuthRequired       ErrorCode = "AUTH_REQUIRED"
+	CodePathOutsideRepo    ErrorCode = "PATH_OUTSIDE_REPO"
+	CodeNetwork            ErrorCode = "NETWORK"
+	CodeGitFailed          ErrorCode = "GIT_FAILED"
+	CodeCancelled          ErrorCode = "CANCELLED"
+	CodeNotFound           ErrorCode = "NOT_FOUND"
+	CodePermissionDenied   ErrorCode = "PERMISSION_DENIED"
+	CodeInvalidArgument    ErrorCode = "INVALID_ARGUMENT"
+	CodeSettingsTooNew     ErrorCode = "SETTINGS_TOO_NEW"
+	CodeNotInWorkspace     ErrorCode = "NOT_IN_WORKSPACE"
+	CodeUnknownAction      ErrorCode = "UNKNOWN_ACTION"
+	CodeKeybindingConflict ErrorCode = "KEYBINDING_CONFLICT"
+	CodeUnknownSubsystem   ErrorCode = "UNKNOWN_SUBSYSTEM"
+	CodePluginFailed       ErrorCode = "PLUGIN_FAILED"
+	CodeInternal           ErrorCode = "INTERNAL"
+)
+
+// Error is a service error with a stable code. It is serialized to the
+// frontend as {code, message, details, retryable}; see AsError.
+type Error struct {
+	Code ErrorCode `json:"code"`
+	// Message is a human-readable description suitable for the UI.
+	Message string `json:"message"`
+	// Details carries machine-readable context such as validation issues
+	// or git stderr.
+	Details map[string]any `json:"details,omitempty"`
+	// Retryable reports whether repeating the operation may succeed.
+	Retryable bool `json:"retryable"`
+	// Err is the underlying cause, if any.
+	Err error `json:"-"`
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the underlying cause.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is an *Error with the same code, so that
+// errors.Is(err, ErrValidationFailed) matches every validation failure.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
 
 // Common service errors.
 var (
 	// ErrNotGitRepo indicates the provided path is not a git repository.
-	ErrNotGitRepo = errors.New("not a git repository")
+	ErrNotGitRepo = &Error{Code: CodeNotGitRepo, Message: "not a git repository"}
+	// ErrSettingsTooNew indicates settings.json was written by a newer, unknown schema version.
+	ErrSettingsTooNew = &Error{Code: CodeSettingsTooNew, Message: "settings were written by a newer version of blog-writer"}
+	// ErrNotInWorkspace indicates the repository is not part of the workspace.
+	ErrNotInWorkspace = &Error{Code: CodeNotInWorkspace, Message: "repository is not in the workspace"}
+	// ErrUnknownAction indicates a keybinding refers to an action that does not exist.
+	ErrUnknownAction = &Error{Code: CodeUnknownAction, Message: "unknown action"}
+	// ErrKeybindingConflict indicates a shortcut is already bound to another action.
+	ErrKeybindingConflict = &Error{Code: CodeKeybindingConflict, Message: "shortcut is already bound"}
+	// ErrUnknownSubsystem indicates a log level refers to an unknown subsystem.
+	ErrUnknownSubsystem = &Error{Code: CodeUnknownSubsystem, Message: "unknown log subsystem"}
+	// ErrValidationFailed matches any schema validation failure.
+	ErrValidationFailed = &Error{Code: CodeValidationFailed, Message: "validation failed"}
+	// ErrPathOutsideRepo indicates a path resolves outside its repository.
+	ErrPathOutsideRepo = &Error{Code: CodePathOutsideRepo, Message: "path is outside the repository"}
 )
+
+// newError returns an *Error with a formatted message.
+func newError(code ErrorCode, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// ValidationIssue is one schema violation in the details of a
+// VALIDATION_FAILED error.
+type ValidationIssue struct {
+	// Path is the JSON pointer of the offending value.
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// validationError wraps a schema validation failure of what, e.g.
+// "invalid settings", listing the violations in Details["issues"].
+func validationError(what string, err error) *Error {
+	e := &Error{Code: CodeValidationFailed, Message: what, Err: err}
+	var ve *jsonschema.ValidationError
+	if errors.As(err, &ve) {
+		var issues []ValidationIssue
+		for _, b := range ve.BasicOutput().Errors {
+			if b.Error != "" && !strings.HasPrefix(b.Error, "doesn't validate with") {
+				issues = append(issues, ValidationIssue{Path: b.InstanceLocation, Message: b.Error})
+			}
+		}
+		e.Details = map[string]any{"issues": issues}
+	}
+	return e
+}
+
+// gitStderrCodes maps fragments of git stderr to error codes, checked in
+// order.
+var gitStderrCodes = []struct {
+	code      ErrorCode
+	fragments []string
+}{
+	{CodeMergeConflict, []string{"CONFLICT", "Merge conflict", "could not apply", "needs merge", "unmerged files", "non-fast-forward", "fetch first"}},
+	{CodeAuthRequired, []string{"Authentication failed", "could not read Username", "could not read Password", "terminal prompts disabled", "Permission denied (publickey", "HTTP Basic: Access denied", "returned error: 403", "returned error: 401"}},
+	{CodeNetwork, []string{"Could not resolve host", "Connection timed out", "Connection refused", "Network is unreachable", "unable to access", "Could not read from remote repository", "early EOF", "The remote end hung up"}},
+	{CodeNotGitRepo, []string{"not a git repository"}},
+}
+
+// gitError classifies a failed git command by its stderr.
+func gitError(args []string, err error, stderr string) *Error {
+	e := &Error{Code: CodeGitFailed, Message: "git " + args[0], Err: err}
+	if stderr != "" {
+		e.Err = fmt.Errorf("%w: %s", err, stderr)
+	}
+	for _, c := range gitStderrCodes {
+		if containsAny(stderr, c.fragments) {
+			e.Code = c.code
+			break
+		}
+	}
+	e.Retryable = e.Code == CodeNetwork
+	e.Details = map[string]any{"command": args[0], "stderr": logging.Redact(stderr)}
+	return e
+}
+
+// containsAny reports whether s contains any of subs.
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// AsError converts err into an *Error for the frontend. Coded errors keep
+// their code, with Message extended by any context they were wrapped in;
+// well-known causes such as cancellation and missing files are classified; everything else becomes INTERNAL. A nil err yields nil.
+func AsError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		out := *e
+		out.Message = logging.Redact(err.Error())
+		if err == e {
+			out.Message = e.Message
+		}
+		return &out
+	}
+	out := &Error{Code: CodeInternal, Message: logging.Redact(err.Error()), Err: err}
+	var ve *jsonschema.ValidationError
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		out.Code, out.Retryable = CodeCancelled, true
+	case errors.Is(err, os.ErrNotExist):
+		out.Code = CodeNotFound
+	case errors.Is(err, os.ErrPermission):
+		out.Code = CodePermissionDenied
+	case errors.As(err, &ve):
+		v := validationError(out.Message, err)
+		out.Code, out.Details = v.Code, v.Details
+	}
+	return out
+}
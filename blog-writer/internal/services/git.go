@@ -0,0 +1,314 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"blog-writer/internal/events"
+	"blog-writer/internal/logging"
+)
+
+// gitWaitDelay bounds how long a cancelled git process may take to exit
+// after it has been asked to terminate before it is killed outright.
+const gitWaitDelay = 5 * time.Second
+
+// GitProgress reports the progress of a long-running git operation.
+type GitProgress struct {
+	Repo    string `json:"repo"`
+	Op      string `json:"op"`
+	Phase   string `json:"phase"`
+	Percent int    `json:"percent"`
+	Current int    `json:"current"`
+	Total   int    `json:"total"`
+}
+
+// GitService wraps the git CLI for long-running repository operations.
+type GitService struct {
+	// Events, if set, receives git.progress events during transfers and
+	// git.status.changed and git.branch.changed events after operations
+	// that change the repository.
+	Events *events.Bus
+}
+
+// NewGitService constructs a GitService.
+func NewGitService() *GitService {
+	return &GitService{}
+}
+
+// Fetch downloads objects and refs from origin.
+func (g *GitService) Fetch(repo string) error {
+	ctx, done := gitOps.start(repo)
+	defer done()
+	_, err := runGitProgress(ctx, repo, g.progress(repo, "fetch"), "fetch", "--progress", "origin")
+	return err
+}
+
+// Pull fetches from the upstream and rebases the current branch onto it.
+func (g *GitService) Pull(repo string) error {
+	ctx, done := gitOps.start(repo)
+	defer done()
+	_, err := runGitProgress(ctx, repo, g.progress(repo, "pull"), "pull", "--rebase", "--progress")
+	if err == nil {
+		g.Events.Publish(events.GitStatusChanged, repo, events.GitChange{Op: "pull"})
+	}
+	return err
+}
+
+// Push uploads the current branch to its upstream.
+func (g *GitService) Push(repo string) error {
+	ctx, done := gitOps.start(repo)
+	defer done()
+	_, err := runGitProgress(ctx, repo, g.progress(repo, "push"), "push", "--progress")
+	if err == nil {
+		g.Events.Publish(events.GitStatusChanged, repo, events.GitChange{Op: "push"})
+	}
+	return err
+}
+
+// Commit records the staged changes with message, optionally amending HEAD.
+func (g *GitService) Commit(repo, message string, amend bool) error {
+	ctx, done := gitOps.start(repo)
+	defer done()
+	args := []string{"commit", "-m", message}
+	if amend {
+		args = append(args, "--amend")
+	}
+	_, err := runGit(ctx, repo, args...)
+	if err == nil {
+		g.Events.Publish(events.GitStatusChanged, repo, events.GitChange{Op: "commit"})
+	}
+	return err
+}
+
+// Branch is a local branch of a repository.
+type Branch struct {
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+}
+
+// Branches lists the local branches of repo in name order.
+func (g *GitService) Branches(repo string) ([]Branch, error) {
+	out, err := runGit(context.Background(), repo, "branch", "--list", "--format=%(HEAD)%(refname:short)")
+	if err != nil {
+		return nil, err
+	}
+	branches := []Branch{}
+	for _, line := range strings.Split(out, "\n") {
+		if line == "" {
+			continue
+		}
+		name, current := strings.CutPrefix(line, "*")
+		branches = append(branches, Branch{Name: strings.TrimSpace(name), Current: current})
+	}
+	return branches, nil
+}
+
+// Checkout switches repo to the local branch name.
+func (g *GitService) Checkout(repo, name string) error {
+	ctx, done := gitOps.start(repo)
+	defer done()
+	_, err := runGit(ctx, repo, "checkout", name)
+	if err == nil {
+		g.Events.Publish(events.GitBranchChanged, repo, events.GitChange{Op: "checkout", Branch: name})
+	}
+	return err
+}
+
+// Cancel stops every running git operation on repo, including clones
+// targeting it. It reports whether any operation was cancelled.
+func (g *GitService) Cancel(repo string) bool {
+	return gitOps.cancel(repo)
+}
+
+// progress returns a callback publishing parsed progress tagged with repo
+// and op, or nil when there is no bus.
+func (g *GitService) progress(repo, op string) func(GitProgress) {
+	return publishProgress(g.Events, repo, op)
+}
+
+// publishProgress returns a callback publishing git progress tagged with
+// repo and op on bus, or nil when bus is nil.
+func publishProgress(bus *events.Bus, repo, op string) func(GitProgress) {
+	if bus == nil {
+		return nil
+	}
+	return func(p GitProgress) {
+		p.Repo, p.Op = repo, op
+		bus.Publish(events.GitProgress, repo, p)
+	}
+}
+
+// operations tracks cancellable git operations keyed by repository path.
+type operations struct {
+	mu      sync.Mutex
+	next    int
+	running map[string]map[int]context.CancelFunc
+}
+
+// gitOps is the tracker shared by all services running git.
+var gitOps = &operations{running: map[string]map[int]context.CancelFunc{}}
+
+// opKey normalizes a repository path for use as a tracker key.
+func opKey(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
+
+// start registers an operation on path and returns its context together
+// with a function that must be called when the operation finishes.
+func (o *operations) start(path string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	key := opKey(path)
+	o.mu.Lock()
+	o.next++
+	id := o.next
+	if o.running[key] == nil {
+		o.running[key] = map[int]context.CancelFunc{}
+	}
+	o.running[key][id] = cancel
+	o.mu.Unlock()
+	return ctx, func() {
+		o.mu.Lock()
+		delete(o.running[key], id)
+		if len(o.running[key]) == 0 {
+			delete(o.running, key)
+		}
+		o.mu.Unlock()
+		cancel()
+	}
+}
+
+// cancel cancels all operations on path.
+func (o *operations) cancel(path string) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	ops := o.running[opKey(path)]
+	for _, c := range ops {
+		c()
+	}
+	return len(ops) > 0
+}
+
+// runGit runs git with args in dir and returns its trimmed stdout. On
+// failure the error includes git's stderr so callers can surface it.
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	return runGitProgress(ctx, dir, nil, args...)
+}
+
+// runGitProgress runs git like runGit, feeding progress lines from stderr
+// to onProgress when it is non-nil. When ctx is cancelled the whole process
+// tree is terminated. Lock files are left to git: it removes its own when
+// terminated, and any other lock may belong to a concurrent process.
+func runGitProgress(ctx context.Context, dir string, onProgress func(GitProgress), args ...string) (string, error) {
+	start := time.Now()
+	log := gitLog.With("args", args, "dir", dir)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.WaitDelay = gitWaitDelay
+	setProcessGroup(cmd)
+	var stdout bytes.Buffer
+	stderr := &progressWriter{onProgress: onProgress}
+	cmd.Stdout = &stdout
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			log.Info("git cancelled", "duration", time.Since(start))
+			return "", fmt.Errorf("git %s cancelled: %w", args[0], ctx.Err())
+		}
+		msg := strings.TrimSpace(stderr.text())
+		log.Warn("git failed", "err", err, "stderr", msg, "duration", time.Since(start))
+		return "", gitError(args, err, msg)
+	}
+	if msg := strings.TrimSpace(stderr.text()); msg != "" {
+		log.Debug("git", "stderr", msg, "duration", time.Since(start))
+	} else {
+		log.Debug("git", "duration", time.Since(start))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// gitLog logs every git invocation, including stderr of failed commands.
+var gitLog = logging.For(logging.Git)
+
+// progressPattern matches git progress lines such as
+// "Receiving objects:  45% (450/1000), 1.20 MiB | 2.00 MiB/s".
+var progressPattern = regexp.MustCompile(`^(?:remote: )?([A-Za-z][A-Za-z ]*):\s+(\d+)% \((\d+)/(\d+)\)`)
+
+// parseProgress extracts progress from a single line of git stderr.
+func parseProgress(line string) (GitProgress, bool) {
+	m := progressPattern.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return GitProgress{}, false
+	}
+	pct, _ := strconv.Atoi(m[2])
+	cur, _ := strconv.Atoi(m[3])
+	total, _ := strconv.Atoi(m[4])
+	return GitProgress{Phase: m[1], Percent: pct, Current: cur, Total: total}, true
+}
+
+// progressWriter collects git stderr, reporting progress lines as they
+// arrive. Git separates progress updates with carriage returns.
+type progressWriter struct {
+	mu         sync.Mutex
+	onProgress func(GitProgress)
+	partial    []byte
+	lines      []string
+}
+
+// Write implements io.Writer.
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexAny(w.partial, "\r\n")
+		if i < 0 {
+			break
+		}
+		w.line(string(w.partial[:i]))
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// line handles a complete line of stderr.
+func (w *progressWriter) line(s string) {
+	if s == "" {
+		return
+	}
+	if p, ok := parseProgress(s); ok {
+		if w.onProgress != nil {
+			w.onProgress(p)
+		}
+		return
+	}
+	w.lines = append(w.lines, s)
+}
+
+// text returns the non-progress stderr output.
+func (w *progressWriter) text() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	out := strings.Join(w.lines, "\n")
+	if len(w.partial) > 0 {
+		out += "\n" + string(w.partial)
+	}
+	return out
+}
+
+// errCancelled reports whether err was caused by a cancelled operation.
+func errCancelled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"blog-writer/internal/events"
+)
+
+// TestParseProgress verifies git progress lines are recognised.
+func TestParseProgress(t *testing.T) {
+	cases := map[string]GitProgress{
+		"Receiving objects:  45% (450/1000), 1.20 MiB | 2.00 MiB/s": {Phase: "Receiving objects", Percent: 45, Current: 450, Total: 1000},
+		"remote: Counting objects: 100% (3/3), done.":               {Phase: "Counting objects", Percent: 100, Current: 3, Total: 3},
+		"Writing objects:   0% (0/12)":                              {Phase: "Writing objects", Percent: 0, Current: 0, Total: 12},
+	}
+	for line, want := range cases {
+		got, ok := parseProgress(line)
+		if !ok || got != want {
+			t.Errorf("parseProgress(%q) = %+v, %v", line, got, ok)
+		}
+	}
+	if _, ok := parseProgress("fatal: repository not found"); ok {
+		t.Error("expected non-progress line to be ignored")
+	}
+}
+
+// TestProgressWriter ensures carriage-return separated updates are split and
+// other output is kept for error messages.
+func TestProgressWriter(t *testing.T) {
+	var got []int
+	w := &progressWriter{onProgress: func(p GitProgress) { got = append(got, p.Percent) }}
+	_, _ = w.Write([]byte("Receiving objects:  10% (1/10)\rReceiving obj"))
+	_, _ = w.Write([]byte("ects:  50% (5/10)\rReceiving objects: 100% (10/10), done.\nfatal: boom\n"))
+	if len(got) != 3 || got[0] != 10 || got[1] != 50 || got[2] != 100 {
+		t.Fatalf("unexpected progress %v", got)
+	}
+	if w.text() != "fatal: boom" {
+		t.Fatalf("unexpected stderr %q", w.text())
+	}
+}
+
+// TestGitServiceFetchPushProgress ensures fetch and push report progress events.
+func TestGitServiceFetchPushProgress(t *testing.T) {
+	requireGit(t)
+	remote := newBareRemote(t)
+	repoSvc := NewRepoServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	local := filepath.Join(t.TempDir(), "local")
+	if err := repoSvc.CreateFromRemote(CreateOptions{Remote: remote, Path: local, Branch: "main", Push: true}); err != nil {
+		t.Fatalf("CreateFromRemote: %v", err)
+	}
+	var progress []GitProgress
+	svc := NewGitService()
+	svc.Events = events.New()
+	svc.Events.Subscribe(string(events.GitProgress), func(e events.Event) {
+		progress = append(progress, e.Data.(GitProgress))
+	})
+	if err := os.WriteFile(filepath.Join(local, "blog", "1755288225.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := runGit(context.Background(), local, "add", "."); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if err := svc.Commit(local, "chore(article): 1755288225 test [create]", false); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if err := svc.Push(local); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	if err := svc.Fetch(local); err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if len(progress) == 0 {
+		t.Fatal("expected progress events from push")
+	}
+	if progress[0].Op != "push" || progress[0].Repo != local {
+		t.Fatalf("unexpected event %+v", progress[0])
+	}
+}
+
+// TestGitServiceBranchesCheckoutPull ensures branches are listed with the
+// current one marked, checkout switches and pull rebases onto upstream.
+func TestGitServiceBranchesCheckoutPull(t *testing.T) {
+	requireGit(t)
+	remote := newBareRemote(t)
+	repoSvc := NewRepoServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	local := filepath.Join(t.TempDir(), "local")
+	if err := repoSvc.CreateFromRemote(CreateOptions{Remote: remote, Path: local, Branch: "main", Push: true}); err != nil {
+		t.Fatalf("CreateFromRemote: %v", err)
+	}
+	svc := NewGitService()
+	if err := svc.Pull(local); err != nil {
+		t.Fatalf("Pull: %v", err)
+	}
+	if _, err := runGit(context.Background(), local, "branch", "draft"); err != nil {
+		t.Fatalf("branch: %v", err)
+	}
+	if err := svc.Checkout(local, "draft"); err != nil {
+		t.Fatalf("Checkout: %v", err)
+	}
+	branches, err := svc.Branches(local)
+	if err != nil {
+		t.Fatalf("Branches: %v", err)
+	}
+	want := []Branch{{Name: "draft", Current: true}, {Name: "main"}}
+	if len(branches) != len(want) || branches[0] != want[0] || branches[1] != want[1] {
+		t.Fatalf("expected %+v, got %+v", want, branches)
+	}
+}
+
+// TestGitCancelKillsProcessGroup ensures cancellation stops git and its children promptly.
+func TestGitCancelKillsProcessGroup(t *testing.T) {
+	requireGit(t)
+	if runtime.GOOS == "windows" {
+		t.Skip("process groups are unix only")
+	}
+	repo := t.TempDir()
+	if _, err := runGit(context.Background(), repo, "init"); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	svc := NewGitService()
+	errc := make(chan error, 1)
+	started := make(chan struct{})
+	go func() {
+		ctx, done := gitOps.start(repo)
+		defer done()
+		close(started)
+		_, err := runGit(ctx, repo, "-c", "alias.slow=!sleep 30", "slow")
+		errc <- err
+	}()
+	<-started
+	time.Sleep(200 * time.Millisecond)
+	if !svc.Cancel(repo) {
+		t.Fatal("expected a running operation to cancel")
+	}
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(gitWaitDelay):
+		t.Fatal("cancelled git did not exit")
+	}
+	if svc.Cancel(repo) {
+		t.Fatal("expected no running operations after completion")
+	}
+}
+
+// TestCancelledRunKeepsExistingLock ensures cancellation never removes an
+// index.lock, which may be held by another process.
+func TestCancelledRunKeepsExistingLock(t *testing.T) {
+	requireGit(t)
+	repo := t.TempDir()
+	if _, err := runGit(context.Background(), repo, "init"); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	lock := filepath.Join(repo, ".git", "index.lock")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_ = os.WriteFile(lock, nil, 0o644)
+	if _, err := runGit(ctx, repo, "status"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if _, err := os.Stat(lock); err != nil {
+		t.Fatalf("pre-existing lock removed: %v", err)
+	}
+}
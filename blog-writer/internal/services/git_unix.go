@@ -0,0 +1,19 @@
+// Copyright (c) 2025 blog-writer authors
+//go:build !windows
+
+package services
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// setProcessGroup runs cmd in its own process group and terminates the whole
+// group on cancellation so helpers such as ssh and remote-https exit too.
+// SIGTERM lets git remove its lock files before exiting.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2025 blog-writer authors
+//go:build windows
+
+package services
+
+import (
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// setProcessGroup starts cmd in a new process group so cancellation does not
+// propagate console signals to the application. Cancellation kills the git
+// process and its descendants, such as ssh and git-remote-https, with
+// taskkill, falling back to killing git alone when taskkill fails.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+	cmd.Cancel = func() error {
+		kill := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid))
+		kill.SysProcAttr = &syscall.SysProcAttr{HideWindow: true}
+		if err := kill.Run(); err != nil {
+			return cmd.Process.Kill()
+		}
+		return nil
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"blog-writer/internal/schema"
+	"blog-writer/pkg/article"
+)
+
+// IndexEntry summarizes an article in a repository.
+type IndexEntry struct {
+	Repo     string   `json:"repo"`
+	ID       string   `json:"id"`
+	Path     string   `json:"path"`
+	Title    string   `json:"title"`
+	Author   string   `json:"author"`
+	Keywords []string `json:"keywords"`
+	// Error is set when the article could not be parsed.
+	Error string `json:"error,omitempty"`
+
+	text string
+}
+
+// SearchHit is an article matching a search query.
+type SearchHit struct {
+	IndexEntry
+	Snippet string `json:"snippet"`
+}
+
+// ValidationResult reports the schema validation outcome for one article.
+type ValidationResult struct {
+	Repo  string `json:"repo"`
+	ID    string `json:"id"`
+	Path  string `json:"path"`
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+// ArticleIndex caches article metadata and text for one repository.
+type ArticleIndex struct {
+	mu      sync.Mutex
+	repo    string
+	entries []IndexEntry
+	built   bool
+}
+
+// NewArticleIndex constructs an index for repo. It is built on first use.
+func NewArticleIndex(repo string) *ArticleIndex {
+	return &ArticleIndex{repo: repo}
+}
+
+// Refresh rescans the repository.
+func (x *ArticleIndex) Refresh() error {
+	paths, err := article.Scan(x.repo)
+	if err != nil {
+		return err
+	}
+	entries := make([]IndexEntry, 0, len(paths))
+	for _, p := range paths {
+		e := IndexEntry{Repo: x.repo, ID: article.ID(p), Path: p}
+		b, err := os.ReadFile(p)
+		if err == nil {
+			var a article.Article
+			if a, err = article.Parse(b); err == nil {
+				e.Title = a.Metadata.Title
+				e.Author = a.Metadata.Author
+				e.Keywords = a.Metadata.Keywords
+				e.text = articleText(a)
+			}
+		}
+		if err != nil {
+			e.Error = err.Error()
+		}
+		entries = append(entries, e)
+	}
+	x.mu.Lock()
+	x.entries, x.built = entries, true
+	x.mu.Unlock()
+	return nil
+}
+
+// Entries returns the indexed articles, building the index if needed.
+func (x *ArticleIndex) Entries() ([]IndexEntry, error) {
+	x.mu.Lock()
+	built := x.built
+	x.mu.Unlock()
+	if !built {
+		if err := x.Refresh(); err != nil {
+			return nil, err
+		}
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return append([]IndexEntry(nil), x.entries...), nil
+}
+
+// Search returns articles whose title, keywords or text contain query,
+// ignoring case. Title matches are listed first.
+func (x *ArticleIndex) Search(query string) ([]SearchHit, error) {
+	entries, err := x.Entries()
+	if err != nil {
+		return nil, err
+	}
+	q := strings.TrimSpace(query)
+	if q == "" {
+		return nil, nil
+	}
+	var hits []SearchHit
+	for _, e := range entries {
+		inTitle := containsFold(e.Title, q)
+		inKeywords := false
+		for _, k := range e.Keywords {
+			if containsFold(k, q) {
+				inKeywords = true
+			}
+		}
+		start, end := indexFold(e.text, q)
+		if !inTitle && !inKeywords && start < 0 {
+			continue
+		}
+		hits = append(hits, SearchHit{IndexEntry: e, Snippet: snippet(e.text, start, end)})
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		return containsFold(hits[i].Title, q) && !containsFold(hits[j].Title, q)
+	})
+	return hits, nil
+}
+
+// Validate checks every article in the repository against the schema.
+func (x *ArticleIndex) Validate() ([]ValidationResult, error) {
+	paths, err := article.Scan(x.repo)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]ValidationResult, 0, len(paths))
+	for _, p := range paths {
+		r := ValidationResult{Repo: x.repo, ID: article.ID(p), Path: p, Valid: true}
+		b, err := os.ReadFile(p)
+		if err == nil {
+			err = schema.Validate(b)
+		}
+		if err != nil {
+			r.Valid, r.Error = false, err.Error()
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// articleText concatenates the text content of an article.
+func articleText(a article.Article) string {
+	var sb strings.Builder
+	article.Walk(a.Document, func(n *article.Node, _ int) bool {
+		if n.Content.IsText() && n.Tag != "math" && n.Tag != "pre" {
+			if sb.Len() > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(*n.Content.Text)
+		}
+		return true
+	})
+	return sb.String()
+}
+
+// snippet returns up to 40 bytes of context around the match text[start:end].
+func snippet(text string, start, end int) string {
+	if start < 0 {
+		return ""
+	}
+	start = max(start-40, 0)
+	end = min(end+40, len(text))
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+	return strings.TrimSpace(text[start:end])
+}
+
+// containsFold reports whether substr occurs in s under simple case folding.
+func containsFold(s, substr string) bool {
+	start, _ := indexFold(s, substr)
+	return start >= 0
+}
+
+// indexFold returns the byte range of the first occurrence of substr in s
+// under simple case folding, or -1, -1. Runes are compared one by one so
+// the offsets always refer to s, even where changing case would change the
+// encoded length.
+func indexFold(s, substr string) (int, int) {
+	for i := range s {
+		j, k := i, 0
+		for k < len(substr) && j < len(s) {
+			a, na := utf8.DecodeRuneInString(s[j:])
+			b, nb := utf8.DecodeRuneInString(substr[k:])
+			if !equalFoldRune(a, b) {
+				break
+			}
+			j, k = j+na, k+nb
+		}
+		if k == len(substr) {
+			return i, j
+		}
+	}
+	return -1, -1
+}
+
+// equalFoldRune reports whether a and b are equal under simple case folding.
+func equalFoldRune(a, b rune) bool {
+	if a == b {
+		return true
+	}
+	for r := unicode.SimpleFold(a); r != a; r = unicode.SimpleFold(r) {
+		if r == b {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestIndexFold ensures matches report offsets into the original text even
+// when changing case would change its byte length.
+func TestIndexFold(t *testing.T) {
+	text := strings.Repeat("İ", 60) + " find the Needle here"
+	start, end := indexFold(text, "NEEDLE")
+	if start < 0 || text[start:end] != "Needle" {
+		t.Fatalf("unexpected match %d:%d", start, end)
+	}
+	if s := snippet(text, start, end); !strings.Contains(s, "Needle here") {
+		t.Fatalf("snippet misses match: %q", s)
+	}
+	if start, end := indexFold("İstanbul", "İSTANBUL"); start != 0 || end != len("İstanbul") {
+		t.Fatalf("unexpected match %d:%d", start, end)
+	}
+	if !containsFold("\u212Aey", "key") {
+		t.Fatal("expected Kelvin sign to fold to k")
+	}
+	if start, _ := indexFold(text, "haystack"); start != -1 {
+		t.Fatalf("unexpected match at %d", start)
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"fmt"
+	"maps"
+	"runtime"
+	"slices"
+
+	"blog-writer/internal/config"
+	"blog-writer/internal/events"
+	"blog-writer/internal/keymap"
+)
+
+// KeybindingService reads and updates the user's keyboard shortcuts.
+type KeybindingService struct {
+	cfgPath string
+	goos    string
+
+	// Events, if set, receives a keybindings.changed event after an update.
+	Events *events.Bus
+}
+
+// NewKeybindingService constructs a KeybindingService backed by the user's
+// config file.
+func NewKeybindingService() (*KeybindingService, error) {
+	p, err := config.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return NewKeybindingServiceWithPath(p), nil
+}
+
+// NewKeybindingServiceWithPath constructs a KeybindingService with a custom
+// config file path. Mainly used for tests.
+func NewKeybindingServiceWithPath(path string) *KeybindingService {
+	return &KeybindingService{cfgPath: path, goos: runtime.GOOS}
+}
+
+// List returns the effective binding of every action, including conflicts
+// introduced by hand-edited config files.
+func (k *KeybindingService) List() ([]keymap.Binding, error) {
+	cfg, err := config.Load(k.cfgPath)
+	if err != nil {
+		return nil, err
+	}
+	return keymap.Bindings(cfg.Keybindings, k.goos), nil
+}
+
+// Set binds shortcut to action. An empty shortcut unbinds it. The shortcut
+// is rejected with ErrKeybindingConflict when another action already uses it.
+func (k *KeybindingService) Set(action, shortcut string) error {
+	if _, ok := keymap.Find(action); !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownAction, action)
+	}
+	shortcut, err := keymap.Canonical(shortcut)
+	if err != nil {
+		return err
+	}
+	return k.update(func(cfg *config.Config) error {
+		overrides := maps.Clone(cfg.Keybindings)
+		if overrides == nil {
+			overrides = map[string]string{}
+		}
+		overrides[action] = shortcut
+		if err := k.checkConflict(overrides, action, shortcut); err != nil {
+			return err
+		}
+		if a, _ := keymap.Find(action); keymap.Normalize(a.Default) == shortcut {
+			delete(cfg.Keybindings, action)
+			return nil
+		}
+		if cfg.Keybindings == nil {
+			cfg.Keybindings = map[string]string{}
+		}
+		cfg.Keybindings[action] = shortcut
+		return nil
+	})
+}
+
+// Reset restores the default shortcut of action, or of every action when
+// action is empty. Like Set it fails with ErrKeybindingConflict when another
+// action has been bound to the default shortcut.
+func (k *KeybindingService) Reset(action string) error {
+	var def keymap.Action
+	if action != "" {
+		a, ok := keymap.Find(action)
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownAction, action)
+		}
+		def = a
+	}
+	return k.update(func(cfg *config.Config) error {
+		if action == "" {
+			cfg.Keybindings = nil
+			return nil
+		}
+		overrides := maps.Clone(cfg.Keybindings)
+		delete(overrides, action)
+		if err := k.checkConflict(overrides, action, keymap.Normalize(def.Default)); err != nil {
+			return err
+		}
+		delete(cfg.Keybindings, action)
+		return nil
+	})
+}
+
+// checkConflict returns ErrKeybindingConflict when action shares shortcut
+// with another action under overrides.
+func (k *KeybindingService) checkConflict(overrides map[string]string, action, shortcut string) error {
+	for _, group := range keymap.Resolve(overrides).Conflicts(k.goos) {
+		if !slices.Contains(group, action) {
+			continue
+		}
+		for _, other := range group {
+			if other != action {
+				return fmt.Errorf("%w: %s is used by %s", ErrKeybindingConflict, shortcut, other)
+			}
+		}
+	}
+	return nil
+}
+
+// update applies fn to the config, saves it and publishes the new bindings.
+func (k *KeybindingService) update(fn func(cfg *config.Config) error) error {
+	var bindings map[string]string
+	err := config.Update(k.cfgPath, func(cfg *config.Config) error {
+		if err := fn(cfg); err != nil {
+			return err
+		}
+		bindings = cfg.Keybindings
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	k.Events.Publish(events.KeybindingsChanged, "", keymap.Bindings(bindings, k.goos))
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"blog-writer/internal/config"
+	"blog-writer/internal/events"
+	"blog-writer/internal/keymap"
+)
+
+// binding returns the binding for action from list.
+func binding(t *testing.T, list []keymap.Binding, action string) keymap.Binding {
+	t.Helper()
+	for _, b := range list {
+		if b.ID == action {
+			return b
+		}
+	}
+	t.Fatalf("binding %s not found", action)
+	return keymap.Binding{}
+}
+
+// TestKeybindingSetReset ensures shortcuts are stored canonically, conflicts
+// are rejected and resets restore defaults.
+func TestKeybindingSetReset(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yml")
+	svc := NewKeybindingServiceWithPath(cfgPath)
+	svc.goos = "linux"
+	var changes int
+	svc.Events = events.New()
+	svc.Events.Subscribe(string(events.KeybindingsChanged), func(events.Event) { changes++ })
+
+	if err := svc.Set("file.save", "shift+ctrl+w"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := svc.Set("git.commit", "CmdOrCtrl+Shift+W"); !errors.Is(err, ErrKeybindingConflict) {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+	if err := svc.Set("nope", "CmdOrCtrl+J"); !errors.Is(err, ErrUnknownAction) {
+		t.Fatalf("expected unknown action, got %v", err)
+	}
+	if err := svc.Set("git.commit", ""); err != nil {
+		t.Fatalf("unbind: %v", err)
+	}
+	list, err := svc.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if b := binding(t, list, "file.save"); b.Shortcut != "Ctrl+Shift+W" || !b.Customized {
+		t.Fatalf("unexpected save binding %+v", b)
+	}
+	if b := binding(t, list, "git.commit"); b.Shortcut != "" {
+		t.Fatalf("expected commit unbound, got %+v", b)
+	}
+
+	// Setting the default again drops the override.
+	if err := svc.Set("file.save", "CmdOrCtrl+S"); err != nil {
+		t.Fatalf("Set default: %v", err)
+	}
+	cfg, _ := config.Load(cfgPath)
+	if _, ok := cfg.Keybindings["file.save"]; ok {
+		t.Fatalf("default kept as override: %v", cfg.Keybindings)
+	}
+
+	if err := svc.Reset(""); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	cfg, _ = config.Load(cfgPath)
+	if len(cfg.Keybindings) != 0 {
+		t.Fatalf("expected no overrides, got %v", cfg.Keybindings)
+	}
+	if changes != 4 {
+		t.Fatalf("expected 4 change notifications, got %d", changes)
+	}
+}
+
+// TestKeybindingResetConflict ensures resetting an action to a default
+// shortcut now used by another action is rejected like Set.
+func TestKeybindingResetConflict(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yml")
+	svc := NewKeybindingServiceWithPath(cfgPath)
+	svc.goos = "linux"
+	if err := svc.Set("file.save", "Ctrl+Shift+W"); err != nil {
+		t.Fatalf("Set save: %v", err)
+	}
+	if err := svc.Set("git.commit", "Ctrl+S"); err != nil {
+		t.Fatalf("Set commit: %v", err)
+	}
+	if err := svc.Reset("file.save"); !errors.Is(err, ErrKeybindingConflict) {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+	cfg, _ := config.Load(cfgPath)
+	if cfg.Keybindings["file.save"] != "Ctrl+Shift+W" {
+		t.Fatalf("override removed despite conflict: %v", cfg.Keybindings)
+	}
+	if err := svc.Reset("git.commit"); err != nil {
+		t.Fatalf("Reset commit: %v", err)
+	}
+	if err := svc.Reset("file.save"); err != nil {
+		t.Fatalf("Reset save: %v", err)
+	}
+	cfg, _ = config.Load(cfgPath)
+	if len(cfg.Keybindings) != 0 {
+		t.Fatalf("expected no overrides, got %v", cfg.Keybindings)
+	}
+}
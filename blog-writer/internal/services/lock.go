@@ -0,0 +1,130 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"blog-writer/internal/lockfile"
+	"blog-writer/internal/logging"
+)
+
+// lockLog reports repository lock files that could not be removed.
+var lockLog = logging.For(logging.FS)
+
+// repoLockRelPath is the per-repository lock file relative to the repository root.
+var repoLockRelPath = filepath.Join(".blog-writer", "lock")
+
+// RepoLockWarning reports that another process already has a repository open.
+type RepoLockWarning struct {
+	Repo    string    `json:"repo"`
+	PID     int       `json:"pid"`
+	Host    string    `json:"host"`
+	Since   time.Time `json:"since"`
+	Message string    `json:"message"`
+}
+
+// repoLockTable tracks the repository locks held by this process. Locks are
+// reference counted because a repository can be open both as the current
+// repository and in the workspace.
+type repoLockTable struct {
+	mu   sync.Mutex
+	held map[string]int
+}
+
+// repoLocks is the process-wide lock table.
+var repoLocks = &repoLockTable{held: map[string]int{}}
+
+// acquire locks repo for this process. When another process holds the lock
+// a warning is returned and the repository is left unlocked.
+func (t *repoLockTable) acquire(repo string) (*RepoLockWarning, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.held[repo] > 0 {
+		t.held[repo]++
+		return nil, nil
+	}
+	owner, err := lockfile.Acquire(filepath.Join(repo, repoLockRelPath))
+	if errors.Is(err, lockfile.ErrLocked) {
+		return &RepoLockWarning{
+			Repo:    repo,
+			PID:     owner.PID,
+			Host:    owner.Host,
+			Since:   owner.Since,
+			Message: fmt.Sprintf("%s is already open in %s; changes may conflict", filepath.Base(repo), owner),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	excludeRepoLock(repo)
+	t.held[repo] = 1
+	return nil, nil
+}
+
+// release drops one reference to the lock on repo, removing the lock file
+// with the last one.
+func (t *repoLockTable) release(repo string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.held[repo] == 0 {
+		return
+	}
+	t.held[repo]--
+	if t.held[repo] == 0 {
+		delete(t.held, repo)
+		releaseRepoLock(repo)
+	}
+}
+
+// releaseRepoLock removes the lock file of repo. Failures are only logged
+// because a lock left behind is taken over once this process has exited.
+func releaseRepoLock(repo string) {
+	if err := lockfile.Release(filepath.Join(repo, repoLockRelPath)); err != nil {
+		lockLog.Warn("release repository lock", "repo", repo, "err", err)
+	}
+}
+
+// ReleaseRepoLocks removes every repository lock held by this process.
+// It is called on shutdown.
+func ReleaseRepoLocks() {
+	repoLocks.mu.Lock()
+	defer repoLocks.mu.Unlock()
+	for repo := range repoLocks.held {
+		releaseRepoLock(repo)
+	}
+	repoLocks.held = map[string]int{}
+}
+
+// excludeRepoLock keeps the lock file out of git status by listing it in
+// .git/info/exclude. Failures are logged; the lock itself still holds.
+func excludeRepoLock(repo string) {
+	entry := "/" + filepath.ToSlash(repoLockRelPath)
+	p := filepath.Join(repo, ".git", "info", "exclude")
+	b, err := os.ReadFile(p)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		lockLog.Warn("exclude repository lock", "repo", repo, "err", err)
+		return
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		if strings.TrimSpace(line) == entry {
+			return
+		}
+	}
+	if len(b) > 0 && b[len(b)-1] != '\n' {
+		b = append(b, '\n')
+	}
+	b = append(b, entry+"\n"...)
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		lockLog.Warn("exclude repository lock", "repo", repo, "err", err)
+		return
+	}
+	if err := os.WriteFile(p, b, 0o644); err != nil {
+		lockLog.Warn("exclude repository lock", "repo", repo, "err", err)
+	}
+}
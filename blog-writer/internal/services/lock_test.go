@@ -0,0 +1,80 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"blog-writer/internal/events"
+)
+
+// newLockRepo creates a fake git repository for lock tests.
+func newLockRepo(t *testing.T) string {
+	t.Helper()
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	return repo
+}
+
+// TestRepoLockOpen ensures Open locks the repository, hides the lock from
+// git and moves the lock when another repository is opened.
+func TestRepoLockOpen(t *testing.T) {
+	svc := NewRepoServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	a, b := newLockRepo(t), newLockRepo(t)
+	if err := svc.Open(a); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(a, repoLockRelPath)); err != nil {
+		t.Fatalf("lock missing: %v", err)
+	}
+	exclude, _ := os.ReadFile(filepath.Join(a, ".git", "info", "exclude"))
+	if !strings.Contains(string(exclude), "/.blog-writer/lock") {
+		t.Fatalf("lock not excluded: %q", exclude)
+	}
+	if err := svc.Open(b); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(a, repoLockRelPath)); !os.IsNotExist(err) {
+		t.Fatalf("previous lock not released: %v", err)
+	}
+	svc.Close()
+	if _, err := os.Stat(filepath.Join(b, repoLockRelPath)); !os.IsNotExist(err) {
+		t.Fatalf("lock not released on close: %v", err)
+	}
+}
+
+// TestRepoLockWarning ensures a repository held by another live process is
+// reported without failing Open.
+func TestRepoLockWarning(t *testing.T) {
+	repo := newLockRepo(t)
+	if err := os.MkdirAll(filepath.Join(repo, ".blog-writer"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	host, _ := os.Hostname()
+	lock := `{"pid":` + strconv.Itoa(os.Getppid()) + `,"host":"` + host + `","since":"2025-01-01T00:00:00Z"}`
+	if err := os.WriteFile(filepath.Join(repo, repoLockRelPath), []byte(lock), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	svc := NewRepoServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	var warnings []RepoLockWarning
+	svc.Events = events.New()
+	svc.Events.Subscribe(string(events.RepoLocked), func(e events.Event) {
+		warnings = append(warnings, e.Data.(RepoLockWarning))
+	})
+	if err := svc.Open(repo); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].PID != os.Getppid() || warnings[0].Repo != repo {
+		t.Fatalf("unexpected warnings: %+v", warnings)
+	}
+	svc.Close()
+	if _, err := os.Stat(filepath.Join(repo, repoLockRelPath)); err != nil {
+		t.Fatalf("foreign lock removed: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"blog-writer/internal/config"
+	"blog-writer/internal/logging"
+)
+
+// LogLevel is the minimum level logged for a subsystem.
+type LogLevel struct {
+	Subsystem string `json:"subsystem"`
+	Level     string `json:"level"`
+}
+
+// LogService backs the in-app log viewer: it returns recent log entries and
+// reads and updates the per-subsystem log levels.
+type LogService struct {
+	mu      sync.Mutex
+	cfgPath string
+}
+
+// NewLogService constructs a LogService storing levels in the user's
+// config file.
+func NewLogService() (*LogService, error) {
+	p, err := config.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return NewLogServiceWithPath(p), nil
+}
+
+// NewLogServiceWithPath constructs a LogService with a custom config file
+// path. Mainly used for tests.
+func NewLogServiceWithPath(path string) *LogService {
+	return &LogService{cfgPath: path}
+}
+
+// Recent returns up to limit of the most recent log entries, oldest first.
+// New entries are streamed to the frontend as "log:entry" events.
+func (s *LogService) Recent(limit int) []logging.Entry {
+	return logging.Default().Recent(limit)
+}
+
+// Levels returns the effective level of every subsystem.
+func (s *LogService) Levels() []LogLevel {
+	l := logging.Default()
+	out := make([]LogLevel, 0, len(logging.Subsystems))
+	for _, sub := range logging.Subsystems {
+		out = append(out, LogLevel{Subsystem: sub, Level: strings.ToLower(l.Level(sub).String())})
+	}
+	return out
+}
+
+// SetLevel changes the level of subsystem immediately and stores it in the
+// user config.
+func (s *LogService) SetLevel(subsystem, level string) error {
+	if !slices.Contains(logging.Subsystems, subsystem) {
+		return fmt.Errorf("%w: %s", ErrUnknownSubsystem, subsystem)
+	}
+	lvl, err := logging.ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err = config.Update(s.cfgPath, func(cfg *config.Config) error {
+		if cfg.Logging.Levels == nil {
+			cfg.Logging.Levels = map[string]string{}
+		}
+		cfg.Logging.Levels[subsystem] = strings.ToLower(lvl.String())
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	logging.Default().SetLevel(subsystem, lvl)
+	return nil
+}
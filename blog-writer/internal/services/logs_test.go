@@ -0,0 +1,62 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"blog-writer/internal/config"
+	"blog-writer/internal/logging"
+)
+
+// TestLogServiceSetLevel ensures levels are applied and persisted and
+// invalid input is rejected.
+func TestLogServiceSetLevel(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yml")
+	svc := NewLogServiceWithPath(cfgPath)
+	l := logging.Default()
+	defer l.SetLevel(logging.Git, l.Level(logging.Git))
+
+	if err := svc.SetLevel(logging.Git, "DEBUG"); err != nil {
+		t.Fatalf("SetLevel: %v", err)
+	}
+	if l.Level(logging.Git) != slog.LevelDebug {
+		t.Fatal("level not applied")
+	}
+	cfg, err := config.Load(cfgPath)
+	if err != nil || cfg.Logging.Levels[logging.Git] != "debug" {
+		t.Fatalf("level not stored: %+v, %v", cfg.Logging, err)
+	}
+	var found bool
+	for _, lvl := range svc.Levels() {
+		if lvl.Subsystem == logging.Git {
+			found = lvl.Level == "debug"
+		}
+	}
+	if !found {
+		t.Fatalf("unexpected levels %+v", svc.Levels())
+	}
+	if err := svc.SetLevel("db", "debug"); !errors.Is(err, ErrUnknownSubsystem) {
+		t.Fatalf("expected ErrUnknownSubsystem, got %v", err)
+	}
+	if err := svc.SetLevel(logging.Git, "loud"); err == nil {
+		t.Fatal("expected invalid level error")
+	}
+}
+
+// TestGitFailureLogged ensures failed git commands are logged with stderr.
+func TestGitFailureLogged(t *testing.T) {
+	l, _ := logging.New(&bytes.Buffer{}, logging.Options{})
+	defer logging.SetDefault(logging.SetDefault(l))
+
+	if _, err := runGit(t.Context(), t.TempDir(), "rev-parse", "HEAD"); err == nil {
+		t.Fatal("expected git to fail outside a repository")
+	}
+	entries := l.Recent(0)
+	if len(entries) == 0 || entries[len(entries)-1].Message != "git failed" || entries[len(entries)-1].Attrs["stderr"] == "" {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+}
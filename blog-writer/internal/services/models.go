@@ -1,23 +1,53 @@
 // Copyright (c) 2024 blog-writer authors
 package services
 
+// CurrentSettingsSchemaVersion is the settings.json schema version written by this build.
+const CurrentSettingsSchemaVersion = 1
+
 // Settings represents repository settings stored in .blog-writer/settings.json.
 type Settings struct {
-	SchemaVersion       int      `json:"schemaVersion"`
-	DefaultAuthor       string   `json:"defaultAuthor"`
-	DefaultKeywords     []string `json:"defaultKeywords"`
-	DefaultBranch       string   `json:"defaultBranch"`
-	Remote              string   `json:"remote"`
-	PreCommitValidate   bool     `json:"preCommitValidate"`
-	MaxEmbeddedSvgBytes int      `json:"maxEmbeddedSvgBytes"`
-	MaxSvgNodeCount     int      `json:"maxSvgNodeCount"`
-	ImageVectorization  struct {
-		Mode      string  `json:"mode"`
-		Threshold float64 `json:"threshold"`
-		Colors    int     `json:"colors"`
-	} `json:"imageVectorization"`
-	Autosave struct {
-		Enabled    bool `json:"enabled"`
-		IntervalMs int  `json:"intervalMs"`
-	} `json:"autosave"`
+	SchemaVersion       int                `json:"schemaVersion"`
+	DefaultAuthor       string             `json:"defaultAuthor"`
+	DefaultKeywords     []string           `json:"defaultKeywords"`
+	DefaultBranch       string             `json:"defaultBranch"`
+	Remote              string             `json:"remote"`
+	PreCommitValidate   bool               `json:"preCommitValidate"`
+	MaxEmbeddedSvgBytes int                `json:"maxEmbeddedSvgBytes"`
+	MaxSvgNodeCount     int                `json:"maxSvgNodeCount"`
+	ImageVectorization  ImageVectorization `json:"imageVectorization"`
+	Autosave            Autosave           `json:"autosave"`
+}
+
+// ImageVectorization configures raster to SVG conversion.
+type ImageVectorization struct {
+	Mode      string  `json:"mode"`
+	Threshold float64 `json:"threshold"`
+	Colors    int     `json:"colors"`
+}
+
+// Autosave configures periodic write-only saves.
+type Autosave struct {
+	Enabled    bool `json:"enabled"`
+	IntervalMs int  `json:"intervalMs"`
+}
+
+// DefaultSettings returns the repository settings defined by the specification.
+func DefaultSettings() Settings {
+	return Settings{
+		SchemaVersion:       CurrentSettingsSchemaVersion,
+		DefaultKeywords:     []string{},
+		DefaultBranch:       "main",
+		PreCommitValidate:   true,
+		MaxEmbeddedSvgBytes: 10485760,
+		MaxSvgNodeCount:     100000,
+		ImageVectorization: ImageVectorization{
+			Mode:      "auto",
+			Threshold: 0.6,
+			Colors:    8,
+		},
+		Autosave: Autosave{
+			Enabled:    true,
+			IntervalMs: 15000,
+		},
+	}
 }
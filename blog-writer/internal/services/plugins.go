@@ -0,0 +1,232 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"blog-writer/internal/about"
+	"blog-writer/internal/config"
+	"blog-writer/internal/fsutil"
+	"blog-writer/internal/plugins"
+	"blog-writer/pkg/plugin"
+)
+
+// pluginsDir is the directory holding plugins, relative to a repository
+// root and to the user config directory.
+const pluginsDir = "plugins"
+
+// PluginService runs external plugins for repositories. Plugins in the
+// user config directory always run; those in a repository's
+// .blog-writer/plugins only run once the repository is trusted.
+type PluginService struct {
+	mu      sync.Mutex
+	cfgPath string
+	hosts   map[string]*plugins.Host
+
+	// Articles loads the articles passed to plugins and saves imported
+	// articles and command results.
+	Articles *ArticleService
+}
+
+// NewPluginService constructs a PluginService using the user's config file.
+func NewPluginService() (*PluginService, error) {
+	p, err := config.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return NewPluginServiceWithPath(p), nil
+}
+
+// NewPluginServiceWithPath constructs a PluginService with a custom config
+// file path. Mainly used for tests.
+func NewPluginServiceWithPath(path string) *PluginService {
+	return &PluginService{cfgPath: path, hosts: map[string]*plugins.Host{}, Articles: NewArticleService()}
+}
+
+// List starts the plugins available to repo, if not yet running, and
+// describes them.
+func (s *PluginService) List(repo string) ([]plugins.Info, error) {
+	h, err := s.host(repo)
+	if err != nil {
+		return nil, err
+	}
+	return h.Plugins(), nil
+}
+
+// Trusted reports whether the plugins in repo may run.
+func (s *PluginService) Trusted(repo string) (bool, error) {
+	cfg, err := config.Load(s.cfgPath)
+	if err != nil {
+		return false, err
+	}
+	return slices.Contains(cfg.Plugins.TrustedRepos, filepath.Clean(repo)), nil
+}
+
+// SetTrusted allows or forbids running the plugins in repo and restarts
+// its plugins.
+func (s *PluginService) SetTrusted(repo string, trusted bool) error {
+	repo = filepath.Clean(repo)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err := config.Update(s.cfgPath, func(cfg *config.Config) error {
+		repos := slices.DeleteFunc(cfg.Plugins.TrustedRepos, func(p string) bool { return p == repo })
+		if trusted {
+			repos = append(repos, repo)
+		}
+		cfg.Plugins.TrustedRepos = repos
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.stop(repo)
+	return nil
+}
+
+// Reload stops the plugins of repo; they are rediscovered on next use.
+func (s *PluginService) Reload(repo string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stop(filepath.Clean(repo))
+}
+
+// Export converts article id with the exporter ref ("<plugin>/<id>") and
+// writes the result to dest.
+func (s *PluginService) Export(repo, ref, id, dest string) error {
+	h, doc, err := s.prepare(repo, id)
+	if err != nil {
+		return err
+	}
+	res, err := h.Export(context.Background(), ref, plugin.ExportRequest{Repo: repo, ID: id, Article: doc})
+	if err != nil {
+		return pluginError(err)
+	}
+	return fsutil.WriteFileAtomic(dest, res.Data, 0o644)
+}
+
+// Lint runs every linter on article id.
+func (s *PluginService) Lint(repo, id string) ([]plugin.Diagnostic, error) {
+	h, doc, err := s.prepare(repo, id)
+	if err != nil {
+		return nil, err
+	}
+	return h.Lint(context.Background(), plugin.LintRequest{Repo: repo, ID: id, Article: doc}), nil
+}
+
+// Import converts the file at path with the importer ref and saves it as a
+// new article, returning its ID.
+func (s *PluginService) Import(repo, ref, path string, commit bool) (string, error) {
+	h, err := s.host(repo)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	res, err := h.Import(context.Background(), ref, plugin.ImportRequest{Repo: repo, Name: filepath.Base(path), Data: data})
+	if err != nil {
+		return "", pluginError(err)
+	}
+	return s.Articles.Save(repo, "", res.Article, commit)
+}
+
+// Run executes the command ref with args, on article id unless id is
+// empty. An article returned by the command is saved over id without
+// committing.
+func (s *PluginService) Run(repo, ref, id string, args []string) (plugin.CommandResult, error) {
+	req := plugin.CommandRequest{Repo: repo, ID: id, Args: args}
+	h, err := s.host(repo)
+	if err != nil {
+		return plugin.CommandResult{}, err
+	}
+	if id != "" {
+		doc, err := s.Articles.Load(repo, id)
+		if err != nil {
+			return plugin.CommandResult{}, err
+		}
+		req.Article = &doc
+	}
+	res, err := h.Command(context.Background(), ref, req)
+	if err != nil {
+		return plugin.CommandResult{}, pluginError(err)
+	}
+	if res.Article != nil && id != "" {
+		if _, err := s.Articles.Save(repo, id, *res.Article, false); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// Close stops every running plugin.
+func (s *PluginService) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for repo := range s.hosts {
+		s.stop(repo)
+	}
+}
+
+// prepare returns the plugin host of repo and article id.
+func (s *PluginService) prepare(repo, id string) (*plugins.Host, plugin.Article, error) {
+	h, err := s.host(repo)
+	if err != nil {
+		return nil, plugin.Article{}, err
+	}
+	doc, err := s.Articles.Load(repo, id)
+	return h, doc, err
+}
+
+// host returns the running plugins of repo, starting them if needed.
+func (s *PluginService) host(repo string) (*plugins.Host, error) {
+	repo = filepath.Clean(repo)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if h, ok := s.hosts[repo]; ok {
+		return h, nil
+	}
+	cfg, err := config.Load(s.cfgPath)
+	if err != nil {
+		return nil, err
+	}
+	var dirs []plugins.Dir
+	if slices.Contains(cfg.Plugins.TrustedRepos, repo) {
+		dirs = append(dirs, plugins.Dir{Path: filepath.Join(repo, ".blog-writer", pluginsDir), Source: plugins.SourceRepo})
+	}
+	dirs = append(dirs, plugins.Dir{Path: filepath.Join(filepath.Dir(s.cfgPath), pluginsDir), Source: plugins.SourceUser})
+	h := plugins.Start(context.Background(), dirs, plugins.Options{
+		Dir:         repo,
+		Timeout:     time.Duration(cfg.Plugins.TimeoutSeconds) * time.Second,
+		HostVersion: about.BuildInfo().Version,
+	})
+	s.hosts[repo] = h
+	return h, nil
+}
+
+// stop shuts down the plugins of repo. The caller holds s.mu.
+func (s *PluginService) stop(repo string) {
+	if h, ok := s.hosts[repo]; ok {
+		h.Close()
+		delete(s.hosts, repo)
+	}
+}
+
+// pluginError classifies a failed plugin call.
+func pluginError(err error) *Error {
+	if errors.Is(err, plugins.ErrUnknownCapability) {
+		return &Error{Code: CodeInvalidArgument, Message: "unknown plugin capability", Err: err}
+	}
+	return &Error{
+		Code:      CodePluginFailed,
+		Message:   "plugin failed",
+		Err:       err,
+		Retryable: errors.Is(err, plugins.ErrTimeout) || errors.Is(err, plugins.ErrCrashed),
+	}
+}
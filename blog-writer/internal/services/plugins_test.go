@@ -0,0 +1,47 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"blog-writer/internal/plugins"
+)
+
+// TestPluginTrust ensures repository plugins only run once the repository
+// is trusted and broken plugins are listed with their error.
+func TestPluginTrust(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("needs a shell script plugin")
+	}
+	repo := t.TempDir()
+	dir := filepath.Join(repo, ".blog-writer", "plugins")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken"), []byte("#!/bin/sh\nexit 1\n"), 0o755); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	svc := NewPluginServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	defer svc.Close()
+	infos, err := svc.List(repo)
+	if err != nil || len(infos) != 0 {
+		t.Fatalf("expected no plugins before trusting, got %+v, %v", infos, err)
+	}
+	if err := svc.SetTrusted(repo, true); err != nil {
+		t.Fatalf("SetTrusted: %v", err)
+	}
+	if ok, err := svc.Trusted(repo); err != nil || !ok {
+		t.Fatalf("Trusted: %v, %v", ok, err)
+	}
+	infos, err = svc.List(repo)
+	if err != nil || len(infos) != 1 || infos[0].Source != plugins.SourceRepo || infos[0].Error == "" {
+		t.Fatalf("expected the broken repo plugin, got %+v, %v", infos, err)
+	}
+	if _, err := svc.Run(repo, "broken/x", "", nil); !errors.Is(err, &Error{Code: CodeInvalidArgument}) {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
@@ -2,20 +2,55 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"blog-writer/internal/config"
+	"blog-writer/internal/events"
+	"blog-writer/internal/fsutil"
+	"blog-writer/internal/schema"
+	"blog-writer/internal/templates"
 )
 
+// Progress steps reported while creating a repository.
+const (
+	StepDetect   = "detect"
+	StepClone    = "clone"
+	StepFetch    = "fetch"
+	StepCheckout = "checkout"
+	StepInit     = "init"
+	StepScaffold = "scaffold"
+	StepTemplate = "template"
+	StepCommit   = "commit"
+	StepPush     = "push"
+	StepDone     = "done"
+)
+
+// RepoProgress describes a single step of a repository operation.
+type RepoProgress struct {
+	Step    string `json:"step"`
+	Message string `json:"message"`
+}
+
 // RepoService manages blog repositories and recent list.
 type RepoService struct {
 	mu      sync.Mutex
 	cfgPath string
+	current string
+	locked  bool
+
+	// Events, if set, receives repo.opened after Open succeeds, repo.locked
+	// when the repository is already open in another process, and
+	// repo.progress and git.progress while creating repositories.
+	Events *events.Bus
 }
 
 // NewRepoService creates a RepoService using the user's home directory for config.
@@ -33,133 +68,503 @@ func NewRepoServiceWithPath(path string) *RepoService {
 	return &RepoService{cfgPath: path}
 }
 
-// Recent returns up to five most recently opened repositories.
+// Recent returns the paths of recently opened repositories, pinned ones
+// first, then most recently opened. Repositories that no longer exist or
+// lost their .git directory are pruned.
 func (r *RepoService) Recent() ([]string, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	cfg, err := config.Load(r.cfgPath)
+	repos, err := r.RecentRepos()
 	if err != nil {
 		return nil, err
 	}
-	if len(cfg.RecentlyOpened) > 5 {
-		cfg.RecentlyOpened = cfg.RecentlyOpened[:5]
+	paths := make([]string, len(repos))
+	for i, e := range repos {
+		paths[i] = e.Path
 	}
-	return cfg.RecentlyOpened, nil
+	return paths, nil
 }
 
-// addRecent records a repo path, moving it to the front and limiting to five entries.
-func (r *RepoService) addRecent(path string) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-	cfg, _ := config.Load(r.cfgPath)
-	filtered := []string{path}
-	for _, p := range cfg.RecentlyOpened {
-		if p != path {
-			filtered = append(filtered, p)
-		}
+// RecentRepos returns the recently opened repositories with their display
+// name, last-opened time, pin state and remote, pruning stale entries.
+func (r *RepoService) RecentRepos() ([]config.RecentRepo, error) {
+	var recent []config.RecentRepo
+	err := config.Update(r.cfgPath, func(cfg *config.Config) error {
+		cfg.Prune(isGitRepo)
+		recent = cfg.RecentlyOpened
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	if len(filtered) > 5 {
-		filtered = filtered[:5]
+	return recent, nil
+}
+
+// Pin pins or unpins a recent repository. Pinned repositories are listed
+// first and never evicted by the recent limit.
+func (r *RepoService) Pin(path string, pinned bool) error {
+	return r.updateConfig(func(cfg *config.Config) error {
+		if !cfg.SetPinned(path, pinned) {
+			return newError(CodeNotFound, "%s is not a recent repository", path)
+		}
+		return nil
+	})
+}
+
+// Forget removes a repository from the recent list.
+func (r *RepoService) Forget(path string) error {
+	return r.updateConfig(func(cfg *config.Config) error {
+		cfg.Forget(path)
+		return nil
+	})
+}
+
+// SetRecentLimit sets how many unpinned recent repositories are kept.
+func (r *RepoService) SetRecentLimit(limit int) error {
+	if limit < 1 {
+		return newError(CodeInvalidArgument, "recent limit must be at least 1")
 	}
-	cfg.RecentlyOpened = filtered
-	return config.Save(r.cfgPath, cfg)
+	return r.updateConfig(func(cfg *config.Config) error {
+		cfg.SetRecentLimit(limit)
+		return nil
+	})
 }
 
-// Open opens an existing git repository and ensures required directories.
+// addRecent records a repo path, moving it to the front of the recent list.
+func (r *RepoService) addRecent(path string) error {
+	remote := gitConfig(context.Background(), path, "remote.origin.url")
+	return r.updateConfig(func(cfg *config.Config) error {
+		cfg.Touch(path, remote, time.Now())
+		cfg.UI.LastRepo = path
+		return nil
+	})
+}
+
+// updateConfig applies fn to the user config through config.Update.
+func (r *RepoService) updateConfig(fn func(cfg *config.Config) error) error {
+	return config.Update(r.cfgPath, fn)
+}
+
+// isGitRepo reports whether path exists and contains a .git entry.
+func isGitRepo(path string) bool {
+	_, err := os.Stat(filepath.Join(path, ".git"))
+	return err == nil
+}
+
+// Open opens an existing git repository, ensures required directories,
+// upgrades settings.json to the current schema version and locks the
+// repository, releasing the lock on the previously opened one. A lock held
+// by another process is reported to LockWarning but does not fail Open.
 func (r *RepoService) Open(path string) error {
-	if _, err := os.Stat(filepath.Join(path, ".git")); err != nil {
+	if !isGitRepo(path) {
 		return ErrNotGitRepo
 	}
-	// ensure directories
-	if err := os.MkdirAll(filepath.Join(path, "blog"), 0o755); err != nil {
+	if err := ensureLayout(path); err != nil {
 		return err
 	}
-	if err := os.MkdirAll(filepath.Join(path, ".blog-writer"), 0o755); err != nil {
+	if _, err := MigrateSettingsFile(path); err != nil {
 		return err
 	}
-	// ensure settings file
-	settings := filepath.Join(path, ".blog-writer", "settings.json")
-	if _, err := os.Stat(settings); errors.Is(err, os.ErrNotExist) {
-		if err := os.WriteFile(settings, defaultSettings(), 0o644); err != nil {
+	if err := r.lock(path); err != nil {
+		return err
+	}
+	if err := r.addRecent(path); err != nil {
+		return err
+	}
+	r.Events.Publish(events.RepoOpened, path, nil)
+	return nil
+}
+
+// Current returns the path of the repository last opened with Open, or an
+// empty string when none is open.
+func (r *RepoService) Current() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current
+}
+
+// Close forgets the currently open repository and releases its lock.
+func (r *RepoService) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current != "" && r.locked {
+		repoLocks.release(r.current)
+	}
+	r.current, r.locked = "", false
+}
+
+// lock makes path the current repository and locks it unless another
+// process holds the lock.
+func (r *RepoService) lock(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.current == path {
+		return nil
+	}
+	warning, err := repoLocks.acquire(path)
+	if err != nil {
+		return err
+	}
+	if r.current != "" && r.locked {
+		repoLocks.release(r.current)
+	}
+	r.current, r.locked = path, warning == nil
+	if warning != nil {
+		r.Events.Publish(events.RepoLocked, path, *warning)
+	}
+	return nil
+}
+
+// CreateOptions configures CreateFromRemote.
+type CreateOptions struct {
+	Remote   string `json:"remote"`
+	Path     string `json:"path"`
+	Branch   string `json:"branch"`
+	Push     bool   `json:"push"`
+	Template string `json:"template"`
+}
+
+// Create creates a new git repository at path with optional remote. It is
+// equivalent to CreateFromRemote without an explicit branch, push or template.
+func (r *RepoService) Create(remote, path string) error {
+	return r.CreateFromRemote(CreateOptions{Remote: remote, Path: path})
+}
+
+// Templates lists the repository templates available to CreateFromRemote:
+// user templates from the config file followed by the built-in ones.
+func (r *RepoService) Templates() ([]templates.Info, error) {
+	cfg, err := config.Load(r.cfgPath)
+	if err != nil {
+		return nil, err
+	}
+	var out []templates.Info
+	for _, ref := range cfg.Templates {
+		desc := ref.URL
+		if ref.Path != "" {
+			desc = ref.Path
+			if t, err := templates.LoadDir(ref.Path); err == nil {
+				desc = t.Description
+			}
+		}
+		out = append(out, templates.Info{Name: ref.Name, Description: desc})
+	}
+	builtin, err := templates.Builtin()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range builtin {
+		out = append(out, t.Info(true))
+	}
+	return out, nil
+}
+
+// CreateFromRemote creates a local blog repository at path. When remote
+// already has commits it is cloned (or fetched into an existing repository)
+// and the default branch is checked out. Otherwise a new repository is
+// initialized on the branch, scaffolded from the optional template,
+// committed and, if Push is set, pushed with upstream tracking. An empty
+// branch resolves to the user default, git's init.defaultBranch, then
+// "main". Each step is reported to Progress. The operation can be aborted
+// with GitService.Cancel(path); a cancelled clone removes the partially
+// cloned directory.
+func (r *RepoService) CreateFromRemote(opts CreateOptions) error {
+	remote, path, branch := opts.Remote, opts.Path, opts.Branch
+	ctx, done := gitOps.start(path)
+	defer done()
+	if branch == "" {
+		var err error
+		if branch, err = r.defaultBranch(ctx); err != nil {
+			return err
+		}
+	}
+	var heads []string
+	if remote != "" {
+		r.report(path, StepDetect, "Checking "+remote+" for existing content")
+		var err error
+		if heads, err = remoteHeads(ctx, remote); err != nil {
 			return err
 		}
 	}
+	var err error
+	if len(heads) > 0 {
+		err = r.cloneExisting(ctx, remote, path, pickBranch(ctx, remote, branch, heads))
+	} else {
+		err = r.initEmpty(ctx, remote, path, branch, opts.Push, opts.Template)
+	}
+	if err != nil {
+		return err
+	}
+	r.report(path, StepDone, "Repository ready at "+path)
 	return r.addRecent(path)
 }
 
-// Create creates a new git repository at path with optional remote.
-func (r *RepoService) Create(remote, path string) error {
-	if err := os.MkdirAll(path, 0o755); err != nil {
+// cloneExisting brings the content of remote into path and checks out the
+// remote branch as a local tracking branch. An existing repository at path
+// must have no origin or one pointing at remote.
+func (r *RepoService) cloneExisting(ctx context.Context, remote, path, branch string) error {
+	if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+		r.report(path, StepFetch, "Fetching "+remote)
+		if err := ensureOrigin(ctx, path, remote); err != nil {
+			return err
+		}
+		if _, err := runGitProgress(ctx, path, r.gitProgress(path, "fetch"), "fetch", "--progress", "origin"); err != nil {
+			return err
+		}
+	} else {
+		entries, statErr := os.ReadDir(path)
+		if statErr == nil && len(entries) > 0 {
+			return newError(CodeInvalidArgument, "destination %s is not empty", path)
+		}
+		r.report(path, StepClone, "Cloning "+remote)
+		if _, err := runGitProgress(ctx, "", r.gitProgress(path, "clone"), "clone", "--progress", remote, path); err != nil {
+			if errCancelled(err) {
+				if statErr != nil {
+					_ = os.RemoveAll(path)
+				} else {
+					removeContents(path)
+				}
+			}
+			return err
+		}
+	}
+	if _, err := runGit(ctx, path, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch); err == nil {
+		if _, err := runGit(ctx, path, "merge-base", "--is-ancestor", branch, "origin/"+branch); err != nil {
+			return newError(CodeMergeConflict, "local branch %s has diverged from origin/%s", branch, branch)
+		}
+	}
+	r.report(path, StepCheckout, "Checking out "+branch)
+	if _, err := runGit(ctx, path, "checkout", "-B", branch, "--track", "origin/"+branch); err != nil {
+		return err
+	}
+	return ensureLayout(path)
+}
+
+// ensureOrigin adds remote as origin of the repository at path, or checks
+// that an existing origin already points at it.
+func ensureOrigin(ctx context.Context, path, remote string) error {
+	origin, err := runGit(ctx, path, "remote", "get-url", "origin")
+	if err != nil {
+		_, err = runGit(ctx, path, "remote", "add", "origin", remote)
+		return err
+	}
+	if origin != remote {
+		e := newError(CodeInvalidArgument, "%s already has origin %s, not %s", path, origin, remote)
+		e.Details = map[string]any{"origin": origin, "remote": remote}
+		return e
+	}
+	return nil
+}
+
+// remoteHeads lists the branch names published by remote.
+func remoteHeads(ctx context.Context, remote string) ([]string, error) {
+	out, err := runGit(ctx, "", "ls-remote", "--heads", remote)
+	if err != nil {
+		return nil, err
+	}
+	var heads []string
+	for _, line := range strings.Split(out, "\n") {
+		if _, ref, ok := strings.Cut(line, "\t"); ok {
+			heads = append(heads, strings.TrimPrefix(ref, "refs/heads/"))
+		}
+	}
+	return heads, nil
+}
+
+// pickBranch chooses the branch to check out from a remote with content:
+// the preferred branch if published, otherwise the remote HEAD, otherwise
+// the first published branch.
+func pickBranch(ctx context.Context, remote, preferred string, heads []string) string {
+	if slices.Contains(heads, preferred) {
+		return preferred
+	}
+	if out, err := runGit(ctx, "", "ls-remote", "--symref", remote, "HEAD"); err == nil {
+		for _, line := range strings.Split(out, "\n") {
+			if ref, ok := strings.CutPrefix(line, "ref: refs/heads/"); ok {
+				if head, _, _ := strings.Cut(ref, "\t"); slices.Contains(heads, head) {
+					return head
+				}
+			}
+		}
+	}
+	return heads[0]
+}
+
+// initEmpty initializes path on branch, scaffolds the blog layout from the
+// named template and makes the initial commit, optionally pushing it to remote.
+// An existing repository at path is reused only while it has no commits and
+// no origin other than remote.
+func (r *RepoService) initEmpty(ctx context.Context, remote, path, branch string, push bool, template string) error {
+	var tpl *templates.Template
+	if template != "" {
+		t, cleanup, err := r.resolveTemplate(ctx, template)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		tpl = &t
+	}
+	settings := DefaultSettings()
+	if tpl != nil && len(tpl.Settings) > 0 {
+		if err := json.Unmarshal(tpl.Settings, &settings); err != nil {
+			return fmt.Errorf("template %s settings: %w", tpl.Name, err)
+		}
+		if settings.DefaultKeywords == nil {
+			settings.DefaultKeywords = []string{}
+		}
+	}
+	settings.DefaultBranch = branch
+	settings.Remote = remote
+	b, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
 		return err
 	}
-	cmd := exec.Command("git", "init", path)
-	if err := cmd.Run(); err != nil {
+	if err := schema.ValidateSettings(b); err != nil {
+		return validationError("invalid template settings", err)
+	}
+	existing := false
+	if _, err := os.Stat(filepath.Join(path, ".git")); err == nil {
+		if _, err := runGit(ctx, path, "rev-parse", "--verify", "--quiet", "HEAD"); err == nil {
+			return newError(CodeInvalidArgument, "%s is already a git repository with history", path)
+		}
+		existing = true
+	}
+	if err := os.MkdirAll(path, 0o755); err != nil {
 		return err
 	}
+	r.report(path, StepInit, "Initializing repository on "+branch)
+	if !existing {
+		if _, err := runGit(ctx, "", "init", path); err != nil {
+			return err
+		}
+	}
 	if remote != "" {
-		cmd = exec.Command("git", "remote", "add", "origin", remote)
-		cmd.Dir = path
-		if err := cmd.Run(); err != nil {
+		if err := ensureOrigin(ctx, path, remote); err != nil {
 			return err
 		}
 	}
+	if _, err := runGit(ctx, path, "symbolic-ref", "HEAD", "refs/heads/"+branch); err != nil {
+		return err
+	}
+	r.report(path, StepScaffold, "Creating blog/ and .blog-writer/")
 	if err := os.MkdirAll(filepath.Join(path, "blog"), 0o755); err != nil {
 		return err
 	}
 	if err := os.MkdirAll(filepath.Join(path, ".blog-writer"), 0o755); err != nil {
 		return err
 	}
-	if err := os.WriteFile(filepath.Join(path, ".blog-writer", "settings.json"), defaultSettings(), 0o644); err != nil {
+	if tpl != nil {
+		r.report(path, StepTemplate, "Applying template "+tpl.Name)
+		cfg, err := config.Load(r.cfgPath)
+		if err != nil {
+			return err
+		}
+		author := layerSettings(ctx, path, settings, nil, cfg).Settings.DefaultAuthor
+		if err := tpl.Apply(path, time.Now(), author, branch); err != nil {
+			return err
+		}
+	}
+	if err := fsutil.WriteFileAtomic(filepath.Join(path, ".blog-writer", "settings.json"), b, 0o644); err != nil {
+		return err
+	}
+	r.report(path, StepCommit, "Creating initial commit")
+	if _, err := runGit(ctx, path, "add", "."); err != nil {
 		return err
 	}
-	// initial commit
-	cmd = exec.Command("git", "add", ".")
-	cmd.Dir = path
-	if err := cmd.Run(); err != nil {
+	if _, err := runGit(ctx, path, "commit", "-m", "chore: initial commit"); err != nil {
+		return err
+	}
+	if push && remote != "" {
+		r.report(path, StepPush, "Pushing "+branch+" to origin")
+		if _, err := runGitProgress(ctx, path, r.gitProgress(path, "push"), "push", "--progress", "-u", "origin", branch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveTemplate finds a template by name, preferring user templates from
+// the config file over built-in ones. Templates referenced by git URL are
+// shallow-cloned into a temporary directory removed by the returned cleanup.
+func (r *RepoService) resolveTemplate(ctx context.Context, name string) (templates.Template, func(), error) {
+	noop := func() {}
+	cfg, err := config.Load(r.cfgPath)
+	if err != nil {
+		return templates.Template{}, noop, err
+	}
+	for _, ref := range cfg.Templates {
+		if ref.Name != name {
+			continue
+		}
+		if ref.Path != "" {
+			t, err := templates.LoadDir(ref.Path)
+			return t, noop, err
+		}
+		tmp, err := os.MkdirTemp("", "blog-writer-template-")
+		if err != nil {
+			return templates.Template{}, noop, err
+		}
+		cleanup := func() { _ = os.RemoveAll(tmp) }
+		if _, err := runGit(ctx, "", "clone", "--depth", "1", ref.URL, tmp); err != nil {
+			cleanup()
+			return templates.Template{}, noop, err
+		}
+		t, err := templates.LoadDir(tmp)
+		if err != nil {
+			cleanup()
+			return templates.Template{}, noop, err
+		}
+		return t, cleanup, nil
+	}
+	if t, ok := templates.FindBuiltin(name); ok {
+		return t, noop, nil
+	}
+	return templates.Template{}, noop, newError(CodeNotFound, "unknown template %q", name)
+}
+
+// defaultBranch resolves the branch for new repositories from the user
+// config, git's init.defaultBranch and finally the built-in default.
+func (r *RepoService) defaultBranch(ctx context.Context) (string, error) {
+	cfg, err := config.Load(r.cfgPath)
+	if err != nil {
+		return "", err
+	}
+	return layerSettings(ctx, "", DefaultSettings(), nil, cfg).Settings.DefaultBranch, nil
+}
+
+// gitProgress returns a callback publishing git transfer progress tagged
+// with path and op, or nil when there is no bus.
+func (r *RepoService) gitProgress(path, op string) func(GitProgress) {
+	return publishProgress(r.Events, path, op)
+}
+
+// removeContents deletes everything inside dir but keeps dir itself.
+func removeContents(dir string) {
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		_ = os.RemoveAll(filepath.Join(dir, e.Name()))
+	}
+}
+
+// report publishes a progress step of the repository at path.
+func (r *RepoService) report(path, step, message string) {
+	r.Events.Publish(events.RepoProgress, path, RepoProgress{Step: step, Message: message})
+}
+
+// ensureLayout creates blog/ and .blog-writer/settings.json when missing.
+func ensureLayout(path string) error {
+	if err := os.MkdirAll(filepath.Join(path, "blog"), 0o755); err != nil {
 		return err
 	}
-	cmd = exec.Command("git", "commit", "-m", "chore: initial commit")
-	cmd.Dir = path
-	if err := cmd.Run(); err != nil {
+	if err := os.MkdirAll(filepath.Join(path, ".blog-writer"), 0o755); err != nil {
 		return err
 	}
-	return r.addRecent(path)
+	settings := filepath.Join(path, ".blog-writer", "settings.json")
+	if _, err := os.Stat(settings); errors.Is(err, os.ErrNotExist) {
+		return fsutil.WriteFileAtomic(settings, defaultSettings(), 0o644)
+	}
+	return nil
 }
 
 // defaultSettings returns the default repository settings JSON.
 func defaultSettings() []byte {
-	// minimal settings matching specification
-	data := struct {
-		SchemaVersion       int      `json:"schemaVersion"`
-		DefaultAuthor       string   `json:"defaultAuthor"`
-		DefaultKeywords     []string `json:"defaultKeywords"`
-		DefaultBranch       string   `json:"defaultBranch"`
-		Remote              string   `json:"remote"`
-		PreCommitValidate   bool     `json:"preCommitValidate"`
-		MaxEmbeddedSvgBytes int      `json:"maxEmbeddedSvgBytes"`
-		MaxSvgNodeCount     int      `json:"maxSvgNodeCount"`
-		ImageVectorization  struct {
-			Mode      string  `json:"mode"`
-			Threshold float64 `json:"threshold"`
-			Colors    int     `json:"colors"`
-		} `json:"imageVectorization"`
-		Autosave struct {
-			Enabled    bool `json:"enabled"`
-			IntervalMs int  `json:"intervalMs"`
-		} `json:"autosave"`
-	}{
-		SchemaVersion:       1,
-		DefaultBranch:       "main",
-		PreCommitValidate:   true,
-		MaxEmbeddedSvgBytes: 10485760,
-		MaxSvgNodeCount:     100000,
-	}
-	data.ImageVectorization.Mode = "auto"
-	data.ImageVectorization.Threshold = 0.6
-	data.ImageVectorization.Colors = 8
-	data.Autosave.Enabled = true
-	data.Autosave.IntervalMs = 15000
-	b, _ := json.MarshalIndent(data, "", "  ")
+	b, _ := json.MarshalIndent(DefaultSettings(), "", "  ")
 	return b
-
 }
@@ -2,10 +2,17 @@
 package services
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"blog-writer/internal/config"
+	"blog-writer/internal/events"
+	"blog-writer/pkg/article"
 )
 
 // TestRecent ensures recent repos list maintains order, limit, and timestamps.
@@ -44,3 +51,349 @@ func TestRecent(t *testing.T) {
 		t.Fatalf("expected %s at front after reopen, got %s", target, rec[0])
 	}
 }
+
+// TestOpenCorruptConfig ensures an unreadable config file is reported
+// instead of being replaced by an empty one.
+func TestOpenCorruptConfig(t *testing.T) {
+	cfgFile := filepath.Join(t.TempDir(), "config.yml")
+	corrupt := []byte("recent: [\n")
+	if err := os.WriteFile(cfgFile, corrupt, 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := NewRepoServiceWithPath(cfgFile).Open(repo); err == nil {
+		t.Fatal("expected error for corrupt config")
+	}
+	if b, _ := os.ReadFile(cfgFile); string(b) != string(corrupt) {
+		t.Fatalf("config overwritten: %q", b)
+	}
+}
+
+// newBareRemote creates an empty bare repository to act as a remote.
+func newBareRemote(t *testing.T) string {
+	t.Helper()
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	if _, err := runGit(context.Background(), "", "init", "--bare", remote); err != nil {
+		t.Fatalf("init bare: %v", err)
+	}
+	return remote
+}
+
+// TestCreateFromRemoteEmpty ensures an empty remote is initialized on the
+// requested branch, committed and pushed.
+func TestCreateFromRemoteEmpty(t *testing.T) {
+	requireGit(t)
+	remote := newBareRemote(t)
+	svc := NewRepoServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	var steps []string
+	svc.Events = events.New()
+	svc.Events.Subscribe(string(events.RepoProgress), func(e events.Event) {
+		steps = append(steps, e.Data.(RepoProgress).Step)
+	})
+	local := filepath.Join(t.TempDir(), "blog")
+	if err := svc.CreateFromRemote(CreateOptions{Remote: remote, Path: local, Branch: "trunk", Push: true}); err != nil {
+		t.Fatalf("CreateFromRemote: %v", err)
+	}
+	want := []string{StepDetect, StepInit, StepScaffold, StepCommit, StepPush, StepDone}
+	if strings.Join(steps, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected steps %v, got %v", want, steps)
+	}
+	if head, _ := runGit(context.Background(), local, "symbolic-ref", "--short", "HEAD"); head != "trunk" {
+		t.Fatalf("expected branch trunk, got %q", head)
+	}
+	if _, err := runGit(context.Background(), remote, "rev-parse", "--verify", "refs/heads/trunk"); err != nil {
+		t.Fatalf("expected trunk pushed: %v", err)
+	}
+	if up, _ := runGit(context.Background(), local, "rev-parse", "--abbrev-ref", "trunk@{upstream}"); up != "origin/trunk" {
+		t.Fatalf("expected upstream origin/trunk, got %q", up)
+	}
+	s, err := loadSettings(local)
+	if err != nil {
+		t.Fatalf("loadSettings: %v", err)
+	}
+	if s.DefaultBranch != "trunk" || s.Remote != remote {
+		t.Fatalf("unexpected settings: %+v", s)
+	}
+}
+
+// TestCreateFromRemoteExisting ensures a remote with content is cloned so the
+// local copy shares its history.
+func TestCreateFromRemoteExisting(t *testing.T) {
+	requireGit(t)
+	remote := newBareRemote(t)
+	svc := NewRepoServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	first := filepath.Join(t.TempDir(), "first")
+	if err := svc.CreateFromRemote(CreateOptions{Remote: remote, Path: first, Branch: "trunk", Push: true}); err != nil {
+		t.Fatalf("seed remote: %v", err)
+	}
+	var steps []string
+	svc.Events = events.New()
+	svc.Events.Subscribe(string(events.RepoProgress), func(e events.Event) {
+		steps = append(steps, e.Data.(RepoProgress).Step)
+	})
+	second := filepath.Join(t.TempDir(), "second")
+	if err := svc.CreateFromRemote(CreateOptions{Remote: remote, Path: second}); err != nil {
+		t.Fatalf("CreateFromRemote: %v", err)
+	}
+	if steps[1] != StepClone || steps[2] != StepCheckout {
+		t.Fatalf("expected clone and checkout steps, got %v", steps)
+	}
+	a, _ := runGit(context.Background(), first, "rev-parse", "HEAD")
+	b, _ := runGit(context.Background(), second, "rev-parse", "HEAD")
+	if a == "" || a != b {
+		t.Fatalf("expected shared history, got %q and %q", a, b)
+	}
+	if head, _ := runGit(context.Background(), second, "symbolic-ref", "--short", "HEAD"); head != "trunk" {
+		t.Fatalf("expected remote default branch trunk, got %q", head)
+	}
+
+	// An existing empty repository is fetched into instead of cloned.
+	third := filepath.Join(t.TempDir(), "third")
+	if _, err := runGit(context.Background(), "", "init", third); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	steps = nil
+	if err := svc.CreateFromRemote(CreateOptions{Remote: remote, Path: third, Branch: "trunk"}); err != nil {
+		t.Fatalf("CreateFromRemote fetch: %v", err)
+	}
+	if steps[1] != StepFetch {
+		t.Fatalf("expected fetch step, got %v", steps)
+	}
+	if c, _ := runGit(context.Background(), third, "rev-parse", "HEAD"); c != a {
+		t.Fatalf("expected fetched history, got %q", c)
+	}
+}
+
+// TestCreateFromRemoteOriginMismatch ensures an existing repository whose
+// origin points elsewhere is refused rather than fetched from.
+func TestCreateFromRemoteOriginMismatch(t *testing.T) {
+	requireGit(t)
+	remote := newBareRemote(t)
+	svc := NewRepoServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	if err := svc.CreateFromRemote(CreateOptions{Remote: remote, Path: filepath.Join(t.TempDir(), "seed"), Branch: "main", Push: true}); err != nil {
+		t.Fatalf("seed remote: %v", err)
+	}
+	local := filepath.Join(t.TempDir(), "local")
+	other := newBareRemote(t)
+	if _, err := runGit(context.Background(), "", "init", local); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if _, err := runGit(context.Background(), local, "remote", "add", "origin", other); err != nil {
+		t.Fatalf("remote add: %v", err)
+	}
+	err := svc.CreateFromRemote(CreateOptions{Remote: remote, Path: local})
+	if e := AsError(err); e == nil || e.Code != CodeInvalidArgument {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+	if _, err := runGit(context.Background(), local, "rev-parse", "--verify", "--quiet", "refs/remotes/origin/main"); err == nil {
+		t.Fatal("expected nothing fetched")
+	}
+}
+
+// TestCreateInExistingRepository ensures a repository with history is
+// refused while an empty one is reused with its matching origin.
+func TestCreateInExistingRepository(t *testing.T) {
+	requireGit(t)
+	ctx := context.Background()
+	svc := NewRepoServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	used := filepath.Join(t.TempDir(), "used")
+	if err := svc.Create("", used); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	head, _ := runGit(ctx, used, "rev-parse", "HEAD")
+	err := svc.CreateFromRemote(CreateOptions{Remote: newBareRemote(t), Path: used})
+	if e := AsError(err); e == nil || e.Code != CodeInvalidArgument {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+	if got, _ := runGit(ctx, used, "rev-parse", "HEAD"); got != head {
+		t.Fatalf("history changed: %s != %s", got, head)
+	}
+	if _, err := runGit(ctx, used, "remote", "get-url", "origin"); err == nil {
+		t.Fatal("expected no origin to be added")
+	}
+
+	remote := newBareRemote(t)
+	empty := filepath.Join(t.TempDir(), "empty")
+	if _, err := runGit(ctx, "", "init", empty); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if _, err := runGit(ctx, empty, "remote", "add", "origin", remote); err != nil {
+		t.Fatalf("remote add: %v", err)
+	}
+	if err := svc.CreateFromRemote(CreateOptions{Remote: remote, Path: empty, Branch: "trunk", Push: true}); err != nil {
+		t.Fatalf("CreateFromRemote: %v", err)
+	}
+	if _, err := runGit(ctx, remote, "rev-parse", "--verify", "refs/heads/trunk"); err != nil {
+		t.Fatalf("expected trunk pushed: %v", err)
+	}
+}
+
+// TestCreateFromRemoteNonEmptyDestination ensures clones never overwrite files.
+func TestCreateFromRemoteNonEmptyDestination(t *testing.T) {
+	requireGit(t)
+	remote := newBareRemote(t)
+	svc := NewRepoServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	if err := svc.CreateFromRemote(CreateOptions{Remote: remote, Path: filepath.Join(t.TempDir(), "seed"), Branch: "main", Push: true}); err != nil {
+		t.Fatalf("seed remote: %v", err)
+	}
+	dest := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dest, "notes.txt"), []byte("x"), 0o644)
+	if err := svc.CreateFromRemote(CreateOptions{Remote: remote, Path: dest}); err == nil {
+		t.Fatal("expected error for non-empty destination")
+	}
+}
+
+// TestCreateWithTemplate ensures built-in and user directory templates are applied.
+func TestCreateWithTemplate(t *testing.T) {
+	requireGit(t)
+	cfgPath := filepath.Join(t.TempDir(), "config.yml")
+	svc := NewRepoServiceWithPath(cfgPath)
+	local := filepath.Join(t.TempDir(), "eng")
+	if err := svc.CreateFromRemote(CreateOptions{Path: local, Branch: "main", Template: "engineering"}); err != nil {
+		t.Fatalf("CreateFromRemote: %v", err)
+	}
+	s, err := loadSettings(local)
+	if err != nil {
+		t.Fatalf("loadSettings: %v", err)
+	}
+	if len(s.DefaultKeywords) != 1 || s.DefaultKeywords[0] != "engineering" {
+		t.Fatalf("expected template keywords, got %v", s.DefaultKeywords)
+	}
+	if out, _ := runGit(context.Background(), local, "status", "--porcelain"); out != "" {
+		t.Fatalf("expected template files committed, got %q", out)
+	}
+	if _, err := os.Stat(filepath.Join(local, "blog", "incidents", ".gitkeep")); err != nil {
+		t.Fatalf("expected subject folder: %v", err)
+	}
+
+	tplDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(tplDir, "template.json"), []byte(`{"name":"mine","description":"Mine","settings":{"defaultAuthor":"Team"}}`), 0o644)
+	_ = os.MkdirAll(filepath.Join(tplDir, "files"), 0o755)
+	_ = os.WriteFile(filepath.Join(tplDir, "files", "README.md"), []byte("# Blog\n"), 0o644)
+	if err := config.Save(cfgPath, config.Config{Templates: []config.Template{{Name: "mine", Path: tplDir}}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	list, err := svc.Templates()
+	if err != nil || len(list) != 3 || list[0].Name != "mine" || list[0].Description != "Mine" {
+		t.Fatalf("unexpected templates %+v %v", list, err)
+	}
+	mine := filepath.Join(t.TempDir(), "mine")
+	if err := svc.CreateFromRemote(CreateOptions{Path: mine, Template: "mine"}); err != nil {
+		t.Fatalf("CreateFromRemote: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(mine, "README.md")); err != nil {
+		t.Fatalf("expected template file: %v", err)
+	}
+	if s, _ := loadSettings(mine); s.DefaultAuthor != "Team" {
+		t.Fatalf("expected template author, got %q", s.DefaultAuthor)
+	}
+	if err := svc.CreateFromRemote(CreateOptions{Path: filepath.Join(t.TempDir(), "x"), Template: "missing"}); err == nil {
+		t.Fatal("expected unknown template error")
+	}
+}
+
+// TestCreateWithTemplateUserAuthor ensures starter articles take the author
+// from the user defaults when the template sets none.
+func TestCreateWithTemplateUserAuthor(t *testing.T) {
+	requireGit(t)
+	cfgPath := filepath.Join(t.TempDir(), "config.yml")
+	if err := config.Save(cfgPath, config.Config{Defaults: config.UserDefaults{DefaultAuthor: "Grace"}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	local := filepath.Join(t.TempDir(), "eng")
+	if err := NewRepoServiceWithPath(cfgPath).CreateFromRemote(CreateOptions{Path: local, Branch: "main", Template: "engineering"}); err != nil {
+		t.Fatalf("CreateFromRemote: %v", err)
+	}
+	paths, err := article.Scan(local)
+	if err != nil || len(paths) != 1 {
+		t.Fatalf("expected one starter article, got %v %v", paths, err)
+	}
+	b, _ := os.ReadFile(paths[0])
+	if a, err := article.Parse(b); err != nil || a.Metadata.Author != "Grace" {
+		t.Fatalf("expected author Grace, got %+v %v", a.Metadata, err)
+	}
+}
+
+// TestCreateWithInvalidTemplateSettings ensures template settings that
+// break the settings schema are rejected before anything is written.
+func TestCreateWithInvalidTemplateSettings(t *testing.T) {
+	requireGit(t)
+	cfgPath := filepath.Join(t.TempDir(), "config.yml")
+	tplDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(tplDir, "template.json"), []byte(`{"name":"bad","settings":{"autosave":{"enabled":true,"intervalMs":10}}}`), 0o644)
+	if err := config.Save(cfgPath, config.Config{Templates: []config.Template{{Name: "bad", Path: tplDir}}}); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	local := filepath.Join(t.TempDir(), "bad")
+	err := NewRepoServiceWithPath(cfgPath).CreateFromRemote(CreateOptions{Path: local, Branch: "main", Template: "bad"})
+	if e := AsError(err); e == nil || e.Code != CodeValidationFailed {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if _, err := os.Stat(local); !os.IsNotExist(err) {
+		t.Fatalf("expected nothing created: %v", err)
+	}
+}
+
+// TestRecentReposPruneAndPin ensures stale repos are pruned, timestamps are
+// recorded and pinned repos survive the limit.
+func TestRecentReposPruneAndPin(t *testing.T) {
+	cfgFile := filepath.Join(t.TempDir(), "config.yml")
+	svc := NewRepoServiceWithPath(cfgFile)
+	if err := svc.SetRecentLimit(2); err != nil {
+		t.Fatalf("SetRecentLimit: %v", err)
+	}
+	root := t.TempDir()
+	var paths []string
+	for i := 0; i < 3; i++ {
+		p := filepath.Join(root, "repo"+strconv.Itoa(i))
+		if err := os.MkdirAll(filepath.Join(p, ".git"), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		paths = append(paths, p)
+	}
+	before := time.Now()
+	if err := svc.Open(paths[0]); err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if err := svc.Pin(paths[0], true); err != nil {
+		t.Fatalf("pin: %v", err)
+	}
+	for _, p := range paths[1:] {
+		if err := svc.Open(p); err != nil {
+			t.Fatalf("open: %v", err)
+		}
+	}
+	repos, err := svc.RecentRepos()
+	if err != nil {
+		t.Fatalf("RecentRepos: %v", err)
+	}
+	if len(repos) != 3 || repos[0].Path != paths[0] || !repos[0].Pinned {
+		t.Fatalf("expected pinned repo first, got %+v", repos)
+	}
+	if repos[1].Path != paths[2] || repos[1].Name != "repo2" || repos[1].LastOpened.Before(before) {
+		t.Fatalf("unexpected entry %+v", repos[1])
+	}
+
+	if err := os.RemoveAll(filepath.Join(paths[2], ".git")); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if err := os.RemoveAll(paths[1]); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	rec, err := svc.Recent()
+	if err != nil {
+		t.Fatalf("Recent: %v", err)
+	}
+	if len(rec) != 1 || rec[0] != paths[0] {
+		t.Fatalf("expected only pinned repo after pruning, got %v", rec)
+	}
+	if err := svc.Pin(paths[1], true); err == nil {
+		t.Fatal("expected error pinning unknown repo")
+	}
+	if err := svc.SetRecentLimit(0); err == nil {
+		t.Fatal("expected error for zero limit")
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"blog-writer/internal/schema"
+	"blog-writer/pkg/article"
+)
+
+// SchemaService validates and migrates raw article and settings JSON.
+type SchemaService struct{}
+
+// NewSchemaService constructs a SchemaService.
+func NewSchemaService() *SchemaService {
+	return &SchemaService{}
+}
+
+// Validate checks the article JSON in data against article.schema.json,
+// listing violations in the details of a VALIDATION_FAILED error.
+func (s *SchemaService) Validate(data string) error {
+	if err := schema.Validate([]byte(data)); err != nil {
+		return validationError("invalid article", err)
+	}
+	return nil
+}
+
+// ValidateSettings checks the settings JSON in data against
+// settings.schema.json.
+func (s *SchemaService) ValidateSettings(data string) error {
+	if err := schema.ValidateSettings([]byte(data)); err != nil {
+		return validationError("invalid settings", err)
+	}
+	return nil
+}
+
+// Migrate upgrades the article JSON in data to article.CurrentVersion. Data
+// that is already current is returned unchanged.
+func (s *SchemaService) Migrate(data string) (string, error) {
+	out, _, err := article.MigrateJSON([]byte(data))
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,27 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestSchemaValidate ensures schema violations are reported as validation
+// errors with their locations.
+func TestSchemaValidate(t *testing.T) {
+	svc := NewSchemaService()
+	valid := `{"version":"1.0.0","metadata":{"title":"T","author":"A","description":"","publicationDate":"2025-01-01T00:00:00Z","updatedDate":"2025-01-01T00:00:00Z","keywords":[]},"document":[]}`
+	if err := svc.Validate(valid); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	err := svc.Validate(`{"version":"1.0.0","metadata":{},"document":[{"tag":"script"}]}`)
+	if !errors.Is(err, ErrValidationFailed) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if issues := err.(*Error).Details["issues"].([]ValidationIssue); len(issues) == 0 {
+		t.Fatal("expected validation issues")
+	}
+	if out, err := svc.Migrate(valid); err != nil || out != valid {
+		t.Fatalf("Migrate changed a current article: %q, %v", out, err)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"blog-writer/internal/config"
+	"blog-writer/internal/events"
+	"blog-writer/internal/fsutil"
+	"blog-writer/internal/schema"
+)
+
+// settingsRelPath is the location of the settings file relative to the repository root.
+var settingsRelPath = filepath.Join(".blog-writer", "settings.json")
+
+// SettingsService loads, validates and updates repository settings.
+type SettingsService struct {
+	mu      sync.Mutex
+	cfgPath string
+
+	// Events, if set, receives a settings.updated event after each Update.
+	Events *events.Bus
+}
+
+// NewSettingsService constructs a SettingsService using the user's config
+// file for user-level defaults.
+func NewSettingsService() (*SettingsService, error) {
+	p, err := config.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return &SettingsService{cfgPath: p}, nil
+}
+
+// NewSettingsServiceWithPath constructs a SettingsService with a custom
+// config file path. Mainly used for tests.
+func NewSettingsServiceWithPath(path string) *SettingsService {
+	return &SettingsService{cfgPath: path}
+}
+
+// settingsPath returns the settings file path for the repository at repo.
+func settingsPath(repo string) string {
+	return filepath.Join(repo, settingsRelPath)
+}
+
+// Get loads the settings for the repository at repo. Older schema versions
+// are upgraded in memory, fields missing from settings.json are filled from
+// DefaultSettings and the result is validated against the settings schema.
+// A missing file yields the defaults.
+func (s *SettingsService) Get(repo string) (Settings, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return loadSettings(repo)
+}
+
+// Update validates and atomically writes settings for the repository at repo.
+// When commit is true the change is committed to git; settings identical to
+// the file on disk are neither written nor committed. Settings written by a
+// newer schema version are never overwritten and yield ErrSettingsTooNew.
+func (s *SettingsService) Update(repo string, settings Settings, commit bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := loadSettings(repo); errors.Is(err, ErrSettingsTooNew) {
+		return err
+	}
+	if settings.SchemaVersion > CurrentSettingsSchemaVersion {
+		return fmt.Errorf("%w: schemaVersion %d, supported %d", ErrSettingsTooNew, settings.SchemaVersion, CurrentSettingsSchemaVersion)
+	}
+	if settings.DefaultKeywords == nil {
+		settings.DefaultKeywords = []string{}
+	}
+	b, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := schema.ValidateSettings(b); err != nil {
+		return validationError("invalid settings", err)
+	}
+	if old, err := os.ReadFile(settingsPath(repo)); err == nil && bytes.Equal(old, b) {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Join(repo, ".blog-writer"), 0o755); err != nil {
+		return err
+	}
+	if err := fsutil.WriteFileAtomic(settingsPath(repo), b, 0o644); err != nil {
+		return err
+	}
+	if commit {
+		if err := commitSettings(repo); err != nil {
+			return err
+		}
+	}
+	s.Events.Publish(events.SettingsUpdated, repo, settings)
+	return nil
+}
+
+// commitSettings stages and commits settings.json in repo.
+func commitSettings(repo string) error {
+	ctx, done := gitOps.start(repo)
+	defer done()
+	if _, err := runGit(ctx, repo, "add", settingsRelPath); err != nil {
+		return err
+	}
+	_, err := runGit(ctx, repo, "commit", "-m", "chore(settings): update settings", "--", settingsRelPath)
+	return err
+}
+
+// Defaults returns the default repository settings.
+func (s *SettingsService) Defaults() Settings {
+	return DefaultSettings()
+}
+
+// loadSettings reads settings.json for repo, merging it over the defaults.
+func loadSettings(repo string) (Settings, error) {
+	settings := DefaultSettings()
+	b, err := os.ReadFile(settingsPath(repo))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return settings, nil
+		}
+		return Settings{}, err
+	}
+	b, _, err = migrateSettings(b, CurrentSettingsSchemaVersion, settingsMigrations)
+	if err != nil {
+		return Settings{}, err
+	}
+	if err := json.Unmarshal(b, &settings); err != nil {
+		return Settings{}, fmt.Errorf("parse settings: %w", err)
+	}
+	if settings.DefaultKeywords == nil {
+		settings.DefaultKeywords = []string{}
+	}
+	merged, err := json.Marshal(settings)
+	if err != nil {
+		return Settings{}, err
+	}
+	if err := schema.ValidateSettings(merged); err != nil {
+		return Settings{}, validationError("invalid settings", err)
+	}
+	return settings, nil
+}
@@ -0,0 +1,163 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+
+	"blog-writer/internal/config"
+)
+
+// SettingSource identifies the layer an effective setting was taken from.
+type SettingSource string
+
+// Setting sources in order of precedence.
+const (
+	SourceRepo    SettingSource = "repo"
+	SourceUser    SettingSource = "user"
+	SourceGit     SettingSource = "git"
+	SourceDefault SettingSource = "default"
+)
+
+// EffectiveSettings are repository settings after layered resolution.
+// Sources maps each settings.json field name to the layer that supplied it.
+type EffectiveSettings struct {
+	Settings Settings                 `json:"settings"`
+	Sources  map[string]SettingSource `json:"sources"`
+}
+
+// Effective resolves the settings for repo. Author, keywords and branch are
+// taken from the first non-empty layer of repo settings, user defaults in
+// the user config and git config (user.name, init.defaultBranch); every
+// other field comes from the repo settings or the built-in defaults.
+func (s *SettingsService) Effective(repo string) (EffectiveSettings, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ctx, done := gitOps.start(repo)
+	defer done()
+	settings, err := loadSettings(repo)
+	if err != nil {
+		return EffectiveSettings{}, err
+	}
+	present, err := repoSettingKeys(repo)
+	if err != nil {
+		return EffectiveSettings{}, err
+	}
+	cfg, err := config.Load(s.cfgPath)
+	if err != nil {
+		return EffectiveSettings{}, err
+	}
+	return layerSettings(ctx, repo, settings, present, cfg), nil
+}
+
+// layerSettings layers the user config cfg and the git config of repo over
+// the repo settings as Effective describes. present lists the keys set in
+// settings.json.
+func layerSettings(ctx context.Context, repo string, settings Settings, present map[string]bool, cfg config.Config) EffectiveSettings {
+	eff := EffectiveSettings{Settings: settings, Sources: map[string]SettingSource{}}
+	for _, key := range settingKeys() {
+		if present[key] {
+			eff.Sources[key] = SourceRepo
+		} else {
+			eff.Sources[key] = SourceDefault
+		}
+	}
+
+	eff.Settings.DefaultAuthor, eff.Sources["defaultAuthor"] = resolveString(
+		layer{SourceRepo, settings.DefaultAuthor},
+		layer{SourceUser, cfg.Defaults.DefaultAuthor},
+		layer{SourceGit, gitConfig(ctx, repo, "user.name")},
+	)
+	repoBranch := ""
+	if present["defaultBranch"] {
+		repoBranch = settings.DefaultBranch
+	}
+	eff.Settings.DefaultBranch, eff.Sources["defaultBranch"] = resolveString(
+		layer{SourceRepo, repoBranch},
+		layer{SourceUser, cfg.Defaults.DefaultBranch},
+		layer{SourceGit, gitConfig(ctx, repo, "init.defaultBranch")},
+	)
+	if eff.Settings.DefaultBranch == "" {
+		eff.Settings.DefaultBranch = DefaultSettings().DefaultBranch
+	}
+	switch {
+	case len(settings.DefaultKeywords) > 0:
+		eff.Sources["defaultKeywords"] = SourceRepo
+	case len(cfg.Defaults.DefaultKeywords) > 0:
+		eff.Settings.DefaultKeywords = cfg.Defaults.DefaultKeywords
+		eff.Sources["defaultKeywords"] = SourceUser
+	default:
+		eff.Sources["defaultKeywords"] = SourceDefault
+	}
+	return eff
+}
+
+// layer is a candidate value for a setting.
+type layer struct {
+	source SettingSource
+	value  string
+}
+
+// resolveString returns the first non-empty layer value and its source.
+func resolveString(layers ...layer) (string, SettingSource) {
+	for _, l := range layers {
+		if l.value != "" {
+			return l.value, l.source
+		}
+	}
+	return "", SourceDefault
+}
+
+// gitConfig returns the value of a git config key as seen from repo, or ""
+// when git is unavailable or the key is unset. Without a repo only the
+// global and system config are read, so a repository that happens to
+// contain the working directory cannot leak in.
+func gitConfig(ctx context.Context, repo, key string) string {
+	if repo == "" {
+		for _, scope := range []string{"--global", "--system"} {
+			if out, err := runGit(ctx, "", "config", scope, "--get", key); err == nil && out != "" {
+				return out
+			}
+		}
+		return ""
+	}
+	out, err := runGit(ctx, repo, "config", "--get", key)
+	if err != nil {
+		return ""
+	}
+	return out
+}
+
+// settingKeys lists the top-level settings.json field names.
+func settingKeys() []string {
+	b, _ := json.Marshal(DefaultSettings())
+	var m map[string]json.RawMessage
+	_ = json.Unmarshal(b, &m)
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// repoSettingKeys reports which top-level fields settings.json sets explicitly.
+func repoSettingKeys(repo string) (map[string]bool, error) {
+	present := map[string]bool{}
+	b, err := os.ReadFile(settingsPath(repo))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return present, nil
+		}
+		return nil, err
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+	for k := range m {
+		present[k] = true
+	}
+	return present, nil
+}
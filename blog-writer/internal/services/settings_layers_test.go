@@ -0,0 +1,115 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"blog-writer/internal/config"
+)
+
+// TestEffectiveLayers ensures repo, user and git layers are applied in order.
+func TestEffectiveLayers(t *testing.T) {
+	requireGit(t)
+	repo := t.TempDir()
+	if err := exec.Command("git", "init", repo).Run(); err != nil {
+		t.Fatalf("git init: %v", err)
+	}
+	if err := exec.Command("git", "-C", repo, "config", "user.name", "Git User").Run(); err != nil {
+		t.Fatalf("git config: %v", err)
+	}
+	cfgPath := filepath.Join(t.TempDir(), "config.yml")
+	svc := NewSettingsServiceWithPath(cfgPath)
+
+	writeSettings(t, repo, `{"schemaVersion":1,"defaultAuthor":""}`)
+	eff, err := svc.Effective(repo)
+	if err != nil {
+		t.Fatalf("Effective: %v", err)
+	}
+	if eff.Settings.DefaultAuthor != "Git User" || eff.Sources["defaultAuthor"] != SourceGit {
+		t.Fatalf("expected git author, got %q from %s", eff.Settings.DefaultAuthor, eff.Sources["defaultAuthor"])
+	}
+	if eff.Sources["autosave"] != SourceDefault || eff.Sources["schemaVersion"] != SourceRepo {
+		t.Fatalf("unexpected sources: %v", eff.Sources)
+	}
+
+	cfg := config.Config{Defaults: config.UserDefaults{
+		DefaultAuthor:   "User Default",
+		DefaultKeywords: []string{"go"},
+		DefaultBranch:   "trunk",
+	}}
+	if err := config.Save(cfgPath, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+	eff, err = svc.Effective(repo)
+	if err != nil {
+		t.Fatalf("Effective: %v", err)
+	}
+	if eff.Settings.DefaultAuthor != "User Default" || eff.Sources["defaultAuthor"] != SourceUser {
+		t.Fatalf("expected user author, got %q from %s", eff.Settings.DefaultAuthor, eff.Sources["defaultAuthor"])
+	}
+	if eff.Settings.DefaultBranch != "trunk" || eff.Sources["defaultBranch"] != SourceUser {
+		t.Fatalf("expected user branch, got %q from %s", eff.Settings.DefaultBranch, eff.Sources["defaultBranch"])
+	}
+	if len(eff.Settings.DefaultKeywords) != 1 || eff.Sources["defaultKeywords"] != SourceUser {
+		t.Fatalf("expected user keywords, got %v", eff.Settings.DefaultKeywords)
+	}
+
+	writeSettings(t, repo, `{"schemaVersion":1,"defaultAuthor":"Repo Author","defaultBranch":"main"}`)
+	eff, err = svc.Effective(repo)
+	if err != nil {
+		t.Fatalf("Effective: %v", err)
+	}
+	if eff.Settings.DefaultAuthor != "Repo Author" || eff.Sources["defaultAuthor"] != SourceRepo {
+		t.Fatalf("expected repo author, got %q from %s", eff.Settings.DefaultAuthor, eff.Sources["defaultAuthor"])
+	}
+	if eff.Settings.DefaultBranch != "main" || eff.Sources["defaultBranch"] != SourceRepo {
+		t.Fatalf("expected repo branch, got %q from %s", eff.Settings.DefaultBranch, eff.Sources["defaultBranch"])
+	}
+}
+
+// TestEffectiveDefaults ensures the built-in defaults apply when no layer sets a value.
+func TestEffectiveDefaults(t *testing.T) {
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(t.TempDir(), "gitconfig"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	repo := t.TempDir()
+	svc := NewSettingsServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	eff, err := svc.Effective(repo)
+	if err != nil {
+		t.Fatalf("Effective: %v", err)
+	}
+	if eff.Settings.DefaultBranch != "main" || eff.Sources["defaultBranch"] != SourceDefault {
+		t.Fatalf("expected default branch, got %q from %s", eff.Settings.DefaultBranch, eff.Sources["defaultBranch"])
+	}
+	if eff.Sources["defaultAuthor"] != SourceDefault {
+		t.Fatalf("expected default author source, got %s", eff.Sources["defaultAuthor"])
+	}
+}
+
+// TestGitConfigWithoutRepo ensures a lookup without a repository ignores
+// the repository containing the working directory.
+func TestGitConfigWithoutRepo(t *testing.T) {
+	requireGit(t)
+	global := filepath.Join(t.TempDir(), "gitconfig")
+	t.Setenv("GIT_CONFIG_GLOBAL", global)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	if err := exec.Command("git", "config", "--file", global, "init.defaultBranch", "global-branch").Run(); err != nil {
+		t.Fatalf("git config: %v", err)
+	}
+	cwd := t.TempDir()
+	if err := exec.Command("git", "init", cwd).Run(); err != nil {
+		t.Fatalf("git init: %v", err)
+	}
+	if err := exec.Command("git", "-C", cwd, "config", "init.defaultBranch", "cwd-branch").Run(); err != nil {
+		t.Fatalf("git config: %v", err)
+	}
+	t.Chdir(cwd)
+	if got := gitConfig(context.Background(), "", "init.defaultBranch"); got != "global-branch" {
+		t.Fatalf("expected global branch, got %q", got)
+	}
+	if got := gitConfig(context.Background(), cwd, "init.defaultBranch"); got != "cwd-branch" {
+		t.Fatalf("expected repository branch, got %q", got)
+	}
+}
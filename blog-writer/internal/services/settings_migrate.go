@@ -0,0 +1,116 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"blog-writer/internal/fsutil"
+)
+
+// settingsMigration upgrades raw settings from version From to From+1.
+type settingsMigration struct {
+	From  int
+	Apply func(doc map[string]interface{}) error
+}
+
+// settingsMigrations lists the upgrade steps in ascending order of From.
+// Add a step here and bump CurrentSettingsSchemaVersion whenever the
+// settings.json format changes.
+var settingsMigrations = []settingsMigration{}
+
+// settingsVersion reports the schemaVersion recorded in doc. Files written
+// before versioning was introduced are treated as version 1.
+func settingsVersion(doc map[string]interface{}) (int, error) {
+	v, ok := doc["schemaVersion"]
+	if !ok {
+		return 1, nil
+	}
+	f, ok := v.(float64)
+	if !ok || f != float64(int(f)) || f < 1 {
+		return 0, fmt.Errorf("invalid schemaVersion %v", v)
+	}
+	return int(f), nil
+}
+
+// migrateSettings upgrades raw settings JSON to target using steps. It returns
+// the upgraded document and the version it started from. Documents newer than
+// target are rejected with ErrSettingsTooNew.
+func migrateSettings(raw []byte, target int, steps []settingsMigration) ([]byte, int, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, 0, fmt.Errorf("parse settings: %w", err)
+	}
+	from, err := settingsVersion(doc)
+	if err != nil {
+		return nil, 0, err
+	}
+	if from > target {
+		return nil, from, fmt.Errorf("%w: schemaVersion %d, supported %d", ErrSettingsTooNew, from, target)
+	}
+	if from == target {
+		return raw, from, nil
+	}
+	for v := from; v < target; v++ {
+		step, ok := findSettingsMigration(steps, v)
+		if !ok {
+			return nil, from, fmt.Errorf("no settings migration from schemaVersion %d", v)
+		}
+		if err := step.Apply(doc); err != nil {
+			return nil, from, fmt.Errorf("migrate settings from schemaVersion %d: %w", v, err)
+		}
+		doc["schemaVersion"] = v + 1
+	}
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, from, err
+	}
+	return b, from, nil
+}
+
+// findSettingsMigration returns the step upgrading from version v.
+func findSettingsMigration(steps []settingsMigration, v int) (settingsMigration, bool) {
+	for _, s := range steps {
+		if s.From == v {
+			return s, true
+		}
+	}
+	return settingsMigration{}, false
+}
+
+// MigrateSettingsFile upgrades .blog-writer/settings.json in repo to the
+// current schema version. The original file is kept as
+// settings.json.v<N>.bak before the upgraded document is written. It
+// reports whether a migration was performed.
+func MigrateSettingsFile(repo string) (bool, error) {
+	return migrateSettingsFile(repo, CurrentSettingsSchemaVersion, settingsMigrations)
+}
+
+// migrateSettingsFile upgrades the settings file in repo to target using steps.
+func migrateSettingsFile(repo string, target int, steps []settingsMigration) (bool, error) {
+	path := settingsPath(repo)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	migrated, from, err := migrateSettings(raw, target, steps)
+	if err != nil {
+		return false, err
+	}
+	if from == target {
+		return false, nil
+	}
+	backup := fmt.Sprintf("%s.v%d.bak", path, from)
+	if err := fsutil.WriteFileAtomic(backup, raw, 0o644); err != nil {
+		return false, err
+	}
+	if err := fsutil.WriteFileAtomic(path, migrated, 0o644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestMigrateSettingsSteps ensures steps run in order and bump schemaVersion.
+func TestMigrateSettingsSteps(t *testing.T) {
+	steps := []settingsMigration{
+		{From: 2, Apply: func(doc map[string]interface{}) error {
+			doc["remote"] = doc["remote"].(string) + "-v3"
+			return nil
+		}},
+		{From: 1, Apply: func(doc map[string]interface{}) error {
+			doc["remote"] = doc["origin"]
+			delete(doc, "origin")
+			return nil
+		}},
+	}
+	out, from, err := migrateSettings([]byte(`{"schemaVersion":1,"origin":"git@x"}`), 3, steps)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if from != 1 {
+		t.Fatalf("expected from 1, got %d", from)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if doc["schemaVersion"].(float64) != 3 || doc["remote"] != "git@x-v3" {
+		t.Fatalf("unexpected result: %v", doc)
+	}
+	if _, ok := doc["origin"]; ok {
+		t.Fatalf("expected origin removed: %v", doc)
+	}
+}
+
+// TestMigrateSettingsTooNew ensures newer settings are refused.
+func TestMigrateSettingsTooNew(t *testing.T) {
+	_, _, err := migrateSettings([]byte(`{"schemaVersion":9}`), 1, nil)
+	if !errors.Is(err, ErrSettingsTooNew) {
+		t.Fatalf("expected ErrSettingsTooNew, got %v", err)
+	}
+}
+
+// TestMigrateSettingsMissingStep ensures gaps in the registry are reported.
+func TestMigrateSettingsMissingStep(t *testing.T) {
+	if _, _, err := migrateSettings([]byte(`{"schemaVersion":1}`), 2, nil); err == nil {
+		t.Fatal("expected error for missing step")
+	}
+}
+
+// TestMigrateSettingsFileBackup ensures the file is upgraded and the original is kept.
+func TestMigrateSettingsFileBackup(t *testing.T) {
+	repo := t.TempDir()
+	raw := `{"defaultAuthor":"Ada"}`
+	writeSettings(t, repo, raw)
+	steps := []settingsMigration{{From: 1, Apply: func(doc map[string]interface{}) error {
+		doc["remote"] = ""
+		return nil
+	}}}
+	migrated, err := migrateSettingsFile(repo, 2, steps)
+	if err != nil {
+		t.Fatalf("migrateSettingsFile: %v", err)
+	}
+	if !migrated {
+		t.Fatal("expected migration to run")
+	}
+	backup, err := os.ReadFile(settingsPath(repo) + ".v1.bak")
+	if err != nil {
+		t.Fatalf("read backup: %v", err)
+	}
+	if string(backup) != raw {
+		t.Fatalf("backup mismatch: %s", backup)
+	}
+	var doc map[string]interface{}
+	b, _ := os.ReadFile(settingsPath(repo))
+	if err := json.Unmarshal(b, &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if doc["schemaVersion"].(float64) != 2 || doc["defaultAuthor"] != "Ada" {
+		t.Fatalf("unexpected migrated settings: %v", doc)
+	}
+	migrated, err = migrateSettingsFile(repo, 2, steps)
+	if err != nil || migrated {
+		t.Fatalf("expected no-op on current settings, got %v %v", migrated, err)
+	}
+}
+
+// TestOpenRefusesNewerSettings ensures repos from a newer app version are not opened.
+func TestOpenRefusesNewerSettings(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	writeSettings(t, repo, `{"schemaVersion":99}`)
+	svc := NewRepoServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	if err := svc.Open(repo); !errors.Is(err, ErrSettingsTooNew) {
+		t.Fatalf("expected ErrSettingsTooNew, got %v", err)
+	}
+	if _, err := NewSettingsServiceWithPath(filepath.Join(t.TempDir(), "config.yml")).Get(repo); !errors.Is(err, ErrSettingsTooNew) {
+		t.Fatalf("expected Get to refuse newer settings, got %v", err)
+	}
+}
+
+// TestUpdateRefusesNewerSettings ensures settings from a newer app version
+// are not overwritten with an older schema.
+func TestUpdateRefusesNewerSettings(t *testing.T) {
+	repo := t.TempDir()
+	newer := `{"schemaVersion":99}`
+	writeSettings(t, repo, newer)
+	svc := NewSettingsServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	if err := svc.Update(repo, DefaultSettings(), false); !errors.Is(err, ErrSettingsTooNew) {
+		t.Fatalf("expected ErrSettingsTooNew, got %v", err)
+	}
+	if b, _ := os.ReadFile(settingsPath(repo)); string(b) != newer {
+		t.Fatalf("newer settings overwritten: %s", b)
+	}
+	future := DefaultSettings()
+	future.SchemaVersion = CurrentSettingsSchemaVersion + 1
+	if err := svc.Update(t.TempDir(), future, false); !errors.Is(err, ErrSettingsTooNew) {
+		t.Fatalf("expected ErrSettingsTooNew for a newer version, got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"blog-writer/internal/events"
+)
+
+// writeSettings writes raw settings JSON into repo.
+func writeSettings(t *testing.T, repo, data string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(repo, ".blog-writer"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(settingsPath(repo), []byte(data), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+}
+
+// requireGit skips the test when git is unavailable and provides a commit identity.
+func requireGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+}
+
+// TestSettingsGetMergesDefaults ensures missing fields are filled from defaults.
+func TestSettingsGetMergesDefaults(t *testing.T) {
+	repo := t.TempDir()
+	writeSettings(t, repo, `{"schemaVersion":1,"defaultAuthor":"Ada","autosave":{"enabled":false}}`)
+	svc := NewSettingsServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	got, err := svc.Get(repo)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.DefaultAuthor != "Ada" {
+		t.Fatalf("expected author Ada, got %q", got.DefaultAuthor)
+	}
+	if got.Autosave.Enabled {
+		t.Fatal("expected autosave disabled from file")
+	}
+	if got.Autosave.IntervalMs != 15000 {
+		t.Fatalf("expected default interval, got %d", got.Autosave.IntervalMs)
+	}
+	if got.DefaultBranch != "main" || got.ImageVectorization.Colors != 8 {
+		t.Fatalf("defaults not applied: %+v", got)
+	}
+}
+
+// TestSettingsGetMissingFile ensures a missing file yields defaults.
+func TestSettingsGetMissingFile(t *testing.T) {
+	got, err := NewSettingsServiceWithPath(filepath.Join(t.TempDir(), "config.yml")).Get(t.TempDir())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	want, _ := json.Marshal(DefaultSettings())
+	have, _ := json.Marshal(got)
+	if string(want) != string(have) {
+		t.Fatalf("expected defaults\n%s\ngot\n%s", want, have)
+	}
+}
+
+// TestSettingsGetInvalid ensures schema violations are reported.
+func TestSettingsGetInvalid(t *testing.T) {
+	repo := t.TempDir()
+	writeSettings(t, repo, `{"schemaVersion":1,"autosave":{"intervalMs":10}}`)
+	if _, err := NewSettingsServiceWithPath(filepath.Join(t.TempDir(), "config.yml")).Get(repo); err == nil {
+		t.Fatal("expected validation error")
+	}
+	writeSettings(t, repo, `{"schemaVersion":`)
+	if _, err := NewSettingsServiceWithPath(filepath.Join(t.TempDir(), "config.yml")).Get(repo); err == nil {
+		t.Fatal("expected parse error")
+	}
+}
+
+// TestSettingsUpdate ensures updates are validated, written and optionally
+// committed, and that unchanged settings are not committed again.
+func TestSettingsUpdate(t *testing.T) {
+	requireGit(t)
+	cfgFile := filepath.Join(t.TempDir(), "config.yml")
+	repo := filepath.Join(t.TempDir(), "repo")
+	if err := NewRepoServiceWithPath(cfgFile).Create("", repo); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	svc := NewSettingsServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	svc.Events = events.New()
+	updated := 0
+	svc.Events.Subscribe(string(events.SettingsUpdated), func(events.Event) { updated++ })
+	s, err := svc.Get(repo)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	s.DefaultAuthor = "Grace"
+	if err := svc.Update(repo, s, true); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	got, err := svc.Get(repo)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.DefaultAuthor != "Grace" {
+		t.Fatalf("expected updated author, got %q", got.DefaultAuthor)
+	}
+	out, err := exec.Command("git", "-C", repo, "log", "-1", "--format=%s").Output()
+	if err != nil {
+		t.Fatalf("git log: %v", err)
+	}
+	if !strings.Contains(string(out), "chore(settings)") {
+		t.Fatalf("expected settings commit, got %q", out)
+	}
+	if updated != 1 {
+		t.Fatalf("expected one settings.updated event, got %d", updated)
+	}
+	head := func() string {
+		out, err := exec.Command("git", "-C", repo, "rev-parse", "HEAD").Output()
+		if err != nil {
+			t.Fatalf("git rev-parse: %v", err)
+		}
+		return string(out)
+	}
+	before := head()
+	if err := svc.Update(repo, s, true); err != nil {
+		t.Fatalf("unchanged Update: %v", err)
+	}
+	if head() != before || updated != 1 {
+		t.Fatalf("expected unchanged settings to be skipped, got %d events", updated)
+	}
+
+	s.MaxSvgNodeCount = 0
+	if err := svc.Update(repo, s, false); err == nil {
+		t.Fatal("expected validation error")
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import "blog-writer/internal/config"
+
+// UIStateService persists layout state such as open tabs and panel splits
+// in the user config.
+type UIStateService struct {
+	cfgPath string
+}
+
+// NewUIStateService constructs a UIStateService backed by the user's config file.
+func NewUIStateService() (*UIStateService, error) {
+	p, err := config.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return &UIStateService{cfgPath: p}, nil
+}
+
+// NewUIStateServiceWithPath constructs a UIStateService with a custom config
+// file path. Mainly used for tests.
+func NewUIStateServiceWithPath(path string) *UIStateService {
+	return &UIStateService{cfgPath: path}
+}
+
+// Get returns the stored UI state.
+func (u *UIStateService) Get() (config.UIState, error) {
+	cfg, err := config.Load(u.cfgPath)
+	if err != nil {
+		return config.UIState{}, err
+	}
+	cfg.UI.Normalize()
+	return cfg.UI, nil
+}
+
+// Save stores the UI state. The last open repository is kept when state
+// leaves it empty.
+func (u *UIStateService) Save(state config.UIState) error {
+	state.Normalize()
+	return config.Update(u.cfgPath, func(cfg *config.Config) error {
+		if state.LastRepo == "" {
+			state.LastRepo = cfg.UI.LastRepo
+		}
+		cfg.UI = state
+		return nil
+	})
+}
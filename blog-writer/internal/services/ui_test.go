@@ -0,0 +1,40 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"blog-writer/internal/config"
+)
+
+// TestUIStateRoundTrip ensures UI state is persisted and the last repo set
+// by Open survives a save from the frontend.
+func TestUIStateRoundTrip(t *testing.T) {
+	cfg := filepath.Join(t.TempDir(), "config.yml")
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := NewRepoServiceWithPath(cfg).Open(repo); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+
+	svc := NewUIStateServiceWithPath(cfg)
+	state := config.UIState{
+		Tabs:      []config.ArticleTab{{Repo: repo, ID: "1755288225"}, {Repo: repo, ID: "1755288300"}},
+		ActiveTab: 1,
+		Splits:    map[string]float64{"sidebar": 0.25},
+	}
+	if err := svc.Save(state); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	got, err := svc.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.LastRepo != repo || len(got.Tabs) != 2 || got.ActiveTab != 1 || got.Splits["sidebar"] != 0.25 {
+		t.Fatalf("unexpected state: %+v", got)
+	}
+}
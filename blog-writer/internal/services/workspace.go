@@ -0,0 +1,351 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"blog-writer/internal/config"
+	"blog-writer/internal/events"
+	"blog-writer/pkg/article"
+)
+
+// DefaultWatchInterval is how often open repositories are polled for
+// article changes.
+const DefaultWatchInterval = 2 * time.Second
+
+// WorkspaceRepo describes a repository open in the workspace.
+type WorkspaceRepo struct {
+	Path   string `json:"path"`
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+}
+
+// repoSession holds the per-repository service instances of the workspace.
+type repoSession struct {
+	path     string
+	settings *SettingsService
+	index    *ArticleIndex
+	locked   bool
+	stop     chan struct{}
+	stopped  chan struct{}
+}
+
+// WorkspaceService keeps several repositories open side by side. The list of
+// repositories and the active one are persisted in the user's config.
+type WorkspaceService struct {
+	mu       sync.Mutex
+	cfgPath  string
+	sessions map[string]*repoSession
+	// Interval controls how often repositories are polled for changes. Zero
+	// disables watching.
+	Interval time.Duration
+	// Events, if set, receives workspace.active.changed events, the
+	// watcher's repo.changed events and repo.locked when an added
+	// repository is already open in another process.
+	Events *events.Bus
+}
+
+// NewWorkspaceService constructs a WorkspaceService backed by the user's
+// config file.
+func NewWorkspaceService() (*WorkspaceService, error) {
+	p, err := config.DefaultPath()
+	if err != nil {
+		return nil, err
+	}
+	return NewWorkspaceServiceWithPath(p), nil
+}
+
+// NewWorkspaceServiceWithPath constructs a WorkspaceService with a custom
+// config file path. Mainly used for tests.
+func NewWorkspaceServiceWithPath(path string) *WorkspaceService {
+	return &WorkspaceService{cfgPath: path, sessions: map[string]*repoSession{}, Interval: DefaultWatchInterval}
+}
+
+// List returns the repositories in the workspace in the order they were added.
+func (w *WorkspaceService) List() ([]WorkspaceRepo, error) {
+	cfg, err := config.Load(w.cfgPath)
+	if err != nil {
+		return nil, err
+	}
+	repos := make([]WorkspaceRepo, 0, len(cfg.Workspace.Repos))
+	for _, p := range cfg.Workspace.Repos {
+		repos = append(repos, WorkspaceRepo{Path: p, Name: filepath.Base(p), Active: p == cfg.Workspace.Active})
+	}
+	return repos, nil
+}
+
+// Add opens the repository at path and adds it to the workspace. The first
+// repository added becomes active.
+func (w *WorkspaceService) Add(path string) error {
+	if !isGitRepo(path) {
+		return ErrNotGitRepo
+	}
+	if err := ensureLayout(path); err != nil {
+		return err
+	}
+	if _, err := MigrateSettingsFile(path); err != nil {
+		return err
+	}
+	var active string
+	err := w.updateConfig(func(cfg *config.Config) error {
+		first := cfg.Workspace.Active == ""
+		cfg.Workspace.Add(path)
+		if first {
+			active = cfg.Workspace.Active
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	w.session(path)
+	if active != "" {
+		w.notifyActive(active)
+	}
+	return nil
+}
+
+// Remove closes the repository at path and removes it from the workspace.
+func (w *WorkspaceService) Remove(path string) error {
+	var before, after string
+	err := w.updateConfig(func(cfg *config.Config) error {
+		if !cfg.Workspace.Contains(path) {
+			return ErrNotInWorkspace
+		}
+		before = cfg.Workspace.Active
+		cfg.Workspace.Remove(path)
+		after = cfg.Workspace.Active
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	s := w.sessions[path]
+	delete(w.sessions, path)
+	w.mu.Unlock()
+	if s != nil {
+		s.close()
+	}
+	if before != after {
+		w.notifyActive(after)
+	}
+	return nil
+}
+
+// Active returns the path of the active repository or an empty string when
+// the workspace is empty.
+func (w *WorkspaceService) Active() (string, error) {
+	cfg, err := config.Load(w.cfgPath)
+	if err != nil {
+		return "", err
+	}
+	return cfg.Workspace.Active, nil
+}
+
+// SetActive switches the active repository to path.
+func (w *WorkspaceService) SetActive(path string) error {
+	err := w.updateConfig(func(cfg *config.Config) error {
+		if !cfg.Workspace.Contains(path) {
+			return ErrNotInWorkspace
+		}
+		cfg.Workspace.Active = path
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	w.notifyActive(path)
+	return nil
+}
+
+// Settings returns the settings of a repository in the workspace.
+func (w *WorkspaceService) Settings(path string) (Settings, error) {
+	s, err := w.open(path)
+	if err != nil {
+		return Settings{}, err
+	}
+	return s.settings.Get(path)
+}
+
+// Articles returns the indexed articles of a repository in the workspace.
+func (w *WorkspaceService) Articles(path string) ([]IndexEntry, error) {
+	s, err := w.open(path)
+	if err != nil {
+		return nil, err
+	}
+	return s.index.Entries()
+}
+
+// Search looks for query in every repository of the workspace.
+func (w *WorkspaceService) Search(query string) ([]SearchHit, error) {
+	sessions, err := w.all()
+	if err != nil {
+		return nil, err
+	}
+	hits := []SearchHit{}
+	for _, s := range sessions {
+		h, err := s.index.Search(query)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.path, err)
+		}
+		hits = append(hits, h...)
+	}
+	return hits, nil
+}
+
+// Validate checks every article in every repository of the workspace
+// against the article schema.
+func (w *WorkspaceService) Validate() ([]ValidationResult, error) {
+	sessions, err := w.all()
+	if err != nil {
+		return nil, err
+	}
+	results := []ValidationResult{}
+	for _, s := range sessions {
+		r, err := s.index.Validate()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", s.path, err)
+		}
+		results = append(results, r...)
+	}
+	return results, nil
+}
+
+// Close stops watching all repositories.
+func (w *WorkspaceService) Close() {
+	w.mu.Lock()
+	sessions := w.sessions
+	w.sessions = map[string]*repoSession{}
+	w.mu.Unlock()
+	for _, s := range sessions {
+		s.close()
+	}
+}
+
+// open returns the session for path if it is part of the workspace.
+func (w *WorkspaceService) open(path string) (*repoSession, error) {
+	cfg, err := config.Load(w.cfgPath)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Workspace.Contains(path) {
+		return nil, ErrNotInWorkspace
+	}
+	return w.session(path), nil
+}
+
+// all returns sessions for every repository in the workspace, in order.
+func (w *WorkspaceService) all() ([]*repoSession, error) {
+	cfg, err := config.Load(w.cfgPath)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]*repoSession, 0, len(cfg.Workspace.Repos))
+	for _, p := range cfg.Workspace.Repos {
+		if _, err := os.Stat(p); err != nil {
+			continue
+		}
+		sessions = append(sessions, w.session(p))
+	}
+	return sessions, nil
+}
+
+// session returns the session for path, creating and starting it if needed.
+func (w *WorkspaceService) session(path string) *repoSession {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if s, ok := w.sessions[path]; ok {
+		return s
+	}
+	s := &repoSession{
+		path:     path,
+		settings: NewSettingsServiceWithPath(w.cfgPath),
+		index:    NewArticleIndex(path),
+	}
+	if warning, err := repoLocks.acquire(path); err == nil {
+		s.locked = warning == nil
+		if warning != nil {
+			w.Events.Publish(events.RepoLocked, path, *warning)
+		}
+	}
+	if w.Interval > 0 {
+		s.watch(w.Interval, func() {
+			w.Events.Publish(events.RepoChanged, path, nil)
+		})
+	}
+	w.sessions[path] = s
+	return s
+}
+
+// updateConfig applies fn to the user config through config.Update.
+func (w *WorkspaceService) updateConfig(fn func(cfg *config.Config) error) error {
+	return config.Update(w.cfgPath, fn)
+}
+
+// notifyActive publishes a change of the active repository.
+func (w *WorkspaceService) notifyActive(path string) {
+	w.Events.Publish(events.WorkspaceActiveChanged, path, nil)
+}
+
+// watch polls the repository's articles every interval and refreshes the
+// index when they change.
+func (s *repoSession) watch(interval time.Duration, changed func()) {
+	s.stop = make(chan struct{})
+	s.stopped = make(chan struct{})
+	last := fingerprint(s.path)
+	go func() {
+		defer close(s.stopped)
+		t := time.NewTicker(interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-t.C:
+			}
+			fp := fingerprint(s.path)
+			if fp == last {
+				continue
+			}
+			last = fp
+			if err := s.index.Refresh(); err == nil {
+				changed()
+			}
+		}
+	}()
+}
+
+// close stops the session's watcher and releases its repository lock.
+func (s *repoSession) close() {
+	if s.locked {
+		repoLocks.release(s.path)
+		s.locked = false
+	}
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	<-s.stopped
+	s.stop = nil
+}
+
+// fingerprint hashes the names, sizes and modification times of the
+// articles in repo.
+func fingerprint(repo string) uint64 {
+	h := fnv.New64a()
+	paths, _ := article.Scan(repo)
+	for _, p := range paths {
+		fi, err := os.Stat(p)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", p, fi.Size(), fi.ModTime().UnixNano())
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2025 blog-writer authors
+package services
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"blog-writer/internal/events"
+)
+
+// newWorkspaceRepo creates a fake git repository holding one article.
+func newWorkspaceRepo(t *testing.T, title, text string) string {
+	t.Helper()
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	writeArticle(t, repo, filepath.Join("notes", "1755288225.json"),
+		`{"version":"1.0.0","metadata":{"title":"`+title+`","author":"A","description":"d","publicationDate":"2025-01-01T00:00:00Z","updatedDate":"2025-01-01T00:00:00Z","keywords":["k"]},"document":[{"tag":"p","content":[{"tag":"span","content":"`+text+`"}]}]}`)
+	return repo
+}
+
+// TestWorkspaceAddSwitchRemove ensures repositories are persisted and the
+// active repository follows additions, switches and removals.
+func TestWorkspaceAddSwitchRemove(t *testing.T) {
+	cfg := filepath.Join(t.TempDir(), "config.yml")
+	svc := NewWorkspaceServiceWithPath(cfg)
+	svc.Interval = 0
+	var changes []string
+	svc.Events = events.New()
+	svc.Events.Subscribe(string(events.WorkspaceActiveChanged), func(e events.Event) { changes = append(changes, e.Repo) })
+
+	a := newWorkspaceRepo(t, "Company", "quarterly update")
+	b := newWorkspaceRepo(t, "Personal", "hiking trip")
+	for _, p := range []string{a, b} {
+		if err := svc.Add(p); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+	if err := svc.Add(t.TempDir()); !errors.Is(err, ErrNotGitRepo) {
+		t.Fatalf("expected ErrNotGitRepo, got %v", err)
+	}
+	if err := svc.SetActive(b); err != nil {
+		t.Fatalf("SetActive: %v", err)
+	}
+	if err := svc.SetActive(t.TempDir()); !errors.Is(err, ErrNotInWorkspace) {
+		t.Fatalf("expected ErrNotInWorkspace, got %v", err)
+	}
+
+	// A fresh service sees the persisted workspace.
+	reloaded := NewWorkspaceServiceWithPath(cfg)
+	reloaded.Interval = 0
+	repos, err := reloaded.List()
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(repos) != 2 || repos[0].Path != a || repos[0].Active || !repos[1].Active {
+		t.Fatalf("unexpected workspace: %+v", repos)
+	}
+
+	if err := svc.Remove(b); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	active, _ := svc.Active()
+	if active != a {
+		t.Fatalf("expected %s active, got %s", a, active)
+	}
+	if want := []string{a, b, a}; len(changes) != len(want) || changes[0] != want[0] || changes[1] != want[1] || changes[2] != want[2] {
+		t.Fatalf("unexpected events: %v", changes)
+	}
+}
+
+// TestWorkspaceSearchValidate ensures search and validation span every
+// repository in the workspace.
+func TestWorkspaceSearchValidate(t *testing.T) {
+	svc := NewWorkspaceServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	svc.Interval = 0
+	a := newWorkspaceRepo(t, "Company news", "quarterly update")
+	b := newWorkspaceRepo(t, "Trip", "company offsite hike")
+	writeArticle(t, b, filepath.Join("notes", "1755288226.json"), `{"version":"1.0.0"}`)
+	for _, p := range []string{a, b} {
+		if err := svc.Add(p); err != nil {
+			t.Fatalf("Add: %v", err)
+		}
+	}
+
+	hits, err := svc.Search("COMPANY")
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(hits) != 2 || hits[0].Repo != a || hits[1].Repo != b || hits[1].Snippet == "" {
+		t.Fatalf("unexpected hits: %+v", hits)
+	}
+
+	results, err := svc.Validate()
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	invalid := 0
+	for _, r := range results {
+		if !r.Valid {
+			invalid++
+			if r.Repo != b || r.ID != "1755288226" {
+				t.Fatalf("unexpected invalid article: %+v", r)
+			}
+		}
+	}
+	if len(results) != 3 || invalid != 1 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	if _, err := svc.Settings(a); err != nil {
+		t.Fatalf("Settings: %v", err)
+	}
+	if _, err := svc.Articles(t.TempDir()); !errors.Is(err, ErrNotInWorkspace) {
+		t.Fatalf("expected ErrNotInWorkspace, got %v", err)
+	}
+}
+
+// TestWorkspaceWatch ensures on-disk article changes refresh the index.
+func TestWorkspaceWatch(t *testing.T) {
+	svc := NewWorkspaceServiceWithPath(filepath.Join(t.TempDir(), "config.yml"))
+	svc.Interval = 10 * time.Millisecond
+	changed := make(chan string, 1)
+	svc.Events = events.New()
+	svc.Events.Subscribe(string(events.RepoChanged), func(e events.Event) {
+		select {
+		case changed <- e.Repo:
+		default:
+		}
+	})
+	defer svc.Close()
+	repo := newWorkspaceRepo(t, "First", "one")
+	if err := svc.Add(repo); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if _, err := svc.Articles(repo); err != nil {
+		t.Fatalf("Articles: %v", err)
+	}
+	writeArticle(t, repo, filepath.Join("notes", "1755288300.json"),
+		`{"version":"1.0.0","metadata":{"title":"Second"},"document":[]}`)
+	select {
+	case p := <-changed:
+		if p != repo {
+			t.Fatalf("unexpected repo %s", p)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change notification")
+	}
+	entries, _ := svc.Articles(repo)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", entries)
+	}
+}
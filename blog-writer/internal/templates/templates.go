@@ -0,0 +1,331 @@
+// Copyright (c) 2025 blog-writer authors
+
+// Package templates provides repository templates used to scaffold new blog
+// repositories. A template is a directory holding a template.json manifest
+// and an optional files/ tree that is copied verbatim into the repository.
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"blog-writer/internal/fsutil"
+	"blog-writer/internal/schema"
+	"blog-writer/pkg/article"
+)
+
+// ManifestFile is the name of the template manifest.
+const ManifestFile = "template.json"
+
+//go:embed all:builtin
+var builtinFS embed.FS
+
+// Template describes a repository template.
+type Template struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Settings holds settings.json fields that override the defaults.
+	Settings json.RawMessage `json:"settings,omitempty"`
+	// Subjects lists folders created under blog/.
+	Subjects []string `json:"subjects,omitempty"`
+	// IncludeSchema copies the article schema to .blog-writer/article.schema.json
+	// so CI can validate articles without the application.
+	IncludeSchema bool `json:"includeSchema,omitempty"`
+	// Articles lists starter articles stored in the template.
+	Articles []StarterArticle `json:"articles,omitempty"`
+
+	fsys fs.FS
+}
+
+// StarterArticle is an article copied into blog/<subject>/ with a fresh ID.
+type StarterArticle struct {
+	File    string `json:"file"`
+	Subject string `json:"subject,omitempty"`
+}
+
+// Info summarizes a template for display.
+type Info struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Builtin     bool   `json:"builtin"`
+}
+
+// Builtin returns the templates shipped with the application, sorted by name.
+func Builtin() ([]Template, error) {
+	entries, err := fs.ReadDir(builtinFS, "builtin")
+	if err != nil {
+		return nil, err
+	}
+	var out []Template
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		sub, err := fs.Sub(builtinFS, path.Join("builtin", e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		t, err := Load(sub)
+		if err != nil {
+			return nil, fmt.Errorf("builtin template %s: %w", e.Name(), err)
+		}
+		out = append(out, t)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// FindBuiltin returns the built-in template called name.
+func FindBuiltin(name string) (Template, bool) {
+	all, err := Builtin()
+	if err != nil {
+		return Template{}, false
+	}
+	for _, t := range all {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Template{}, false
+}
+
+// LoadDir reads a template from a directory on disk.
+func LoadDir(dir string) (Template, error) {
+	return Load(os.DirFS(dir))
+}
+
+// Load reads a template from fsys.
+func Load(fsys fs.FS) (Template, error) {
+	b, err := fs.ReadFile(fsys, ManifestFile)
+	if err != nil {
+		return Template{}, err
+	}
+	var t Template
+	if err := json.Unmarshal(b, &t); err != nil {
+		return Template{}, fmt.Errorf("parse %s: %w", ManifestFile, err)
+	}
+	if t.Name == "" {
+		return Template{}, errors.New("template name required")
+	}
+	for _, s := range t.Subjects {
+		if !validRel(s) {
+			return Template{}, fmt.Errorf("invalid subject %q", s)
+		}
+	}
+	for _, a := range t.Articles {
+		if !validRel(a.File) || (a.Subject != "" && !validRel(a.Subject)) {
+			return Template{}, fmt.Errorf("invalid starter article %q", a.File)
+		}
+		if err := checkRegular(fsys, a.File); err != nil {
+			return Template{}, err
+		}
+		b, err := fs.ReadFile(fsys, a.File)
+		if err != nil {
+			return Template{}, err
+		}
+		if err := article.Validate(b); err != nil {
+			return Template{}, fmt.Errorf("starter article %s: %w", a.File, err)
+		}
+	}
+	if err := checkFiles(fsys); err != nil {
+		return Template{}, err
+	}
+	t.fsys = fsys
+	return t, nil
+}
+
+// checkFiles rejects entries of the files/ tree that are neither regular
+// files nor directories, such as symlinks that would copy files from
+// outside the template, and those that would overwrite git internals or the
+// repository settings, which are written by the app.
+func checkFiles(fsys fs.FS) error {
+	if _, err := fs.Stat(fsys, "files"); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err := checkEntry(fsys, "files", true); err != nil {
+		return err
+	}
+	return fs.WalkDir(fsys, "files", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || p == "files" {
+			return err
+		}
+		rel := strings.TrimPrefix(p, "files/")
+		if !validRel(rel) || reservedFile(rel) || !plainEntry(d) {
+			return fmt.Errorf("invalid template file %q", p)
+		}
+		return nil
+	})
+}
+
+// plainEntry reports whether d is a regular file or a directory.
+func plainEntry(d fs.DirEntry) bool {
+	return d.IsDir() || d.Type().IsRegular()
+}
+
+// checkRegular fails unless every parent of the slash path p in fsys is a
+// directory and p itself is a regular file, none of them symlinks.
+func checkRegular(fsys fs.FS, p string) error {
+	parts := strings.Split(p, "/")
+	for i := range parts {
+		if err := checkEntry(fsys, strings.Join(parts[:i+1], "/"), i < len(parts)-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkEntry fails unless p is a directory when dir is set, or a regular
+// file otherwise. Unlike fs.Stat it does not follow symlinks.
+func checkEntry(fsys fs.FS, p string, dir bool) error {
+	entries, err := fs.ReadDir(fsys, path.Dir(p))
+	if err != nil {
+		return err
+	}
+	name := path.Base(p)
+	for _, e := range entries {
+		if e.Name() != name {
+			continue
+		}
+		if dir && e.IsDir() || !dir && e.Type().IsRegular() {
+			return nil
+		}
+		break
+	}
+	return fmt.Errorf("invalid template file %q", p)
+}
+
+// reservedFile reports whether the repository path rel is inside a .git
+// directory or is .blog-writer/settings.json. Names are compared without
+// case for case-insensitive file systems.
+func reservedFile(rel string) bool {
+	if strings.EqualFold(rel, ".blog-writer/settings.json") {
+		return true
+	}
+	for _, part := range strings.Split(rel, "/") {
+		if strings.EqualFold(part, ".git") {
+			return true
+		}
+	}
+	return false
+}
+
+// BranchPlaceholder is replaced by the repository's default branch in the
+// files copied from files/, for example in CI workflow triggers.
+const BranchPlaceholder = "{{defaultBranch}}"
+
+// Apply writes the template into the repository at root whose default
+// branch is branch. Starter articles get consecutive epoch-second IDs
+// starting at now; author, when non-empty, replaces the article author and
+// dates are set to now.
+func (t Template) Apply(root string, now time.Time, author, branch string) error {
+	if t.fsys == nil {
+		return errors.New("template not loaded")
+	}
+	if err := t.copyFiles(root, branch); err != nil {
+		return err
+	}
+	for _, s := range t.Subjects {
+		dir := filepath.Join(root, article.BlogDir, filepath.FromSlash(s))
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		if err := fsutil.WriteFileAtomic(filepath.Join(dir, ".gitkeep"), nil, 0o644); err != nil {
+			return err
+		}
+	}
+	if t.IncludeSchema {
+		if err := os.MkdirAll(filepath.Join(root, ".blog-writer"), 0o755); err != nil {
+			return err
+		}
+		if err := fsutil.WriteFileAtomic(filepath.Join(root, ".blog-writer", "article.schema.json"), schema.ArticleSchema(), 0o644); err != nil {
+			return err
+		}
+	}
+	id := now.Unix()
+	for _, a := range t.Articles {
+		if err := t.writeArticle(root, a, id, now, author); err != nil {
+			return err
+		}
+		id++
+	}
+	return nil
+}
+
+// copyFiles copies the files/ tree of the template into root, replacing
+// BranchPlaceholder with branch. Entries other than regular files and
+// directories are refused again in case the tree changed since Load.
+func (t Template) copyFiles(root, branch string) error {
+	if _, err := fs.Stat(t.fsys, "files"); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return fs.WalkDir(t.fsys, "files", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !plainEntry(d) {
+			return fmt.Errorf("invalid template file %q", p)
+		}
+		rel, _ := filepath.Rel("files", filepath.FromSlash(p))
+		dest := filepath.Join(root, rel)
+		if d.IsDir() {
+			return os.MkdirAll(dest, 0o755)
+		}
+		b, err := fs.ReadFile(t.fsys, p)
+		if err != nil {
+			return err
+		}
+		b = bytes.ReplaceAll(b, []byte(BranchPlaceholder), []byte(branch))
+		return fsutil.WriteFileAtomic(dest, b, 0o644)
+	})
+}
+
+// writeArticle stores a starter article as blog/<subject>/<id>.json.
+func (t Template) writeArticle(root string, s StarterArticle, id int64, now time.Time, author string) error {
+	b, err := fs.ReadFile(t.fsys, s.File)
+	if err != nil {
+		return err
+	}
+	a, err := article.Parse(b)
+	if err != nil {
+		return fmt.Errorf("starter article %s: %w", s.File, err)
+	}
+	if author != "" {
+		a.Metadata.Author = author
+	}
+	stamp := now.UTC().Format(time.RFC3339)
+	a.Metadata.PublicationDate = stamp
+	a.Metadata.UpdatedDate = stamp
+	out, err := article.Marshal(a)
+	if err != nil {
+		return err
+	}
+	if err := article.Validate(out); err != nil {
+		return fmt.Errorf("starter article %s: %w", s.File, err)
+	}
+	dir := filepath.Join(root, article.BlogDir, filepath.FromSlash(s.Subject))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return fsutil.WriteFileAtomic(filepath.Join(dir, strconv.FormatInt(id, 10)+".json"), append(out, '\n'), 0o644)
+}
+
+// Info returns display information for the template.
+func (t Template) Info(builtin bool) Info {
+	return Info{Name: t.Name, Description: t.Description, Builtin: builtin}
+}
+
+// validRel reports whether p is a relative slash path that stays inside its root.
+func validRel(p string) bool {
+	return p != "" && fs.ValidPath(p) && p != "."
+}
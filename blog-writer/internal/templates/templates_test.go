@@ -0,0 +1,157 @@
+// Copyright (c) 2025 blog-writer authors
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"blog-writer/internal/schema"
+	"blog-writer/pkg/article"
+)
+
+// TestBuiltin ensures the shipped templates load.
+func TestBuiltin(t *testing.T) {
+	all, err := Builtin()
+	if err != nil {
+		t.Fatalf("Builtin: %v", err)
+	}
+	names := map[string]bool{}
+	for _, tpl := range all {
+		names[tpl.Name] = true
+	}
+	if !names["personal"] || !names["engineering"] {
+		t.Fatalf("expected personal and engineering templates, got %v", names)
+	}
+}
+
+// TestApplyEngineering ensures files, subjects, schema and starter articles are written.
+func TestApplyEngineering(t *testing.T) {
+	tpl, ok := FindBuiltin("engineering")
+	if !ok {
+		t.Fatal("engineering template missing")
+	}
+	root := t.TempDir()
+	now := time.Date(2025, 8, 15, 0, 0, 0, 0, time.UTC)
+	if err := tpl.Apply(root, now, "Ada", "trunk"); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	for _, p := range []string{
+		".gitignore",
+		".gitattributes",
+		filepath.Join(".github", "workflows", "validate-articles.yml"),
+		filepath.Join(".blog-writer", "article.schema.json"),
+		filepath.Join("blog", "architecture", ".gitkeep"),
+	} {
+		if _, err := os.Stat(filepath.Join(root, p)); err != nil {
+			t.Errorf("expected %s: %v", p, err)
+		}
+	}
+	wf, _ := os.ReadFile(filepath.Join(root, ".github", "workflows", "validate-articles.yml"))
+	if !strings.Contains(string(wf), `branches: ["trunk"]`) {
+		t.Fatalf("expected workflow on the default branch, got:\n%s", wf)
+	}
+	paths, err := article.Scan(root)
+	if err != nil || len(paths) != 1 {
+		t.Fatalf("expected one starter article, got %v %v", paths, err)
+	}
+	if want := filepath.Join(root, "blog", "releases", "1755216000.json"); paths[0] != want {
+		t.Fatalf("expected %s, got %s", want, paths[0])
+	}
+	b, _ := os.ReadFile(paths[0])
+	if err := schema.Validate(b); err != nil {
+		t.Fatalf("starter article invalid: %v", err)
+	}
+	a, _ := article.Parse(b)
+	if a.Metadata.Author != "Ada" || a.Metadata.PublicationDate != "2025-08-15T00:00:00Z" {
+		t.Fatalf("unexpected metadata %+v", a.Metadata)
+	}
+}
+
+// TestLoadDirRejectsEscapes ensures manifests cannot write outside the repository.
+func TestLoadDirRejectsEscapes(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, ManifestFile), []byte(`{"name":"bad","subjects":["../x"]}`), 0o644)
+	if _, err := LoadDir(dir); err == nil {
+		t.Fatal("expected error for escaping subject")
+	}
+	_ = os.WriteFile(filepath.Join(dir, ManifestFile), []byte(`{"description":"no name"}`), 0o644)
+	if _, err := LoadDir(dir); err == nil {
+		t.Fatal("expected error for missing name")
+	}
+}
+
+// TestLoadDirRejectsReservedFiles ensures templates cannot ship git internals
+// or replace the settings written from the manifest.
+func TestLoadDirRejectsReservedFiles(t *testing.T) {
+	for _, rel := range []string{".git/config", "sub/.git/HEAD", ".GIT/hooks/pre-commit", ".blog-writer/settings.json"} {
+		dir := t.TempDir()
+		_ = os.WriteFile(filepath.Join(dir, ManifestFile), []byte(`{"name":"bad"}`), 0o644)
+		file := filepath.Join(dir, "files", filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		_ = os.WriteFile(file, []byte("x"), 0o644)
+		if _, err := LoadDir(dir); err == nil {
+			t.Errorf("expected error for %s", rel)
+		}
+	}
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, ManifestFile), []byte(`{"name":"ok"}`), 0o644)
+	_ = os.MkdirAll(filepath.Join(dir, "files", ".github"), 0o755)
+	_ = os.WriteFile(filepath.Join(dir, "files", ".gitignore"), []byte("x"), 0o644)
+	if _, err := LoadDir(dir); err != nil {
+		t.Fatalf("expected other dot files to load: %v", err)
+	}
+}
+
+// TestLoadDirRejectsSymlinks ensures template files and starter articles
+// cannot pull in files from outside the template through symlinks.
+func TestLoadDirRejectsSymlinks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("symlinks need privileges on Windows")
+	}
+	secret := filepath.Join(t.TempDir(), "id_rsa")
+	_ = os.WriteFile(secret, []byte("secret"), 0o600)
+	cases := map[string]func(dir string) error{
+		"file": func(dir string) error {
+			_ = os.MkdirAll(filepath.Join(dir, "files"), 0o755)
+			return os.Symlink(secret, filepath.Join(dir, "files", "key"))
+		},
+		"files dir": func(dir string) error {
+			return os.Symlink(filepath.Dir(secret), filepath.Join(dir, "files"))
+		},
+		"nested dir": func(dir string) error {
+			_ = os.MkdirAll(filepath.Join(dir, "files"), 0o755)
+			return os.Symlink(filepath.Dir(secret), filepath.Join(dir, "files", "ssh"))
+		},
+		"article": func(dir string) error {
+			_ = os.WriteFile(filepath.Join(dir, ManifestFile), []byte(`{"name":"bad","articles":[{"file":"a.json"}]}`), 0o644)
+			return os.Symlink(secret, filepath.Join(dir, "a.json"))
+		},
+	}
+	for name, setup := range cases {
+		dir := t.TempDir()
+		_ = os.WriteFile(filepath.Join(dir, ManifestFile), []byte(`{"name":"bad"}`), 0o644)
+		if err := setup(dir); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if _, err := LoadDir(dir); err == nil {
+			t.Errorf("%s: expected error for symlink", name)
+		}
+	}
+}
+
+// TestLoadDirRejectsInvalidArticles ensures starter articles are validated
+// against the article schema when the template loads.
+func TestLoadDirRejectsInvalidArticles(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, ManifestFile), []byte(`{"name":"bad","articles":[{"file":"a.json"}]}`), 0o644)
+	_ = os.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"version":"1.0.0","document":[]}`), 0o644)
+	if _, err := LoadDir(dir); err == nil {
+		t.Fatal("expected error for invalid starter article")
+	}
+}
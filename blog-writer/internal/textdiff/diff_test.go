@@ -0,0 +1,31 @@
+// Copyright (c) 2025 blog-writer authors
+package textdiff
+
+import "testing"
+
+// TestUnifiedEqual ensures identical inputs produce no diff.
+func TestUnifiedEqual(t *testing.T) {
+	if d := Unified("a", "b", "x\ny\n", "x\ny\n"); d != "" {
+		t.Fatalf("expected empty diff, got %q", d)
+	}
+}
+
+// TestUnifiedChange verifies hunk headers and line markers.
+func TestUnifiedChange(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	b := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n"
+	want := "--- a\n+++ b\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n"
+	if d := Unified("a", "b", a, b); d != want {
+		t.Fatalf("unexpected diff:\n%s\nwant:\n%s", d, want)
+	}
+}
+
+// TestUnifiedSeparateHunks ensures distant changes produce separate hunks.
+func TestUnifiedSeparateHunks(t *testing.T) {
+	a := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+	b := "A\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nL\n"
+	want := "--- x\n+++ y\n@@ -1,4 +1,4 @@\n-a\n+A\n b\n c\n d\n@@ -9,4 +9,4 @@\n i\n j\n k\n-l\n+L\n"
+	if d := Unified("x", "y", a, b); d != want {
+		t.Fatalf("unexpected diff:\n%s\nwant:\n%s", d, want)
+	}
+}
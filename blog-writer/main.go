@@ -2,52 +2,226 @@
 package main
 
 import (
+	"context"
 	"embed"
+	"errors"
+	"os"
 
 	wails "github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 
+	"blog-writer/internal/config"
+	"blog-writer/internal/events"
+	"blog-writer/internal/help"
+	"blog-writer/internal/instance"
+	"blog-writer/internal/logging"
 	"blog-writer/internal/services"
 )
 
 //go:embed all:frontend/dist
 var assets embed.FS
 
+// log reports application-level errors.
+var log = logging.For(logging.App)
+
 func main() {
 	// Create services
 	app := NewApp()
+	if _, err := config.MigrateLegacy(); err != nil {
+		log.Error("migrate legacy config", "err", err)
+	}
+	if logger := initLogging(app.cfgPath); logger != nil {
+		defer logger.Close()
+	}
+
+	// Forward the command line to a running instance, if any.
+	cwd, _ := os.Getwd()
+	msg := instance.Message{Args: os.Args[1:], Dir: cwd}
+	if stateDir, err := config.StateDir(); err == nil {
+		app.inst, err = instance.Start(stateDir, msg)
+		if errors.Is(err, instance.ErrForwarded) {
+			return
+		}
+		if err != nil {
+			log.Error("start single instance listener", "err", err)
+		}
+	}
+	app.pending = instance.Targets(msg)
+
+	// Services publish state changes on the bus; OnStartup forwards them
+	// to the frontend.
+	bus := events.New()
+	bus.Subscribe(string(events.GitBranchChanged), func(events.Event) {
+		app.refreshMenu()
+	})
+	bus.Subscribe(string(events.RepoOpened), func(events.Event) {
+		app.refreshMenu()
+	})
+	bus.Subscribe(string(events.KeybindingsChanged), func(events.Event) {
+		app.rebuildMenu()
+	})
+
 	repoSvc, err := services.NewRepoService()
 	if err != nil {
-		println("Error:", err.Error())
+		log.Error("create repository service", "err", err)
 		return
 	}
+	repoSvc.Events = bus
+	gitSvc := services.NewGitService()
+	gitSvc.Events = bus
 	treeSvc := services.NewTreeService()
 	dirSvc := services.NewDirectoryService()
+	settingsSvc, err := services.NewSettingsService()
+	if err != nil {
+		log.Error("create settings service", "err", err)
+		return
+	}
+	settingsSvc.Events = bus
+	articleSvc := services.NewArticleService()
+	articleSvc.Events = bus
+	schemaSvc := services.NewSchemaService()
+	pluginSvc, err := services.NewPluginService()
+	if err != nil {
+		log.Error("create plugin service", "err", err)
+		return
+	}
+	pluginSvc.Articles = articleSvc
+	app.repos, app.git = repoSvc, gitSvc
+	uiSvc, err := services.NewUIStateService()
+	if err != nil {
+		log.Error("create UI state service", "err", err)
+		return
+	}
+	keySvc, err := services.NewKeybindingService()
+	if err != nil {
+		log.Error("create keybinding service", "err", err)
+		return
+	}
+	keySvc.Events = bus
+	diagSvc, err := services.NewDiagnosticsService()
+	if err != nil {
+		log.Error("create diagnostics service", "err", err)
+		return
+	}
+	logSvc, err := services.NewLogService()
+	if err != nil {
+		log.Error("create log service", "err", err)
+		return
+	}
+	workspaceSvc, err := services.NewWorkspaceService()
+	if err != nil {
+		log.Error("create workspace service", "err", err)
+		return
+	}
+	workspaceSvc.Events = bus
+
+	// The local API, when enabled, starts with the window so that service
+	// callbacks have a context to emit events on.
+	var apiSrv *apiServer
 
 	// Create application menu.
 	appMenu := newAppMenu(app)
 
+	// Restore the remembered window size.
+	width, height, startState := app.windowOptions()
+
 	// Create application with options.
 	err = wails.Run(&options.App{
-		Title:  "Blog-Writer",
-		Width:  1024,
-		Height: 768,
+		Title:            "Blog-Writer",
+		Width:            width,
+		Height:           height,
+		MinWidth:         config.MinWindowWidth,
+		MinHeight:        config.MinWindowHeight,
+		WindowStartState: startState,
 		AssetServer: &assetserver.Options{
-			Assets: assets,
+			Assets:  assets,
+			Handler: help.Handler(),
 		},
 		Menu:             appMenu,
 		BackgroundColour: &options.RGBA{R: 27, G: 38, B: 54, A: 1},
-		OnStartup:        app.startup,
+		OnStartup: func(ctx context.Context) {
+			app.startup(ctx)
+			logging.Default().Subscribe(func(e logging.Entry) {
+				runtime.EventsEmit(ctx, EventLogEntry, e)
+			})
+			bus.Subscribe("*", func(e events.Event) {
+				runtime.EventsEmit(ctx, events.WailsEvent, e)
+			})
+			apiSrv = startAPI(app.cfgPath, map[string]any{
+				"Repo":    repoSvc,
+				"Tree":    treeSvc,
+				"Article": articleSvc,
+				"Git":     gitSvc,
+				"Schema":  schemaSvc,
+			})
+		},
+		OnBeforeClose:  app.beforeClose,
+		ErrorFormatter: formatError,
+		OnShutdown: func(ctx context.Context) {
+			apiSrv.Close()
+			pluginSvc.Close()
+			workspaceSvc.Close()
+			repoSvc.Close()
+			services.ReleaseRepoLocks()
+			if app.inst != nil {
+				if err := app.inst.Close(); err != nil {
+					log.Error("close single instance listener", "err", err)
+				}
+			}
+		},
 		Bind: []interface{}{
 			app,
 			repoSvc,
 			treeSvc,
 			dirSvc,
+			settingsSvc,
+			articleSvc,
+			schemaSvc,
+			gitSvc,
+			workspaceSvc,
+			uiSvc,
+			keySvc,
+			diagSvc,
+			logSvc,
+			pluginSvc,
 		},
 	})
 
 	if err != nil {
-		println("Error:", err.Error())
+		log.Error("run application", "err", err)
+	}
+}
+
+// formatError serializes errors returned by bound methods and API calls as
+// {code, message, details, retryable} so that clients can branch on the
+// code instead of parsing the message.
+func formatError(err error) any {
+	return services.AsError(err)
+}
+
+// initLogging opens the application log in config.LogDir using the levels
+// from the user config at cfgPath. It returns nil when the log cannot be
+// opened, in which case logs go to stderr.
+func initLogging(cfgPath string) *logging.Logger {
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		log.Error("load config", "err", err)
+	}
+	dir, err := config.LogDir()
+	if err != nil {
+		log.Error("locate log directory", "err", err)
+		return nil
+	}
+	logger, err := logging.Init(dir, logging.Options{
+		Level:    cfg.Logging.Level,
+		Levels:   cfg.Logging.Levels,
+		MaxSize:  int64(cfg.Logging.MaxSizeMB) << 20,
+		MaxFiles: cfg.Logging.MaxFiles,
+	})
+	if err != nil {
+		log.Error("configure logging", "err", err)
 	}
+	return logger
 }
@@ -0,0 +1,34 @@
+// Copyright (c) 2025 Asymmetric Effort, LLC. <scaldwell@asymmetric-effort.com>
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"blog-writer/internal/services"
+)
+
+// TestFormatError ensures errors from bound methods reach the frontend as
+// coded errors rather than plain strings.
+func TestFormatError(t *testing.T) {
+	err := services.NewSchemaService().Validate("{}")
+	if err == nil {
+		t.Fatal("expected a validation error")
+	}
+	b, err := json.Marshal(formatError(err))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"code", "message", "details", "retryable"} {
+		if _, ok := got[key]; !ok {
+			t.Fatalf("missing %q in %s", key, b)
+		}
+	}
+	if got["code"] != string(services.CodeValidationFailed) {
+		t.Fatalf("unexpected code in %s", b)
+	}
+}
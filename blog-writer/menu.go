@@ -1,15 +1,279 @@
 // Copyright (c) 2025 Asymmetric Effort, LLC. <scaldwell@asymmetric-effort.com>
 package main
 
-import "github.com/wailsapp/wails/v2/pkg/menu"
+import (
+	"path/filepath"
+	goruntime "runtime"
+	"sync"
 
-// newAppMenu constructs the application menu including a Help submenu with
-// entries for About, documentation, and bug reporting.
+	"github.com/wailsapp/wails/v2/pkg/menu"
+	"github.com/wailsapp/wails/v2/pkg/menu/keys"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"blog-writer/internal/config"
+	"blog-writer/internal/keymap"
+)
+
+// Events emitted to the frontend by menu items that the UI handles itself.
+const (
+	EventNewArticle  = "menu:new-article"
+	EventOpenRepo    = "menu:open-repo"
+	EventSave        = "menu:save"
+	EventSaveAll     = "menu:save-all"
+	EventFind        = "menu:find"
+	EventReplace     = "menu:replace"
+	EventCommit      = "menu:commit"
+	EventTogglePanel = "menu:toggle-panel"
+	EventRepoChanged = "repo:changed"
+	EventShowLogs    = "menu:show-logs"
+	EventLogEntry    = "log:entry"
+	EventAbout       = "help:about"
+	EventHelpOpen    = "help:open"
+	EventReportBug   = "help:report-bug"
+)
+
+// viewPanel is a panel that can be shown or hidden from the View menu.
+type viewPanel struct {
+	ID     string
+	Label  string
+	Action string
+}
+
+// viewPanels lists the toggleable panels in menu order.
+var viewPanels = []viewPanel{
+	{ID: "sidebar", Label: "Sidebar", Action: "view.sidebar"},
+	{ID: "preview", Label: "JSON Preview", Action: "view.preview"},
+	{ID: "git", Label: "Git Panel", Action: "view.git"},
+	{ID: "diagnostics", Label: "Diagnostics", Action: "view.diagnostics"},
+}
+
+// PanelToggle is the payload of EventTogglePanel.
+type PanelToggle struct {
+	Panel   string `json:"panel"`
+	Visible bool   `json:"visible"`
+}
+
+// menuState keeps the menu items whose content or state depends on the
+// open repository.
+type menuState struct {
+	mu        sync.Mutex
+	root      *menu.Menu
+	recent    *menu.Menu
+	branches  *menu.Menu
+	repoItems []*menu.MenuItem
+	panels    map[string]*menu.MenuItem
+}
+
+// newAppMenu constructs the application menu with File, Edit, Git, View and
+// Help submenus. Accelerators come from the keymap with the user's
+// keybindings applied. Items needing an open repository are disabled until
+// one is opened; the recent and branch submenus are filled by refreshMenu.
 func newAppMenu(app *App) *menu.Menu {
+	cfg, _ := config.Load(app.cfgPath)
+	km := keymap.Resolve(cfg.Keybindings)
+	accel := func(action string) *keys.Accelerator {
+		return km.Accelerator(action, goruntime.GOOS)
+	}
+
+	s := &app.menu
+	s.mu.Lock()
+	s.root, s.repoItems = nil, nil
+	s.mu.Unlock()
 	appMenu := menu.NewMenu()
+
+	fileMenu := appMenu.AddSubmenu("File")
+	s.repoItem(fileMenu.AddText("New Article", accel("file.newArticle"), app.emitMenu(EventNewArticle)))
+	fileMenu.AddText("Open Repository…", accel("file.openRepo"), app.emitMenu(EventOpenRepo))
+	s.recent = fileMenu.AddSubmenu("Open Recent")
+	fileMenu.AddSeparator()
+	s.repoItem(fileMenu.AddText("Save", accel("file.save"), app.emitMenu(EventSave)))
+	s.repoItem(fileMenu.AddText("Save All", accel("file.saveAll"), app.emitMenu(EventSaveAll)))
+
+	editMenu := appMenu.AddSubmenu("Edit")
+	s.repoItem(editMenu.AddText("Find", accel("edit.find"), app.emitMenu(EventFind)))
+	s.repoItem(editMenu.AddText("Replace in Repository…", accel("edit.replace"), app.emitMenu(EventReplace)))
+
+	gitMenu := appMenu.AddSubmenu("Git")
+	s.repoItem(gitMenu.AddText("Pull", accel("git.pull"), app.gitAction("pull")))
+	s.repoItem(gitMenu.AddText("Push", accel("git.push"), app.gitAction("push")))
+	s.repoItem(gitMenu.AddText("Commit…", accel("git.commit"), app.emitMenu(EventCommit)))
+	gitMenu.AddSeparator()
+	s.branches = gitMenu.AddSubmenu("Switch Branch")
+
+	viewMenu := appMenu.AddSubmenu("View")
+	s.panels = map[string]*menu.MenuItem{}
+	for _, p := range viewPanels {
+		s.panels[p.ID] = viewMenu.AddCheckbox(p.Label, cfg.UI.PanelVisible(p.ID), accel(p.Action), app.togglePanel(p.ID))
+	}
+	viewMenu.AddSeparator()
+	viewMenu.AddText("Logs…", nil, app.emitMenu(EventShowLogs))
+
 	helpMenu := appMenu.AddSubmenu("Help")
 	helpMenu.AddText("About", nil, app.ShowAbout)
 	helpMenu.AddText("Read the docs", nil, app.ShowDocs)
 	helpMenu.AddText("Report a bug", nil, app.ReportBug)
+
+	s.root = appMenu
+	app.refreshMenu()
 	return appMenu
 }
+
+// rebuildMenu replaces the application menu, e.g. after the keybindings
+// changed.
+func (a *App) rebuildMenu() {
+	m := newAppMenu(a)
+	if a.ctx != nil {
+		runtime.MenuSetApplicationMenu(a.ctx, m)
+	}
+}
+
+// repoItem registers an item that is only enabled while a repository is open.
+func (s *menuState) repoItem(item *menu.MenuItem) {
+	s.repoItems = append(s.repoItems, item)
+}
+
+// RefreshMenu updates the recent repositories, branches and enabled states
+// of the menu. The frontend calls it after changing repository state.
+func (a *App) RefreshMenu() {
+	a.refreshMenu()
+}
+
+// refreshMenu rebuilds the repository dependent parts of the menu and
+// pushes the result to the window.
+func (a *App) refreshMenu() {
+	s := &a.menu
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.root == nil {
+		return
+	}
+	repo := a.currentRepo()
+	for _, item := range s.repoItems {
+		item.Disabled = repo == ""
+	}
+
+	s.recent.Items = nil
+	var recent []string
+	if a.repos != nil {
+		recent, _ = a.repos.Recent()
+	}
+	for _, path := range recent {
+		s.recent.AddRadio(filepath.Base(path), path == repo, nil, a.openRecent(path))
+	}
+	if len(recent) == 0 {
+		s.recent.AddText("No Recent Repositories", nil, nil).Disabled = true
+	}
+
+	s.branches.Items = nil
+	if repo != "" && a.git != nil {
+		branches, _ := a.git.Branches(repo)
+		for _, b := range branches {
+			s.branches.AddRadio(b.Name, b.Current, nil, a.checkout(b.Name))
+		}
+	}
+	if len(s.branches.Items) == 0 {
+		s.branches.AddText("No Branches", nil, nil).Disabled = true
+	}
+
+	if a.ctx != nil {
+		runtime.MenuUpdateApplicationMenu(a.ctx)
+	}
+}
+
+// currentRepo returns the repository the menu acts on.
+func (a *App) currentRepo() string {
+	if a.repos == nil {
+		return ""
+	}
+	return a.repos.Current()
+}
+
+// emitMenu returns a callback emitting event with the current repository.
+func (a *App) emitMenu(event string) menu.Callback {
+	return func(*menu.CallbackData) {
+		runtime.EventsEmit(a.ctx, event, a.currentRepo())
+	}
+}
+
+// openRecent returns a callback opening the repository at path.
+func (a *App) openRecent(path string) menu.Callback {
+	return func(*menu.CallbackData) {
+		if err := a.repos.Open(path); err != nil {
+			a.showError("Open Repository", err)
+			a.refreshMenu()
+		}
+	}
+}
+
+// gitAction returns a callback running a git operation on the current
+// repository in the background.
+func (a *App) gitAction(op string) menu.Callback {
+	return func(*menu.CallbackData) {
+		repo := a.currentRepo()
+		if repo == "" || a.git == nil {
+			return
+		}
+		go func() {
+			var err error
+			switch op {
+			case "pull":
+				err = a.git.Pull(repo)
+			case "push":
+				err = a.git.Push(repo)
+			}
+			a.afterGit("Git "+op, repo, err)
+		}()
+	}
+}
+
+// checkout returns a callback switching the current repository to branch.
+func (a *App) checkout(branch string) menu.Callback {
+	return func(*menu.CallbackData) {
+		repo := a.currentRepo()
+		if repo == "" || a.git == nil {
+			return
+		}
+		go func() {
+			a.afterGit("Switch Branch", repo, a.git.Checkout(repo, branch))
+		}()
+	}
+}
+
+// afterGit reports the outcome of a menu git operation and refreshes the menu.
+func (a *App) afterGit(title, repo string, err error) {
+	if err != nil {
+		a.showError(title, err)
+	} else {
+		runtime.EventsEmit(a.ctx, EventRepoChanged, repo)
+	}
+	a.refreshMenu()
+}
+
+// togglePanel returns a callback that stores and announces the visibility
+// of the panel named id.
+func (a *App) togglePanel(id string) menu.Callback {
+	return func(data *menu.CallbackData) {
+		visible := data.MenuItem.Checked
+		err := config.Update(a.cfgPath, func(cfg *config.Config) error {
+			if cfg.UI.Panels == nil {
+				cfg.UI.Panels = map[string]bool{}
+			}
+			cfg.UI.Panels[id] = visible
+			return nil
+		})
+		if err != nil {
+			log.Error("save panel state", "panel", id, "err", err)
+		}
+		runtime.EventsEmit(a.ctx, EventTogglePanel, PanelToggle{Panel: id, Visible: visible})
+	}
+}
+
+// showError logs err and displays it in an error dialog.
+func (a *App) showError(title string, err error) {
+	log.Error(title, "err", err)
+	runtime.MessageDialog(a.ctx, runtime.MessageDialogOptions{
+		Type:    runtime.ErrorDialog,
+		Title:   title,
+		Message: err.Error(),
+	})
+}
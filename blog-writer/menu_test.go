@@ -1,9 +1,16 @@
 package main
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
 
 	"github.com/wailsapp/wails/v2/pkg/menu"
+
+	"blog-writer/internal/config"
+	"blog-writer/internal/events"
+	"blog-writer/internal/services"
 )
 
 // TestNewAppMenuHelpItems ensures the help menu contains all expected entries.
@@ -37,3 +44,127 @@ func TestNewAppMenuHelpItems(t *testing.T) {
 		}
 	}
 }
+
+// submenu returns the submenu labelled label or fails the test.
+func submenu(t *testing.T, m *menu.Menu, label string) *menu.Menu {
+	t.Helper()
+	for _, item := range m.Items {
+		if item.Label == label && item.Type == menu.SubmenuType {
+			return item.SubMenu
+		}
+	}
+	t.Fatalf("submenu %q not found", label)
+	return nil
+}
+
+// item returns the item labelled label or fails the test.
+func item(t *testing.T, m *menu.Menu, label string) *menu.MenuItem {
+	t.Helper()
+	for _, it := range m.Items {
+		if it.Label == label {
+			return it
+		}
+	}
+	t.Fatalf("item %q not found", label)
+	return nil
+}
+
+// TestNewAppMenuLayout ensures the top-level menus, accelerators and
+// panel checkboxes are present.
+func TestNewAppMenuLayout(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yml")
+	if err := config.Save(cfgPath, config.Config{UI: config.UIState{Panels: map[string]bool{"preview": false}}}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	app := &App{cfgPath: cfgPath}
+	m := newAppMenu(app)
+
+	var labels []string
+	for _, it := range m.Items {
+		labels = append(labels, it.Label)
+	}
+	want := []string{"File", "Edit", "Git", "View", "Help"}
+	if len(labels) != len(want) {
+		t.Fatalf("expected menus %v, got %v", want, labels)
+	}
+	for i := range want {
+		if labels[i] != want[i] {
+			t.Fatalf("expected menus %v, got %v", want, labels)
+		}
+	}
+
+	file := submenu(t, m, "File")
+	if save := item(t, file, "Save"); save.Accelerator == nil || save.Accelerator.Key != "s" || !save.Disabled {
+		t.Fatalf("unexpected Save item: %+v", save)
+	}
+	if item(t, file, "Open Repository…").Disabled {
+		t.Fatal("open repository should always be enabled")
+	}
+	if recent := submenu(t, file, "Open Recent"); len(recent.Items) != 1 || !recent.Items[0].Disabled {
+		t.Fatalf("expected disabled placeholder, got %+v", recent.Items)
+	}
+
+	view := submenu(t, m, "View")
+	if !item(t, view, "Sidebar").Checked || item(t, view, "JSON Preview").Checked {
+		t.Fatal("panel checkboxes do not reflect the stored UI state")
+	}
+	if item(t, view, "Logs…").Click == nil {
+		t.Fatal("logs item missing click handler")
+	}
+}
+
+// TestRefreshMenuWithRepo ensures opening a repository enables repository
+// items and lists recent repositories and branches.
+func TestRefreshMenuWithRepo(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	cfgPath := filepath.Join(t.TempDir(), "config.yml")
+	repo := t.TempDir()
+	cmd := exec.Command("git", "init", "-b", "main", repo)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v %s", err, out)
+	}
+	app := &App{cfgPath: cfgPath, repos: services.NewRepoServiceWithPath(cfgPath), git: services.NewGitService()}
+	m := newAppMenu(app)
+	app.repos.Events = events.New()
+	app.repos.Events.Subscribe(string(events.RepoOpened), func(events.Event) { app.refreshMenu() })
+	if err := app.repos.Open(repo); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer app.repos.Close()
+
+	if item(t, submenu(t, m, "Git"), "Push").Disabled {
+		t.Fatal("push should be enabled with an open repository")
+	}
+	recent := submenu(t, submenu(t, m, "File"), "Open Recent")
+	if len(recent.Items) != 1 || recent.Items[0].Label != filepath.Base(repo) || !recent.Items[0].Checked {
+		t.Fatalf("unexpected recent items: %+v", recent.Items)
+	}
+	// A fresh repository has no commits and therefore no branches yet.
+	branches := submenu(t, submenu(t, m, "Git"), "Switch Branch")
+	if len(branches.Items) != 1 || !branches.Items[0].Disabled {
+		t.Fatalf("unexpected branch items: %+v", branches.Items)
+	}
+	if _, err := os.Stat(filepath.Join(repo, ".blog-writer")); err != nil {
+		t.Fatalf("layout missing: %v", err)
+	}
+}
+
+// TestNewAppMenuKeybindings ensures user keybindings replace the default
+// accelerators and unbound actions have none.
+func TestNewAppMenuKeybindings(t *testing.T) {
+	cfgPath := filepath.Join(t.TempDir(), "config.yml")
+	cfg := config.Config{Keybindings: map[string]string{"file.save": "Ctrl+Shift+W", "git.commit": ""}}
+	if err := config.Save(cfgPath, cfg); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	m := newAppMenu(&App{cfgPath: cfgPath})
+	save := item(t, submenu(t, m, "File"), "Save")
+	if save.Accelerator == nil || save.Accelerator.Key != "w" || len(save.Accelerator.Modifiers) != 2 {
+		t.Fatalf("unexpected Save accelerator %+v", save.Accelerator)
+	}
+	if commit := item(t, submenu(t, m, "Git"), "Commit…"); commit.Accelerator != nil {
+		t.Fatalf("expected unbound commit, got %+v", commit.Accelerator)
+	}
+}
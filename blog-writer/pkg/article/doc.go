@@ -0,0 +1,34 @@
+// Copyright (c) 2025 blog-writer authors
+
+// Package article is the public Go SDK for the Blog-Writer article format.
+// It provides the typed model defined by article.schema.json, schema
+// validation, tree walking, repository scanning and envelope version
+// migration, so tools such as static site builders can read and write
+// articles exactly like the app does.
+//
+// A typical consumer scans a repository, parses each file and walks its
+// document tree:
+//
+//	paths, err := article.Scan(repo)
+//	for _, p := range paths {
+//		data, _ := os.ReadFile(p)
+//		if err := article.Validate(data); err != nil {
+//			continue
+//		}
+//		a, _ := article.Parse(data)
+//		article.Walk(a.Document, func(n *article.Node, depth int) bool {
+//			return true
+//		})
+//	}
+//
+// # Compatibility
+//
+// The package follows semantic versioning through APIVersion. Within a
+// major version, exported identifiers are only added, never removed or
+// changed incompatibly. A breaking change ships as a new import path,
+// blog-writer/pkg/article/v2, while this package keeps working. The article
+// envelope itself is versioned separately by CurrentVersion.
+package article
+
+// APIVersion is the version of this package's Go API.
+const APIVersion = "1.0.0"
@@ -0,0 +1,77 @@
+// Copyright (c) 2025 blog-writer authors
+package article_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"blog-writer/pkg/article"
+)
+
+// exampleJSON is a minimal valid article.
+const exampleJSON = `{
+  "version": "1.0.0",
+  "metadata": {
+    "title": "Hello",
+    "author": "Ada",
+    "description": "A first post",
+    "publicationDate": "2025-01-01T00:00:00Z",
+    "updatedDate": "2025-01-01T00:00:00Z",
+    "keywords": ["intro"]
+  },
+  "document": [
+    {"tag": "h1", "content": "Hello"},
+    {"tag": "p", "content": [{"tag": "span", "content": "Welcome to "}, {"tag": "strong", "content": "my blog"}]}
+  ]
+}`
+
+func ExampleParse() {
+	a, err := article.Parse([]byte(exampleJSON))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(a.Metadata.Title, len(a.Document))
+	// Output: Hello 2
+}
+
+func ExampleValidate() {
+	fmt.Println(article.Validate([]byte(exampleJSON)) == nil)
+	fmt.Println(article.Validate([]byte(`{"version": "1.0.0"}`)) == nil)
+	// Output:
+	// true
+	// false
+}
+
+func ExampleWalk() {
+	a, _ := article.Parse([]byte(exampleJSON))
+	article.Walk(a.Document, func(n *article.Node, depth int) bool {
+		text := ""
+		if n.Content.IsText() {
+			text = fmt.Sprintf(" %q", *n.Content.Text)
+		}
+		fmt.Printf("%s%s%s\n", strings.Repeat("  ", depth), n.Tag, text)
+		return true
+	})
+	// Output:
+	// h1 "Hello"
+	// p
+	//   span "Welcome to "
+	//   strong "my blog"
+}
+
+func ExampleScan() {
+	repo, _ := os.MkdirTemp("", "blog")
+	defer os.RemoveAll(repo)
+	dir := filepath.Join(repo, article.BlogDir, "2025")
+	_ = os.MkdirAll(dir, 0o755)
+	_ = os.WriteFile(filepath.Join(dir, "1735689600.json"), []byte(exampleJSON), 0o644)
+	_ = os.WriteFile(filepath.Join(dir, "notes.txt"), nil, 0o644)
+
+	paths, _ := article.Scan(repo)
+	for _, p := range paths {
+		fmt.Println(article.ID(p))
+	}
+	// Output: 1735689600
+}
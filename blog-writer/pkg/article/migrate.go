@@ -0,0 +1,148 @@
+// Copyright (c) 2025 blog-writer authors
+package article
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CurrentVersion is the envelope version written by this build.
+const CurrentVersion = "1.0.0"
+
+// ErrVersionTooNew indicates an article was written by a newer, unknown schema version.
+var ErrVersionTooNew = errors.New("article was written by a newer version of blog-writer")
+
+// Migration upgrades an article from one envelope version to the next.
+type Migration struct {
+	From        string
+	To          string
+	Description string
+	Apply       func(a *Article) error
+}
+
+// Registry holds migrations keyed by the version they upgrade from.
+type Registry struct {
+	steps map[string]Migration
+}
+
+// NewRegistry constructs an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{steps: map[string]Migration{}}
+}
+
+// Migrations is the registry used by Migrate. Register a step here and bump
+// CurrentVersion whenever the article format changes.
+var Migrations = NewRegistry()
+
+// Register adds m to the registry. Each version may have only one step.
+func (r *Registry) Register(m Migration) error {
+	if _, err := parseVersion(m.From); err != nil {
+		return err
+	}
+	if _, err := parseVersion(m.To); err != nil {
+		return err
+	}
+	if compareVersions(m.From, m.To) >= 0 {
+		return fmt.Errorf("migration %s -> %s does not increase the version", m.From, m.To)
+	}
+	if _, ok := r.steps[m.From]; ok {
+		return fmt.Errorf("duplicate migration from %s", m.From)
+	}
+	r.steps[m.From] = m
+	return nil
+}
+
+// Migrate upgrades a to target in place and returns the steps applied.
+// Articles newer than target are rejected with ErrVersionTooNew.
+func (r *Registry) Migrate(a *Article, target string) ([]Migration, error) {
+	if _, err := parseVersion(a.Version); err != nil {
+		return nil, err
+	}
+	if compareVersions(a.Version, target) > 0 {
+		return nil, fmt.Errorf("%w: version %s, supported %s", ErrVersionTooNew, a.Version, target)
+	}
+	var applied []Migration
+	for compareVersions(a.Version, target) < 0 {
+		step, ok := r.steps[a.Version]
+		if !ok {
+			return applied, fmt.Errorf("no article migration from version %s", a.Version)
+		}
+		if compareVersions(step.To, target) > 0 {
+			return applied, fmt.Errorf("migration %s -> %s overshoots target %s", step.From, step.To, target)
+		}
+		if err := step.Apply(a); err != nil {
+			return applied, fmt.Errorf("migrate article from version %s: %w", step.From, err)
+		}
+		a.Version = step.To
+		applied = append(applied, step)
+	}
+	return applied, nil
+}
+
+// Migrate upgrades a to CurrentVersion using the default registry.
+func Migrate(a *Article) ([]Migration, error) {
+	return Migrations.Migrate(a, CurrentVersion)
+}
+
+// parseVersion splits a MAJOR.MINOR.PATCH version into its numeric parts.
+func parseVersion(v string) ([3]int, error) {
+	var out [3]int
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return out, fmt.Errorf("invalid version %q", v)
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return out, fmt.Errorf("invalid version %q", v)
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+// compareVersions returns -1, 0 or 1 as a is older than, equal to or newer than b.
+// Invalid versions compare as 0.0.0.
+func compareVersions(a, b string) int {
+	va, _ := parseVersion(a)
+	vb, _ := parseVersion(b)
+	for i := range va {
+		switch {
+		case va[i] < vb[i]:
+			return -1
+		case va[i] > vb[i]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// MigrateJSON parses data, upgrades it to CurrentVersion and returns the
+// re-encoded article with the applied steps. When no step applies the
+// original bytes are returned unchanged.
+func MigrateJSON(data []byte) ([]byte, []Migration, error) {
+	return Migrations.MigrateJSON(data, CurrentVersion)
+}
+
+// MigrateJSON is like the package-level MigrateJSON but uses the steps in r
+// to upgrade to target.
+func (r *Registry) MigrateJSON(data []byte, target string) ([]byte, []Migration, error) {
+	a, err := Parse(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	applied, err := r.Migrate(&a, target)
+	if err != nil {
+		return nil, applied, err
+	}
+	if len(applied) == 0 {
+		return data, nil, nil
+	}
+	out, err := Marshal(a)
+	if err != nil {
+		return nil, applied, err
+	}
+	return append(out, '\n'), applied, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025 blog-writer authors
+package article
+
+import (
+	"errors"
+	"testing"
+)
+
+// testRegistry returns a registry that renames b to strong in 1.0.0 -> 1.1.0
+// and wraps the document in a section in 1.1.0 -> 2.0.0.
+func testRegistry(t *testing.T) *Registry {
+	t.Helper()
+	r := NewRegistry()
+	steps := []Migration{
+		{From: "1.1.0", To: "2.0.0", Apply: func(a *Article) error {
+			a.Document = []Node{{Tag: "section", Content: Children(a.Document...)}}
+			return nil
+		}},
+		{From: "1.0.0", To: "1.1.0", Apply: func(a *Article) error {
+			Walk(a.Document, func(n *Node, _ int) bool {
+				if n.Tag == "b" {
+					n.Tag = "strong"
+				}
+				return true
+			})
+			return nil
+		}},
+	}
+	for _, s := range steps {
+		if err := r.Register(s); err != nil {
+			t.Fatalf("Register: %v", err)
+		}
+	}
+	return r
+}
+
+// TestRegistryMigrate ensures steps are chained in version order.
+func TestRegistryMigrate(t *testing.T) {
+	a := Article{Version: "1.0.0", Document: []Node{{Tag: "p", Content: Children(Node{Tag: "b", Content: Text("x")})}}}
+	applied, err := testRegistry(t).Migrate(&a, "2.0.0")
+	if err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if len(applied) != 2 || a.Version != "2.0.0" {
+		t.Fatalf("unexpected result: %d steps, version %s", len(applied), a.Version)
+	}
+	if a.Document[0].Tag != "section" || a.Document[0].Content.Nodes[0].Content.Nodes[0].Tag != "strong" {
+		t.Fatalf("unexpected document: %+v", a.Document)
+	}
+}
+
+// TestRegistryErrors covers newer versions, gaps and invalid registrations.
+func TestRegistryErrors(t *testing.T) {
+	r := testRegistry(t)
+	a := Article{Version: "3.0.0"}
+	if _, err := r.Migrate(&a, "2.0.0"); !errors.Is(err, ErrVersionTooNew) {
+		t.Fatalf("expected ErrVersionTooNew, got %v", err)
+	}
+	a = Article{Version: "0.9.0"}
+	if _, err := r.Migrate(&a, "2.0.0"); err == nil {
+		t.Fatal("expected error for missing step")
+	}
+	a = Article{Version: "one"}
+	if _, err := r.Migrate(&a, "2.0.0"); err == nil {
+		t.Fatal("expected error for invalid version")
+	}
+	if err := r.Register(Migration{From: "1.0.0", To: "1.2.0"}); err == nil {
+		t.Fatal("expected duplicate registration error")
+	}
+	if err := r.Register(Migration{From: "2.0.0", To: "1.0.0"}); err == nil {
+		t.Fatal("expected non-increasing registration error")
+	}
+}
+
+// TestMigrateJSONCurrent ensures current articles are returned untouched.
+func TestMigrateJSONCurrent(t *testing.T) {
+	out, applied, err := MigrateJSON([]byte(sample))
+	if err != nil {
+		t.Fatalf("MigrateJSON: %v", err)
+	}
+	if len(applied) != 0 || string(out) != sample {
+		t.Fatalf("expected unchanged article")
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2025 blog-writer authors
+package article
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// Article is the file envelope stored in blog/**/<id>.json.
+type Article struct {
+	Version  string   `json:"version"`
+	Metadata Metadata `json:"metadata"`
+	Document []Node   `json:"document"`
+}
+
+// Metadata describes an article.
+type Metadata struct {
+	Title           string   `json:"title"`
+	Author          string   `json:"author"`
+	Description     string   `json:"description"`
+	PublicationDate string   `json:"publicationDate"`
+	UpdatedDate     string   `json:"updatedDate"`
+	Keywords        []string `json:"keywords"`
+}
+
+// Node is a single element of the document tree. Content holds either a
+// string or child nodes; the remaining fields are tag specific.
+type Node struct {
+	Tag      string   `json:"tag"`
+	Content  *Content `json:"content,omitempty"`
+	Mode     string   `json:"mode,omitempty"`
+	Numbered *bool    `json:"numbered,omitempty"`
+	Label    string   `json:"label,omitempty"`
+	Alt      string   `json:"alt,omitempty"`
+	URL      string   `json:"url,omitempty"`
+	Lang     string   `json:"lang,omitempty"`
+	Start    *int     `json:"start,omitempty"`
+	Datetime string   `json:"datetime,omitempty"`
+}
+
+// Content is the polymorphic content of a node: text when Text is set,
+// otherwise the child Nodes.
+type Content struct {
+	Text  *string
+	Nodes []Node
+}
+
+// Text returns content holding the string s.
+func Text(s string) *Content {
+	return &Content{Text: &s}
+}
+
+// Children returns content holding the given child nodes.
+func Children(nodes ...Node) *Content {
+	if nodes == nil {
+		nodes = []Node{}
+	}
+	return &Content{Nodes: nodes}
+}
+
+// IsText reports whether the content is a string.
+func (c *Content) IsText() bool {
+	return c != nil && c.Text != nil
+}
+
+// MarshalJSON encodes the content as a string or an array of nodes.
+func (c Content) MarshalJSON() ([]byte, error) {
+	if c.Text != nil {
+		return json.Marshal(*c.Text)
+	}
+	if c.Nodes == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(c.Nodes)
+}
+
+// UnmarshalJSON decodes a string or an array of nodes.
+func (c *Content) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	switch {
+	case len(b) > 0 && b[0] == '"':
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		c.Text, c.Nodes = &s, nil
+		return nil
+	case len(b) > 0 && b[0] == '[':
+		nodes := []Node{}
+		if err := json.Unmarshal(b, &nodes); err != nil {
+			return err
+		}
+		c.Text, c.Nodes = nil, nodes
+		return nil
+	}
+	return errors.New("content must be a string or an array of nodes")
+}
+
+// Parse decodes an article from JSON.
+func Parse(data []byte) (Article, error) {
+	var a Article
+	if err := json.Unmarshal(data, &a); err != nil {
+		return Article{}, err
+	}
+	return a, nil
+}
+
+// Marshal encodes an article as indented JSON.
+func Marshal(a Article) ([]byte, error) {
+	if a.Metadata.Keywords == nil {
+		a.Metadata.Keywords = []string{}
+	}
+	if a.Document == nil {
+		a.Document = []Node{}
+	}
+	return json.MarshalIndent(a, "", "  ")
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025 blog-writer authors
+package article
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+const sample = `{
+  "version": "1.0.0",
+  "metadata": {
+    "title": "Sample",
+    "author": "Author",
+    "description": "Desc",
+    "publicationDate": "2025-08-15T00:00:00Z",
+    "updatedDate": "2025-08-15T00:00:00Z",
+    "keywords": ["intro"]
+  },
+  "document": [
+    {"tag": "p", "content": [
+      {"tag": "span", "content": "Einstein "},
+      {"tag": "math", "mode": "inline", "content": "E=mc^2"},
+      {"tag": "br"}
+    ]},
+    {"tag": "math", "mode": "display", "content": "x", "numbered": true, "label": "eq:x"},
+    {"tag": "ol", "start": 3, "content": [{"tag": "li", "content": []}]},
+    {"tag": "pre", "lang": "go", "content": "package main"},
+    {"tag": "footer", "content": [{"tag": "time", "datetime": "2025-08-15T00:00:00Z", "content": "Updated"}]}
+  ]
+}`
+
+// TestParseMarshalRoundTrip ensures the typed model preserves the document structure.
+func TestParseMarshalRoundTrip(t *testing.T) {
+	a, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	out, err := Marshal(a)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if err := Validate(out); err != nil {
+		t.Fatalf("marshalled article invalid: %v", err)
+	}
+	var want, got interface{}
+	_ = json.Unmarshal([]byte(sample), &want)
+	_ = json.Unmarshal(out, &got)
+	wb, _ := json.Marshal(want)
+	gb, _ := json.Marshal(got)
+	if !bytes.Equal(wb, gb) {
+		t.Fatalf("round trip mismatch\nwant %s\ngot  %s", wb, gb)
+	}
+}
+
+// TestContentInvalid ensures non string/array content is rejected.
+func TestContentInvalid(t *testing.T) {
+	if _, err := Parse([]byte(`{"version":"1.0.0","document":[{"tag":"p","content":5}]}`)); err == nil {
+		t.Fatal("expected error for numeric content")
+	}
+}
+
+// TestWalk ensures nodes are visited depth first and may be modified.
+func TestWalk(t *testing.T) {
+	a, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	var tags []string
+	Walk(a.Document, func(n *Node, depth int) bool {
+		tags = append(tags, n.Tag)
+		if n.Tag == "span" {
+			n.Content = Text("changed")
+		}
+		return n.Tag != "footer"
+	})
+	want := []string{"p", "span", "math", "br", "math", "ol", "li", "pre", "footer"}
+	if len(tags) != len(want) {
+		t.Fatalf("expected %v, got %v", want, tags)
+	}
+	for i := range want {
+		if tags[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, tags)
+		}
+	}
+	if got := *a.Document[0].Content.Nodes[0].Content.Text; got != "changed" {
+		t.Fatalf("expected in-place modification, got %q", got)
+	}
+}
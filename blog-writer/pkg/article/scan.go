@@ -0,0 +1,68 @@
+// Copyright (c) 2025 blog-writer authors
+package article
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BlogDir is the directory, relative to the repository root, holding articles.
+const BlogDir = "blog"
+
+// IsArticleFile reports whether name follows the <epoch>.json naming rule.
+func IsArticleFile(name string) bool {
+	id, ok := strings.CutSuffix(name, ".json")
+	if !ok || id == "" {
+		return false
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// ID returns the article ID encoded in path.
+func ID(path string) string {
+	return strings.TrimSuffix(filepath.Base(path), ".json")
+}
+
+// Scan returns the paths of all blog/**/<id>.json files under the repository
+// root, in lexical order. A missing blog directory yields no paths.
+func Scan(root string) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(filepath.Join(root, BlogDir), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && IsArticleFile(d.Name()) {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return paths, err
+}
+
+// Find returns the path of the article with the given ID under root.
+func Find(root, id string) (string, error) {
+	if !IsArticleFile(id + ".json") {
+		return "", errors.New("invalid article id")
+	}
+	paths, err := Scan(root)
+	if err != nil {
+		return "", err
+	}
+	for _, p := range paths {
+		if ID(p) == id {
+			return p, nil
+		}
+	}
+	return "", os.ErrNotExist
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2025 blog-writer authors
+package article
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestScanAndFind ensures only blog/**/<id>.json files are discovered.
+func TestScanAndFind(t *testing.T) {
+	root := t.TempDir()
+	files := []string{
+		filepath.Join("blog", "1755288225.json"),
+		filepath.Join("blog", "subject", "1755288227.json"),
+		filepath.Join("blog", "notes.json"),
+		filepath.Join("blog", "1755288228.txt"),
+		filepath.Join("other", "1755288229.json"),
+	}
+	for _, f := range files {
+		p := filepath.Join(root, f)
+		_ = os.MkdirAll(filepath.Dir(p), 0o755)
+		_ = os.WriteFile(p, []byte("{}"), 0o644)
+	}
+	paths, err := Scan(root)
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 articles, got %v", paths)
+	}
+	p, err := Find(root, "1755288227")
+	if err != nil || p != filepath.Join(root, files[1]) {
+		t.Fatalf("Find: %s %v", p, err)
+	}
+	if _, err := Find(root, "42"); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected not exist, got %v", err)
+	}
+	if _, err := Find(root, "../x"); err == nil {
+		t.Fatal("expected invalid id error")
+	}
+	if paths, err := Scan(t.TempDir()); err != nil || len(paths) != 0 {
+		t.Fatalf("expected empty scan, got %v %v", paths, err)
+	}
+}
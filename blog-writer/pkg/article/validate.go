@@ -0,0 +1,66 @@
+// Copyright (c) 2025 blog-writer authors
+package article
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// schemaJSON is the article schema, maintained in this package.
+//
+//go:embed article.schema.json
+var schemaJSON []byte
+
+// schemaURL identifies the embedded schema to the compiler.
+const schemaURL = "article.schema.json"
+
+var (
+	compileOnce sync.Once
+	compiled    *jsonschema.Schema
+	compileErr  error
+)
+
+// Schema returns the article JSON schema.
+func Schema() []byte {
+	return bytes.Clone(schemaJSON)
+}
+
+// compiledSchema compiles the embedded schema once.
+func compiledSchema() (*jsonschema.Schema, error) {
+	compileOnce.Do(func() {
+		c := jsonschema.NewCompiler()
+		if compileErr = c.AddResource(schemaURL, bytes.NewReader(schemaJSON)); compileErr != nil {
+			return
+		}
+		compiled, compileErr = c.Compile(schemaURL)
+	})
+	return compiled, compileErr
+}
+
+// Validate checks the JSON document data against the article schema. The
+// returned error describes every violation.
+func Validate(data []byte) error {
+	s, err := compiledSchema()
+	if err != nil {
+		return err
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	return s.Validate(v)
+}
+
+// ValidateArticle checks a against the article schema as it would be
+// written by Marshal.
+func ValidateArticle(a Article) error {
+	b, err := Marshal(a)
+	if err != nil {
+		return err
+	}
+	return Validate(b)
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2025 blog-writer authors
+package article
+
+import "testing"
+
+// TestValidate covers valid and invalid documents.
+func TestValidate(t *testing.T) {
+	if err := Validate([]byte(sample)); err != nil {
+		t.Fatalf("expected sample to be valid: %v", err)
+	}
+	for _, data := range []string{`{`, `{"version":"1.0.0"}`, `[]`} {
+		if err := Validate([]byte(data)); err == nil {
+			t.Fatalf("expected %q to be invalid", data)
+		}
+	}
+}
+
+// TestValidateArticle ensures typed articles are validated as written.
+func TestValidateArticle(t *testing.T) {
+	a, err := Parse([]byte(sample))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if err := ValidateArticle(a); err != nil {
+		t.Fatalf("expected valid article: %v", err)
+	}
+	a.Document = append(a.Document, Node{Tag: "marquee", Content: Text("x")})
+	if err := ValidateArticle(a); err == nil {
+		t.Fatal("expected unknown tag to be rejected")
+	}
+}
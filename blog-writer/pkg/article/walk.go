@@ -0,0 +1,24 @@
+// Copyright (c) 2025 blog-writer authors
+package article
+
+// WalkFunc is called for every node visited by Walk. The node may be
+// modified in place. Returning false skips the node's children.
+type WalkFunc func(n *Node, depth int) bool
+
+// Walk visits nodes depth first in document order.
+func Walk(nodes []Node, fn WalkFunc) {
+	walk(nodes, 0, fn)
+}
+
+// walk visits nodes at the given depth.
+func walk(nodes []Node, depth int, fn WalkFunc) {
+	for i := range nodes {
+		n := &nodes[i]
+		if !fn(n, depth) {
+			continue
+		}
+		if n.Content != nil && !n.Content.IsText() {
+			walk(n.Content.Nodes, depth+1, fn)
+		}
+	}
+}
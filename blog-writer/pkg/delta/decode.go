@@ -0,0 +1,421 @@
+// Copyright (c) 2025 blog-writer authors
+package delta
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"blog-writer/pkg/article"
+)
+
+// ToNodes converts a document Delta to a node tree. See the package
+// documentation for the mapping and its lossy cases.
+func ToNodes(d Delta) ([]article.Node, error) {
+	lines, err := splitLines(d.Ops)
+	if err != nil {
+		return nil, err
+	}
+	dec := &decoder{}
+	for _, l := range lines {
+		dec.line(l)
+	}
+	return build(dec.root.children), nil
+}
+
+// splitLines groups ops into lines. Text after the last newline forms a
+// final line without attributes.
+func splitLines(ops []Op) ([]line, error) {
+	var lines []line
+	var cur []run
+	for i, op := range ops {
+		if op.Retain != 0 || op.Delete != 0 {
+			return nil, fmt.Errorf("op %d: %w", i, ErrNotDocument)
+		}
+		switch v := op.Insert.(type) {
+		case string:
+			for j, part := range strings.Split(v, "\n") {
+				if j > 0 {
+					lines = append(lines, line{runs: cur, attrs: op.Attributes})
+					cur = nil
+				}
+				if part != "" {
+					cur = append(cur, run{text: part, attrs: op.Attributes})
+				}
+			}
+		case map[string]any:
+			cur = append(cur, run{embed: v, attrs: op.Attributes})
+		default:
+			return nil, fmt.Errorf("op %d: %w: insert of type %T", i, ErrNotDocument, op.Insert)
+		}
+	}
+	if len(cur) > 0 {
+		lines = append(lines, line{runs: cur})
+	}
+	return lines, nil
+}
+
+// Kinds of lines.
+const (
+	kindParagraph  = "p"
+	kindHeader     = "header"
+	kindFigcaption = "figcaption"
+	kindPre        = "pre"
+	kindList       = "list"
+	kindTable      = "table"
+	kindRule       = "hr"
+	kindBare       = "bare"
+)
+
+// lineKind classifies l by its block attributes.
+func lineKind(l line) string {
+	_, header := intAttr(l.attrs[attrHeader])
+	switch {
+	case len(l.runs) == 1 && l.runs[0].embed != nil && l.runs[0].embed[embedDivider] != nil:
+		return kindRule
+	case l.attrs[attrList] != nil:
+		return kindList
+	case l.attrs[attrTable] != nil:
+		return kindTable
+	case l.attrs[attrCodeBlock] != nil && l.attrs[attrCodeBlock] != false:
+		return kindPre
+	case header:
+		return kindHeader
+	case l.attrs[attrFigcaption] == true:
+		return kindFigcaption
+	case l.attrs[attrBare] == true:
+		return kindBare
+	}
+	return kindParagraph
+}
+
+// bnode is a node under construction. Its content is the inline nodes
+// built from runs followed by children. An inline bnode stands for the
+// inline nodes of its runs.
+type bnode struct {
+	node     article.Node
+	runs     []run
+	children []*bnode
+	inline   bool
+	// sealed marks an empty container; later lines open a sibling.
+	sealed bool
+}
+
+// decoder rebuilds the tree line by line.
+type decoder struct {
+	root bnode
+	// stack holds the open containers, outermost first.
+	stack []*bnode
+	// path and kind describe the previous line; blocks spanning lines
+	// continue only while they match.
+	path string
+	kind string
+
+	lists   []*bnode
+	pre     *bnode
+	preLang any
+	table   *bnode
+	row     *bnode
+	rowID   any
+}
+
+// line adds l to the tree.
+func (d *decoder) line(l line) {
+	var path []string
+	if s, ok := l.attrs[attrContainer].(string); ok {
+		for _, tag := range strings.Split(s, "/") {
+			if containers[tag] {
+				path = append(path, tag)
+			}
+		}
+	}
+	kind := lineKind(l)
+	if l.attrs[attrBlockquote] == true {
+		path = append(path, "blockquote")
+		if kind == kindParagraph {
+			kind = kindBare
+		}
+	}
+	key := strings.Join(path, "/")
+	// A container's inline content between blocks is a single bare line,
+	// and an empty bare line is all an empty container has, so other bare
+	// lines belong to a sibling container. Lines after an empty container
+	// do too; enter skips sealed containers.
+	split := kind == kindBare && len(path) > 0 &&
+		(d.kind == kindBare && key == d.path || len(l.runs) == 0 && d.filled(path))
+	if key != d.path || split {
+		d.kind = ""
+	}
+	parent := d.enter(path, split)
+	switch kind {
+	case kindRule:
+		parent.children = append(parent.children, &bnode{node: article.Node{Tag: "hr"}})
+	case kindList:
+		d.listItem(parent, l)
+	case kindTable:
+		d.cell(parent, l)
+	case kindPre:
+		if d.kind == kindPre && reflect.DeepEqual(d.preLang, l.attrs[attrCodeBlock]) {
+			d.pre.runs = append(d.pre.runs, run{text: "\n"})
+			d.pre.runs = append(d.pre.runs, l.runs...)
+			break
+		}
+		d.pre = &bnode{node: article.Node{Tag: "pre"}, runs: l.runs}
+		d.preLang = l.attrs[attrCodeBlock]
+		if lang, ok := d.preLang.(string); ok {
+			d.pre.node.Lang = lang
+		}
+		parent.children = append(parent.children, d.pre)
+	case kindHeader:
+		level, _ := intAttr(l.attrs[attrHeader])
+		level = min(max(level, 1), 5)
+		parent.children = append(parent.children, &bnode{node: article.Node{Tag: fmt.Sprintf("h%d", level)}, runs: l.runs})
+	case kindFigcaption:
+		parent.children = append(parent.children, &bnode{node: article.Node{Tag: "figcaption"}, runs: l.runs})
+	case kindBare:
+		parent.children = append(parent.children, &bnode{runs: l.runs, inline: true})
+		parent.sealed = len(l.runs) == 0 && len(path) > 0
+	default:
+		parent.children = append(parent.children, &bnode{node: article.Node{Tag: "p"}, runs: l.runs})
+	}
+	d.path, d.kind = key, kind
+}
+
+// enter makes path the open containers and returns the innermost one, or
+// the root. With split the innermost container is closed and reopened.
+func (d *decoder) enter(path []string, split bool) *bnode {
+	keep := 0
+	for keep < len(d.stack) && keep < len(path) && d.stack[keep].node.Tag == path[keep] && !d.stack[keep].sealed {
+		keep++
+	}
+	if split && keep == len(path) {
+		keep--
+	}
+	d.stack = d.stack[:keep]
+	for _, tag := range path[keep:] {
+		c := &bnode{node: article.Node{Tag: tag}}
+		parent := d.top()
+		parent.children = append(parent.children, c)
+		d.stack = append(d.stack, c)
+	}
+	return d.top()
+}
+
+// filled reports whether path names the open containers and the innermost
+// one has content.
+func (d *decoder) filled(path []string) bool {
+	if len(d.stack) < len(path) {
+		return false
+	}
+	for i, tag := range path {
+		if d.stack[i].node.Tag != tag {
+			return false
+		}
+	}
+	return len(d.stack[len(path)-1].children) > 0
+}
+
+// top returns the innermost open container, or the root.
+func (d *decoder) top() *bnode {
+	if len(d.stack) == 0 {
+		return &d.root
+	}
+	return d.stack[len(d.stack)-1]
+}
+
+// listItem adds a list line as an item of the open list at its indent,
+// opening lists as needed. A type change or a start opens a new list.
+func (d *decoder) listItem(parent *bnode, l line) {
+	tag := "ul"
+	if l.attrs[attrList] == "ordered" {
+		tag = "ol"
+	}
+	indent, _ := intAttr(l.attrs[attrIndent])
+	indent = max(indent, 0)
+	start, hasStart := intAttr(l.attrs[attrStart])
+	hasStart = hasStart && start >= 1
+	if d.kind != kindList {
+		d.lists = nil
+	}
+	d.lists = d.lists[:min(len(d.lists), indent+1)]
+	if len(d.lists) == indent+1 && (d.lists[indent].node.Tag != tag || hasStart) {
+		d.lists = d.lists[:indent]
+	}
+	for len(d.lists) <= indent {
+		list := &bnode{node: article.Node{Tag: tag}}
+		if len(d.lists) == indent && hasStart {
+			list.node.Start = &start
+		}
+		if len(d.lists) == 0 {
+			parent.children = append(parent.children, list)
+		} else {
+			outer := d.lists[len(d.lists)-1]
+			if len(outer.children) == 0 {
+				outer.children = append(outer.children, &bnode{node: article.Node{Tag: "li"}})
+			}
+			item := outer.children[len(outer.children)-1]
+			item.children = append(item.children, list)
+		}
+		d.lists = append(d.lists, list)
+	}
+	list := d.lists[indent]
+	list.children = append(list.children, &bnode{node: article.Node{Tag: "li"}, runs: l.runs})
+}
+
+// cell adds a table line as a cell of its row, starting a row when the
+// row ID changes.
+func (d *decoder) cell(parent *bnode, l line) {
+	id := l.attrs[attrTable]
+	if d.kind != kindTable {
+		d.table = &bnode{node: article.Node{Tag: "table"}}
+		parent.children = append(parent.children, d.table)
+		d.row = nil
+	}
+	if d.row == nil || !reflect.DeepEqual(id, d.rowID) {
+		d.row = &bnode{node: article.Node{Tag: "tr"}}
+		d.table.children = append(d.table.children, d.row)
+		d.rowID = id
+	}
+	tag := "td"
+	if l.attrs[attrCell] == "th" {
+		tag = "th"
+	}
+	d.row.children = append(d.row.children, &bnode{node: article.Node{Tag: tag}, runs: l.runs})
+}
+
+// build converts bnodes to nodes.
+func build(bs []*bnode) []article.Node {
+	var out []article.Node
+	for _, b := range bs {
+		if b.inline {
+			out = append(out, inlineNodes(b.runs)...)
+			continue
+		}
+		n := b.node
+		n.Content = content(append(inlineNodes(b.runs), build(b.children)...))
+		out = append(out, n)
+	}
+	return out
+}
+
+// content returns nodes as node content: nothing when empty and a string
+// for a single unformatted text.
+func content(nodes []article.Node) *article.Content {
+	switch {
+	case len(nodes) == 0:
+		return nil
+	case len(nodes) == 1 && reflect.DeepEqual(nodes[0], article.Node{Tag: "span", Content: nodes[0].Content}) && nodes[0].Content.IsText():
+		return nodes[0].Content
+	}
+	return article.Children(nodes...)
+}
+
+// item is an inline run with its formatting as nested elements.
+type item struct {
+	chain []article.Node
+	text  string
+	embed *article.Node
+}
+
+// inlineNodes rebuilds the inline elements of runs, nesting formatting in
+// the canonical order and sharing wrappers between adjacent runs.
+func inlineNodes(runs []run) []article.Node {
+	var items []item
+	for _, r := range runs {
+		it := item{chain: chain(r.attrs), text: r.text}
+		if r.embed != nil {
+			n, ok := embedNode(r.embed, r.attrs)
+			if !ok {
+				continue
+			}
+			it.embed = &n
+		}
+		if last := len(items) - 1; last >= 0 && it.embed == nil && items[last].embed == nil && reflect.DeepEqual(items[last].chain, it.chain) {
+			items[last].text += it.text
+			continue
+		}
+		items = append(items, it)
+	}
+	return group(items, 0)
+}
+
+// group nests items from chain depth on, merging equal wrappers of
+// adjacent items.
+func group(items []item, depth int) []article.Node {
+	var out []article.Node
+	for i := 0; i < len(items); {
+		it := items[i]
+		if len(it.chain) <= depth {
+			if it.embed != nil {
+				out = append(out, *it.embed)
+			} else {
+				out = append(out, article.Node{Tag: "span", Content: article.Text(it.text)})
+			}
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(items) && len(items[j].chain) > depth && reflect.DeepEqual(items[j].chain[depth], it.chain[depth]) {
+			j++
+		}
+		n := it.chain[depth]
+		n.Content = content(group(items[i:j], depth+1))
+		out = append(out, n)
+		i = j
+	}
+	return out
+}
+
+// chain returns the inline elements set by attrs, outermost first.
+func chain(attrs map[string]any) []article.Node {
+	var c []article.Node
+	if lang, ok := attrs[attrLang].(string); ok && lang != "" {
+		c = append(c, article.Node{Tag: "span", Lang: lang})
+	}
+	if v := attrs[attrTime]; v != nil && v != false {
+		dt, _ := v.(string)
+		c = append(c, article.Node{Tag: "time", Datetime: dt})
+	}
+	for _, f := range []struct{ attr, tag string }{
+		{attrMark, "mark"}, {attrSmall, "small"}, {attrBold, "strong"}, {attrB, "b"},
+		{attrItalic, "em"}, {attrI, "i"}, {attrUnderline, "u"}, {attrStrike, "s"},
+	} {
+		if attrs[f.attr] == true {
+			c = append(c, article.Node{Tag: f.tag})
+		}
+	}
+	switch attrs[attrScript] {
+	case "sub":
+		c = append(c, article.Node{Tag: "sub"})
+	case "super":
+		c = append(c, article.Node{Tag: "sup"})
+	}
+	if attrs[attrCode] == true {
+		c = append(c, article.Node{Tag: "code"})
+	}
+	return c
+}
+
+// embedNode converts a supported embed to its node.
+func embedNode(embed, attrs map[string]any) (article.Node, bool) {
+	switch {
+	case embed[embedBreak] != nil:
+		return article.Node{Tag: "br"}, true
+	case embed[embedImage] != nil:
+		url, _ := embed[embedImage].(string)
+		alt, _ := attrs[attrAlt].(string)
+		return article.Node{Tag: "img", URL: url, Alt: alt}, true
+	case embed[embedFormula] != nil:
+		tex, _ := embed[embedFormula].(string)
+		n := article.Node{Tag: "math", Mode: "inline", Content: article.Text(tex)}
+		if attrs[attrMode] == "display" {
+			n.Mode = "display"
+		}
+		if numbered, ok := attrs[attrNumbered].(bool); ok {
+			n.Numbered = &numbered
+		}
+		n.Label, _ = attrs[attrLabel].(string)
+		return n, true
+	}
+	return article.Node{}, false
+}
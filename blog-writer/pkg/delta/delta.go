@@ -0,0 +1,166 @@
+// Copyright (c) 2025 blog-writer authors
+
+// Package delta converts between Quill Delta documents, the editor's
+// format, and the tag/content node tree of blog-writer/pkg/article, the
+// canonical storage format.
+//
+// A document Delta is a list of insert operations. Text and embeds carry
+// inline attributes, and every line ends with a "\n" insert carrying the
+// line's block attributes. The mapping uses Quill's built-in formats where
+// they exist and custom ones, which the editor registers, elsewhere.
+//
+// Inline elements become attributes of the text they wrap:
+//
+//	strong  bold: true         em    italic: true
+//	u       underline: true    s     strike: true
+//	code    code: true         sub   script: "sub"
+//	sup     script: "super"    b     b: true
+//	i       i: true            mark  mark: true
+//	small   small: true        span  lang: <lang> (only when set)
+//	time    time: <datetime>, or true without one
+//
+// br, img and math are embeds: {"br": true}, {"image": <url>} with an
+// "alt" attribute, and {"formula": <tex>} with "mode": "display",
+// "numbered" and "label" attributes when set.
+//
+// Block elements become line attributes:
+//
+//	p           no attribute
+//	h1-h5       header: 1-5
+//	pre         code-block: <lang>, or true; one line per text line
+//	ol, ul, li  list: "ordered" or "bullet", indent: <nesting>, and
+//	            start: <n> on the first line of an ol with a start
+//	table, tr   table: "row-<n>", unique in the document
+//	th, td      cell: "th" for th cells
+//	figcaption  figcaption: true
+//	hr          a line holding only the embed {"divider": true}
+//
+// blockquote, header, footer, main, section, article, aside, nav and figure
+// are containers. Every line inside them carries container: the path of
+// enclosing containers, outermost first, such as "article/section". Inline
+// content placed directly in a container, or at the top level, is written
+// as a line with bare: true instead of a paragraph.
+//
+// # Lossy cases
+//
+// Converting nodes to a Delta and back yields a normalized tree. Deltas
+// produced by FromNodes convert back to nodes and again to the same Delta,
+// and normalized trees round-trip unchanged. The normalization:
+//
+//   - Inline elements are rebuilt in a fixed nesting order: span, time,
+//     mark, small, strong, b, em, i, u, s, sub or sup, code. Spans without
+//     lang and repeated elements are dropped. When sub and sup, or several
+//     lang or time values, are nested, the innermost wins.
+//   - Content holding a single unformatted text becomes a string, and
+//     empty content is omitted.
+//   - Outside pre, a newline in text becomes a br.
+//   - Adjacent siblings merge: text with the same formatting, pre blocks
+//     with the same lang, containers with the same tag, tables, and lists
+//     of the same type unless the second is an ol with a start.
+//   - Block elements inside a paragraph, heading, list item, cell, caption
+//     or pre are unwrapped. Their text is kept, separated by br, and hr is
+//     dropped. The exceptions are lists nested in a list item, which come
+//     after the item's text even if they came first.
+//   - Misplaced elements are repaired. A li outside a list is wrapped in a
+//     ul, and list children other than li become items. A tr, th or td
+//     outside a table is wrapped in one. Table children other than tr
+//     become rows of one cell, and row children other than th become td.
+//     Elements with tags outside the schema are unwrapped.
+//   - Lists, tables and rows without items or cells are dropped.
+//   - Properties are kept only where listed above. Content of br, hr and
+//     img is dropped.
+//
+// Deltas written by Quill itself are read with these additional rules:
+// blockquote: true makes the line a blockquote, header levels above 5
+// become h5, check lists become ul, and other attributes and embeds, such
+// as link, color, align or video, are dropped.
+package delta
+
+import "errors"
+
+// ErrNotDocument is returned by ToNodes for a Delta containing retain or
+// delete operations or inserts that are neither text nor an embed.
+var ErrNotDocument = errors.New("delta is not a document")
+
+// Delta is a Quill Delta, serialized as {"ops": [...]}.
+type Delta struct {
+	Ops []Op `json:"ops"`
+}
+
+// Op is a single Delta operation. Insert is a string or an embed such as
+// map[string]any{"image": url}.
+type Op struct {
+	Insert     any            `json:"insert,omitempty"`
+	Retain     int            `json:"retain,omitempty"`
+	Delete     int            `json:"delete,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// Attribute and embed names of the mapping.
+const (
+	attrBold      = "bold"
+	attrItalic    = "italic"
+	attrUnderline = "underline"
+	attrStrike    = "strike"
+	attrCode      = "code"
+	attrScript    = "script"
+	attrB         = "b"
+	attrI         = "i"
+	attrMark      = "mark"
+	attrSmall     = "small"
+	attrLang      = "lang"
+	attrTime      = "time"
+	attrAlt       = "alt"
+	attrMode      = "mode"
+	attrNumbered  = "numbered"
+	attrLabel     = "label"
+
+	attrHeader     = "header"
+	attrCodeBlock  = "code-block"
+	attrList       = "list"
+	attrIndent     = "indent"
+	attrStart      = "start"
+	attrTable      = "table"
+	attrCell       = "cell"
+	attrFigcaption = "figcaption"
+	attrContainer  = "container"
+	attrBare       = "bare"
+	attrBlockquote = "blockquote"
+
+	embedBreak   = "br"
+	embedImage   = "image"
+	embedFormula = "formula"
+	embedDivider = "divider"
+)
+
+// containers are the tags encoded in the container line attribute.
+var containers = map[string]bool{
+	"blockquote": true, "header": true, "footer": true, "main": true, "section": true,
+	"article": true, "aside": true, "nav": true, "figure": true,
+}
+
+// run is an inline segment of a line: text, or an embed when embed is set.
+type run struct {
+	text  string
+	embed map[string]any
+	attrs map[string]any
+}
+
+// line is a line of a Delta: its inline runs and block attributes.
+type line struct {
+	runs  []run
+	attrs map[string]any
+}
+
+// intAttr returns v as an integer. JSON decoding yields float64.
+func intAttr(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), n == float64(int(n))
+	}
+	return 0, false
+}
@@ -0,0 +1,189 @@
+// Copyright (c) 2025 blog-writer authors
+package delta
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"blog-writer/pkg/article"
+)
+
+// parseNodes decodes a JSON node array.
+func parseNodes(t *testing.T, s string) []article.Node {
+	t.Helper()
+	var nodes []article.Node
+	if err := json.Unmarshal([]byte(s), &nodes); err != nil {
+		t.Fatalf("parse nodes: %v", err)
+	}
+	return nodes
+}
+
+// compactJSON marshals v without whitespace, or compacts a JSON string.
+func compactJSON(t *testing.T, v any) string {
+	t.Helper()
+	b, ok := v.([]byte)
+	if s, isString := v.(string); isString {
+		b, ok = []byte(s), true
+	}
+	if !ok {
+		var err error
+		if b, err = json.Marshal(v); err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, b); err != nil {
+		t.Fatalf("compact: %v", err)
+	}
+	return buf.String()
+}
+
+// TestMapping covers every tag of the schema with the exact Delta it maps
+// to and back.
+func TestMapping(t *testing.T) {
+	cases := []struct {
+		name, nodes, delta string
+	}{
+		{"paragraph", `[{"tag":"p","content":"Hello"}]`, `{"ops":[{"insert":"Hello\n"}]}`},
+		{"headings",
+			`[{"tag":"h1","content":"A"},{"tag":"h2","content":"B"},{"tag":"h3","content":"C"},{"tag":"h4","content":"D"},{"tag":"h5","content":"E"}]`,
+			`{"ops":[{"insert":"A"},{"insert":"\n","attributes":{"header":1}},{"insert":"B"},{"insert":"\n","attributes":{"header":2}},{"insert":"C"},{"insert":"\n","attributes":{"header":3}},{"insert":"D"},{"insert":"\n","attributes":{"header":4}},{"insert":"E"},{"insert":"\n","attributes":{"header":5}}]}`},
+		{"inline formats",
+			`[{"tag":"p","content":[{"tag":"strong","content":"b"},{"tag":"em","content":"i"},{"tag":"u","content":"u"},{"tag":"s","content":"s"},{"tag":"code","content":"c"},{"tag":"sub","content":"1"},{"tag":"sup","content":"2"},{"tag":"b","content":"B"},{"tag":"i","content":"I"},{"tag":"mark","content":"m"},{"tag":"small","content":"x"}]}]`,
+			`{"ops":[{"insert":"b","attributes":{"bold":true}},{"insert":"i","attributes":{"italic":true}},{"insert":"u","attributes":{"underline":true}},{"insert":"s","attributes":{"strike":true}},{"insert":"c","attributes":{"code":true}},{"insert":"1","attributes":{"script":"sub"}},{"insert":"2","attributes":{"script":"super"}},{"insert":"B","attributes":{"b":true}},{"insert":"I","attributes":{"i":true}},{"insert":"m","attributes":{"mark":true}},{"insert":"x","attributes":{"small":true}},{"insert":"\n"}]}`},
+		{"nested formats",
+			`[{"tag":"p","content":[{"tag":"span","content":"a "},{"tag":"strong","content":[{"tag":"span","content":"b"},{"tag":"em","content":"c"}]}]}]`,
+			`{"ops":[{"insert":"a "},{"insert":"b","attributes":{"bold":true}},{"insert":"c","attributes":{"bold":true,"italic":true}},{"insert":"\n"}]}`},
+		{"span and time",
+			`[{"tag":"p","content":[{"tag":"span","lang":"fr","content":"oui"},{"tag":"time","datetime":"2025-01-01","content":"today"},{"tag":"time","content":"now"}]}]`,
+			`{"ops":[{"insert":"oui","attributes":{"lang":"fr"}},{"insert":"today","attributes":{"time":"2025-01-01"}},{"insert":"now","attributes":{"time":true}},{"insert":"\n"}]}`},
+		{"embeds",
+			`[{"tag":"p","content":[{"tag":"span","content":"a"},{"tag":"br"},{"tag":"img","alt":"dot","url":"data:image/svg+xml;base64,PHN2Zy8+"},{"tag":"math","mode":"inline","content":"x^2"},{"tag":"math","mode":"display","numbered":true,"label":"eq1","content":"y"}]}]`,
+			`{"ops":[{"insert":"a"},{"insert":{"br":true}},{"insert":{"image":"data:image/svg+xml;base64,PHN2Zy8+"},"attributes":{"alt":"dot"}},{"insert":{"formula":"x^2"}},{"insert":{"formula":"y"},"attributes":{"label":"eq1","mode":"display","numbered":true}},{"insert":"\n"}]}`},
+		{"pre",
+			`[{"tag":"pre","lang":"go","content":"a\n\nb"},{"tag":"pre","content":"c"}]`,
+			`{"ops":[{"insert":"a"},{"insert":"\n\n","attributes":{"code-block":"go"}},{"insert":"b"},{"insert":"\n","attributes":{"code-block":"go"}},{"insert":"c"},{"insert":"\n","attributes":{"code-block":true}}]}`},
+		{"lists",
+			`[{"tag":"ul","content":[{"tag":"li","content":[{"tag":"span","content":"a"},{"tag":"ol","start":3,"content":[{"tag":"li","content":"b"}]}]},{"tag":"li","content":"c"}]}]`,
+			`{"ops":[{"insert":"a"},{"insert":"\n","attributes":{"list":"bullet"}},{"insert":"b"},{"insert":"\n","attributes":{"indent":1,"list":"ordered","start":3}},{"insert":"c"},{"insert":"\n","attributes":{"list":"bullet"}}]}`},
+		{"table",
+			`[{"tag":"table","content":[{"tag":"tr","content":[{"tag":"th","content":"h"}]},{"tag":"tr","content":[{"tag":"td","content":"d"},{"tag":"td"}]}]}]`,
+			`{"ops":[{"insert":"h"},{"insert":"\n","attributes":{"cell":"th","table":"row-1"}},{"insert":"d"},{"insert":"\n\n","attributes":{"table":"row-2"}}]}`},
+		{"containers",
+			`[{"tag":"article","content":[{"tag":"header","content":[{"tag":"h1","content":"T"}]},{"tag":"main","content":[{"tag":"section","content":[{"tag":"p","content":"x"}]},{"tag":"aside","content":"note"}]},{"tag":"nav"},{"tag":"footer","content":[{"tag":"hr"}]}]},{"tag":"blockquote","content":"q"}]`,
+			`{"ops":[{"insert":"T"},{"insert":"\n","attributes":{"container":"article/header","header":1}},{"insert":"x"},{"insert":"\n","attributes":{"container":"article/main/section"}},{"insert":"note"},{"insert":"\n","attributes":{"bare":true,"container":"article/main/aside"}},{"insert":"\n","attributes":{"bare":true,"container":"article/nav"}},{"insert":{"divider":true}},{"insert":"\n","attributes":{"container":"article/footer"}},{"insert":"q"},{"insert":"\n","attributes":{"bare":true,"container":"blockquote"}}]}`},
+		{"figure",
+			`[{"tag":"figure","content":[{"tag":"img","url":"data:image/svg+xml;base64,PHN2Zy8+"},{"tag":"figcaption","content":"cap"}]}]`,
+			`{"ops":[{"insert":{"image":"data:image/svg+xml;base64,PHN2Zy8+"}},{"insert":"\n","attributes":{"bare":true,"container":"figure"}},{"insert":"cap"},{"insert":"\n","attributes":{"container":"figure","figcaption":true}}]}`},
+		{"sibling containers",
+			`[{"tag":"section","content":"a"},{"tag":"section","content":"b"},{"tag":"hr"}]`,
+			`{"ops":[{"insert":"a"},{"insert":"\n","attributes":{"bare":true,"container":"section"}},{"insert":"b"},{"insert":"\n","attributes":{"bare":true,"container":"section"}},{"insert":{"divider":true}},{"insert":"\n"}]}`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			nodes := parseNodes(t, c.nodes)
+			d := FromNodes(nodes)
+			if got := compactJSON(t, d); got != compactJSON(t, c.delta) {
+				t.Fatalf("FromNodes:\n got %s\nwant %s", got, compactJSON(t, c.delta))
+			}
+			var decoded Delta
+			if err := json.Unmarshal([]byte(c.delta), &decoded); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			back, err := ToNodes(decoded)
+			if err != nil {
+				t.Fatalf("ToNodes: %v", err)
+			}
+			if got, want := compactJSON(t, back), compactJSON(t, nodes); got != want {
+				t.Fatalf("ToNodes:\n got %s\nwant %s", got, want)
+			}
+		})
+	}
+}
+
+// TestNormalization covers the documented lossy cases.
+func TestNormalization(t *testing.T) {
+	cases := []struct {
+		name, in, want string
+	}{
+		{"span content", `[{"tag":"p","content":[{"tag":"span","content":"a"}]}]`, `[{"tag":"p","content":"a"}]`},
+		{"empty content", `[{"tag":"p","content":""},{"tag":"h1","content":[]}]`, `[{"tag":"p"},{"tag":"h1"}]`},
+		{"nesting order", `[{"tag":"p","content":[{"tag":"em","content":[{"tag":"strong","content":"x"}]}]}]`,
+			`[{"tag":"p","content":[{"tag":"strong","content":[{"tag":"em","content":"x"}]}]}]`},
+		{"redundant wrappers", `[{"tag":"p","content":[{"tag":"strong","content":[{"tag":"span","content":[{"tag":"strong","content":"x"}]}]}]}]`,
+			`[{"tag":"p","content":[{"tag":"strong","content":"x"}]}]`},
+		{"innermost script", `[{"tag":"p","content":[{"tag":"sub","content":[{"tag":"sup","content":"x"}]}]}]`,
+			`[{"tag":"p","content":[{"tag":"sup","content":"x"}]}]`},
+		{"newline", `[{"tag":"p","content":"a\nb"}]`, `[{"tag":"p","content":[{"tag":"span","content":"a"},{"tag":"br"},{"tag":"span","content":"b"}]}]`},
+		{"merged text", `[{"tag":"p","content":[{"tag":"span","content":"a"},{"tag":"span","content":"b"}]}]`, `[{"tag":"p","content":"ab"}]`},
+		{"merged pre", `[{"tag":"pre","content":"a"},{"tag":"pre","content":"b"}]`, `[{"tag":"pre","content":"a\nb"}]`},
+		{"merged containers", `[{"tag":"section","content":[{"tag":"p","content":"a"}]},{"tag":"section","content":[{"tag":"p","content":"b"}]}]`,
+			`[{"tag":"section","content":[{"tag":"p","content":"a"},{"tag":"p","content":"b"}]}]`},
+		{"merged tables", `[{"tag":"table","content":[{"tag":"tr","content":[{"tag":"td","content":"a"}]}]},{"tag":"table","content":[{"tag":"tr","content":[{"tag":"td","content":"b"}]}]}]`,
+			`[{"tag":"table","content":[{"tag":"tr","content":[{"tag":"td","content":"a"}]},{"tag":"tr","content":[{"tag":"td","content":"b"}]}]}]`},
+		{"merged lists", `[{"tag":"ul","content":[{"tag":"li","content":"a"}]},{"tag":"ul","content":[{"tag":"li","content":"b"}]}]`,
+			`[{"tag":"ul","content":[{"tag":"li","content":"a"},{"tag":"li","content":"b"}]}]`},
+		{"unwrapped blocks", `[{"tag":"td","content":[{"tag":"p","content":"a"},{"tag":"hr"},{"tag":"p","content":"b"}]}]`,
+			`[{"tag":"table","content":[{"tag":"tr","content":[{"tag":"td","content":[{"tag":"span","content":"a"},{"tag":"br"},{"tag":"span","content":"b"}]}]}]}]`},
+		{"nested list order", `[{"tag":"ol","content":[{"tag":"li","content":[{"tag":"ul","content":[{"tag":"li","content":"b"}]},{"tag":"span","content":"a"}]}]}]`,
+			`[{"tag":"ol","content":[{"tag":"li","content":[{"tag":"span","content":"a"},{"tag":"ul","content":[{"tag":"li","content":"b"}]}]}]}]`},
+		{"stray items", `[{"tag":"li","content":"a"},{"tag":"ol","content":[{"tag":"p","content":"b"}]}]`,
+			`[{"tag":"ul","content":[{"tag":"li","content":"a"}]},{"tag":"ol","content":[{"tag":"li","content":"b"}]}]`},
+		{"stray cells", `[{"tag":"table","content":[{"tag":"p","content":"a"},{"tag":"tr","content":[{"tag":"span","content":"b"}]}]}]`,
+			`[{"tag":"table","content":[{"tag":"tr","content":[{"tag":"td","content":"a"}]},{"tag":"tr","content":[{"tag":"td","content":"b"}]}]}]`},
+		{"empty blocks", `[{"tag":"ul"},{"tag":"table","content":[{"tag":"tr"}]},{"tag":"p","content":"a"}]`, `[{"tag":"p","content":"a"}]`},
+		{"dropped properties", `[{"tag":"p","lang":"de","content":"a"},{"tag":"ul","start":2,"content":[{"tag":"li","content":"b"}]},{"tag":"hr","content":"x"}]`,
+			`[{"tag":"p","content":"a"},{"tag":"ul","content":[{"tag":"li","content":"b"}]},{"tag":"hr"}]`},
+		{"top-level inline", `[{"tag":"strong","content":"a"},{"tag":"math","mode":"display","content":"x"}]`,
+			`[{"tag":"strong","content":"a"},{"tag":"math","mode":"display","content":"x"}]`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := ToNodes(FromNodes(parseNodes(t, c.in)))
+			if err != nil {
+				t.Fatalf("ToNodes: %v", err)
+			}
+			if got, want := compactJSON(t, got), compactJSON(t, parseNodes(t, c.want)); got != want {
+				t.Fatalf("got %s\nwant %s", got, want)
+			}
+		})
+	}
+}
+
+// TestQuillDelta reads a Delta as written by Quill's own formats.
+func TestQuillDelta(t *testing.T) {
+	const in = `{"ops":[
+		{"insert":"Title"},{"insert":"\n","attributes":{"header":6}},
+		{"insert":"quoted","attributes":{"link":"https://example.com","color":"red"}},{"insert":"\n","attributes":{"blockquote":true,"align":"center"}},
+		{"insert":"todo"},{"insert":"\n","attributes":{"list":"checked"}},
+		{"insert":{"video":"https://example.com/v"}},{"insert":"tail"}
+	]}`
+	const want = `[{"tag":"h5","content":"Title"},{"tag":"blockquote","content":"quoted"},{"tag":"ul","content":[{"tag":"li","content":"todo"}]},{"tag":"p","content":"tail"}]`
+	var d Delta
+	if err := json.Unmarshal([]byte(in), &d); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	got, err := ToNodes(d)
+	if err != nil {
+		t.Fatalf("ToNodes: %v", err)
+	}
+	if got, want := compactJSON(t, got), compactJSON(t, parseNodes(t, want)); got != want {
+		t.Fatalf("got %s\nwant %s", got, want)
+	}
+}
+
+// TestToNodesErrors rejects Deltas that are not documents.
+func TestToNodesErrors(t *testing.T) {
+	for _, d := range []Delta{
+		{Ops: []Op{{Retain: 3}}},
+		{Ops: []Op{{Insert: "a"}, {Delete: 1}}},
+		{Ops: []Op{{Insert: 42.0}}},
+		{Ops: []Op{{}}},
+	} {
+		if _, err := ToNodes(d); !errors.Is(err, ErrNotDocument) {
+			t.Fatalf("expected ErrNotDocument for %+v, got %v", d, err)
+		}
+	}
+}
@@ -0,0 +1,402 @@
+// Copyright (c) 2025 blog-writer authors
+package delta
+
+import (
+	"maps"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"blog-writer/pkg/article"
+)
+
+// FromNodes converts a document tree to a Delta. See the package
+// documentation for the mapping and its lossy cases.
+func FromNodes(nodes []article.Node) Delta {
+	e := &encoder{}
+	e.blocks(nodes, nil)
+	return Delta{Ops: e.ops()}
+}
+
+// encoder collects the lines of a Delta.
+type encoder struct {
+	lines []line
+	// rows counts the table rows encoded so far.
+	rows int
+}
+
+// line appends a line with runs and the block attributes attrs, adding the
+// container path.
+func (e *encoder) line(runs []run, attrs map[string]any, path []string) {
+	if len(path) > 0 {
+		attrs[attrContainer] = strings.Join(path, "/")
+	}
+	e.lines = append(e.lines, line{runs: runs, attrs: attrs})
+}
+
+// ops encodes the lines, merging adjacent text with equal attributes as
+// Quill does.
+func (e *encoder) ops() []Op {
+	ops := []Op{}
+	add := func(insert any, attrs map[string]any) {
+		if len(attrs) == 0 {
+			attrs = nil
+		}
+		if s, ok := insert.(string); ok && len(ops) > 0 {
+			last := &ops[len(ops)-1]
+			if prev, ok := last.Insert.(string); ok && reflect.DeepEqual(last.Attributes, attrs) {
+				last.Insert = prev + s
+				return
+			}
+		}
+		ops = append(ops, Op{Insert: insert, Attributes: attrs})
+	}
+	for _, l := range e.lines {
+		for _, r := range l.runs {
+			if r.embed != nil {
+				add(r.embed, r.attrs)
+			} else {
+				add(r.text, r.attrs)
+			}
+		}
+		add("\n", l.attrs)
+	}
+	return ops
+}
+
+// blocks encodes nodes inside the containers of path. Consecutive inline
+// nodes form one bare line; unknown elements are unwrapped.
+func (e *encoder) blocks(nodes []article.Node, path []string) {
+	var in inliner
+	flush := func() {
+		if len(in.runs) > 0 {
+			e.line(in.runs, map[string]any{attrBare: true}, path)
+		}
+		in = inliner{}
+	}
+	var visit func(nodes []article.Node)
+	visit = func(nodes []article.Node) {
+		for _, n := range nodes {
+			switch {
+			case isInline(n.Tag):
+				in.node(n, nil)
+			case !blockTags[n.Tag]:
+				visit(children(n))
+			case !isEmptyBlock(n):
+				flush()
+				e.block(n, path)
+			}
+		}
+	}
+	visit(nodes)
+	flush()
+}
+
+// blockTags are the block elements of the schema.
+var blockTags = map[string]bool{
+	"p": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true,
+	"figcaption": true, "pre": true, "ol": true, "ul": true, "li": true,
+	"table": true, "tr": true, "th": true, "td": true, "hr": true,
+	"blockquote": true, "header": true, "footer": true, "main": true, "section": true,
+	"article": true, "aside": true, "nav": true, "figure": true,
+}
+
+// block encodes a block node.
+func (e *encoder) block(n article.Node, path []string) {
+	switch n.Tag {
+	case "p":
+		e.text(n, map[string]any{}, path)
+	case "h1", "h2", "h3", "h4", "h5":
+		e.text(n, map[string]any{attrHeader: int(n.Tag[1] - '0')}, path)
+	case "figcaption":
+		e.text(n, map[string]any{attrFigcaption: true}, path)
+	case "pre":
+		e.pre(n, path)
+	case "ol", "ul":
+		e.list(n, 0, path)
+	case "li":
+		e.list(article.Node{Tag: "ul", Content: article.Children(n)}, 0, path)
+	case "table":
+		e.table(n, path)
+	case "tr", "th", "td":
+		e.table(article.Node{Tag: "table", Content: article.Children(n)}, path)
+	case "hr":
+		e.line([]run{{embed: map[string]any{embedDivider: true}}}, map[string]any{}, path)
+	default:
+		path = append(path[:len(path):len(path)], n.Tag)
+		before := len(e.lines)
+		e.blocks(children(n), path)
+		if len(e.lines) == before {
+			e.line(nil, map[string]any{attrBare: true}, path)
+		}
+	}
+}
+
+// text encodes a block holding inline content as one line.
+func (e *encoder) text(n article.Node, attrs map[string]any, path []string) {
+	var in inliner
+	in.nodes(children(n), nil)
+	e.line(in.runs, attrs, path)
+}
+
+// pre encodes a pre block as one code-block line per line of text.
+func (e *encoder) pre(n article.Node, path []string) {
+	in := inliner{pre: true}
+	in.nodes(children(n), nil)
+	var lang any = true
+	if n.Lang != "" {
+		lang = n.Lang
+	}
+	var cur []run
+	for _, r := range in.runs {
+		if r.embed != nil {
+			cur = append(cur, r)
+			continue
+		}
+		for i, part := range strings.Split(r.text, "\n") {
+			if i > 0 {
+				e.line(cur, map[string]any{attrCodeBlock: lang}, path)
+				cur = nil
+			}
+			if part != "" {
+				cur = append(cur, run{text: part, attrs: r.attrs})
+			}
+		}
+	}
+	e.line(cur, map[string]any{attrCodeBlock: lang}, path)
+}
+
+// list encodes an ol or ul at the given nesting depth, one line per item
+// followed by the item's nested lists.
+func (e *encoder) list(n article.Node, indent int, path []string) {
+	kind := "bullet"
+	if n.Tag == "ol" {
+		kind = "ordered"
+	}
+	for i, item := range children(n) {
+		attrs := map[string]any{attrList: kind}
+		if indent > 0 {
+			attrs[attrIndent] = indent
+		}
+		if i == 0 && n.Tag == "ol" && n.Start != nil && *n.Start >= 1 {
+			attrs[attrStart] = *n.Start
+		}
+		if item.Tag != "li" {
+			item = article.Node{Tag: "li", Content: article.Children(item)}
+		}
+		var in inliner
+		var nested []article.Node
+		for _, c := range children(item) {
+			if c.Tag == "ol" || c.Tag == "ul" {
+				nested = append(nested, c)
+			} else {
+				in.node(c, nil)
+			}
+		}
+		e.line(in.runs, attrs, path)
+		for _, l := range nested {
+			e.list(l, indent+1, path)
+		}
+	}
+}
+
+// table encodes a table as one line per cell, tagged with its row.
+func (e *encoder) table(n article.Node, path []string) {
+	for _, c := range children(n) {
+		cells := rowCells(c)
+		if len(cells) == 0 {
+			continue
+		}
+		e.rows++
+		id := "row-" + strconv.Itoa(e.rows)
+		for _, cell := range cells {
+			attrs := map[string]any{attrTable: id}
+			switch cell.Tag {
+			case "th":
+				attrs[attrCell] = "th"
+			case "td":
+			default:
+				cell = article.Node{Tag: "td", Content: article.Children(cell)}
+			}
+			e.text(cell, attrs, path)
+		}
+	}
+}
+
+// rowCells returns the cells of a table child: the children of a tr, or
+// the node itself otherwise.
+func rowCells(n article.Node) []article.Node {
+	if n.Tag == "tr" {
+		return children(n)
+	}
+	return []article.Node{n}
+}
+
+// isEmptyBlock reports whether n encodes to no lines at all.
+func isEmptyBlock(n article.Node) bool {
+	switch n.Tag {
+	case "ol", "ul", "tr":
+		return len(children(n)) == 0
+	case "table":
+		for _, c := range children(n) {
+			if len(rowCells(c)) > 0 {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// inliner flattens inline nodes into runs.
+type inliner struct {
+	runs []run
+	// pre keeps newlines in text instead of turning them into breaks.
+	pre bool
+	// brk is set when a break is due before the next run, after an
+	// unwrapped block.
+	brk bool
+}
+
+// add appends r, preceded by a pending break.
+func (in *inliner) add(r run) {
+	if in.brk && len(in.runs) > 0 {
+		in.runs = append(in.runs, run{embed: map[string]any{embedBreak: true}})
+	}
+	in.brk = false
+	in.runs = append(in.runs, r)
+}
+
+// nodes flattens nodes with the inline attributes attrs.
+func (in *inliner) nodes(nodes []article.Node, attrs map[string]any) {
+	for _, n := range nodes {
+		in.node(n, attrs)
+	}
+}
+
+// node flattens n with the inline attributes of its ancestors.
+func (in *inliner) node(n article.Node, attrs map[string]any) {
+	switch n.Tag {
+	case "br":
+		in.add(run{embed: map[string]any{embedBreak: true}, attrs: attrs})
+	case "img":
+		a := maps.Clone(attrs)
+		if n.Alt != "" {
+			a = with(a, attrAlt, n.Alt)
+		}
+		in.add(run{embed: map[string]any{embedImage: n.URL}, attrs: a})
+	case "math":
+		a := maps.Clone(attrs)
+		if n.Mode == "display" {
+			a = with(a, attrMode, "display")
+		}
+		if n.Numbered != nil {
+			a = with(a, attrNumbered, *n.Numbered)
+		}
+		if n.Label != "" {
+			a = with(a, attrLabel, n.Label)
+		}
+		in.add(run{embed: map[string]any{embedFormula: plainText(children(n))}, attrs: a})
+	default:
+		if isInline(n.Tag) {
+			attrs = format(attrs, n)
+			if n.Content.IsText() {
+				in.text(*n.Content.Text, attrs)
+				return
+			}
+			in.nodes(children(n), attrs)
+			return
+		}
+		// A block inside inline content is unwrapped between breaks.
+		in.brk = in.brk || len(in.runs) > 0
+		in.nodes(children(n), attrs)
+		in.brk = in.brk || len(in.runs) > 0
+	}
+}
+
+// text adds the string s, turning newlines into breaks outside pre.
+func (in *inliner) text(s string, attrs map[string]any) {
+	if in.pre {
+		if s != "" {
+			in.add(run{text: s, attrs: attrs})
+		}
+		return
+	}
+	for i, part := range strings.Split(s, "\n") {
+		if i > 0 {
+			in.add(run{embed: map[string]any{embedBreak: true}, attrs: attrs})
+		}
+		if part != "" {
+			in.add(run{text: part, attrs: attrs})
+		}
+	}
+}
+
+// format returns attrs extended with the attribute of the inline element n.
+func format(attrs map[string]any, n article.Node) map[string]any {
+	switch n.Tag {
+	case "span":
+		if n.Lang != "" {
+			return with(attrs, attrLang, n.Lang)
+		}
+		return attrs
+	case "time":
+		if n.Datetime != "" {
+			return with(attrs, attrTime, n.Datetime)
+		}
+		return with(attrs, attrTime, true)
+	case "sub":
+		return with(attrs, attrScript, "sub")
+	case "sup":
+		return with(attrs, attrScript, "super")
+	}
+	return with(attrs, inlineAttrs[n.Tag], true)
+}
+
+// inlineAttrs maps boolean inline elements to their attribute.
+var inlineAttrs = map[string]string{
+	"strong": attrBold, "b": attrB, "em": attrItalic, "i": attrI, "u": attrUnderline,
+	"s": attrStrike, "code": attrCode, "mark": attrMark, "small": attrSmall,
+}
+
+// isInline reports whether tag is an inline element or embed.
+func isInline(tag string) bool {
+	switch tag {
+	case "span", "time", "sub", "sup", "br", "img", "math":
+		return true
+	}
+	return inlineAttrs[tag] != ""
+}
+
+// with returns a copy of attrs with key set to v.
+func with(attrs map[string]any, key string, v any) map[string]any {
+	out := maps.Clone(attrs)
+	if out == nil {
+		out = map[string]any{}
+	}
+	out[key] = v
+	return out
+}
+
+// children returns the content of n as nodes; text becomes a span.
+func children(n article.Node) []article.Node {
+	switch {
+	case n.Content == nil:
+		return nil
+	case n.Content.IsText():
+		return []article.Node{{Tag: "span", Content: n.Content}}
+	}
+	return n.Content.Nodes
+}
+
+// plainText concatenates the text in nodes.
+func plainText(nodes []article.Node) string {
+	var b strings.Builder
+	article.Walk(nodes, func(n *article.Node, _ int) bool {
+		if n.Content.IsText() {
+			b.WriteString(*n.Content.Text)
+		}
+		return true
+	})
+	return b.String()
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2025 blog-writer authors
+package delta
+
+import (
+	"encoding/json"
+	"math/rand"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"blog-writer/pkg/article"
+)
+
+// quickConfig runs enough cases to reach the rare nestings.
+var quickConfig = &quick.Config{MaxCount: 2000}
+
+// allTags lists every tag of article.schema.json.
+var allTags = []string{
+	"h1", "h2", "h3", "h4", "h5", "p", "span",
+	"b", "i", "u", "strong", "em", "code", "sub", "sup", "s", "mark", "small",
+	"br", "math", "img",
+	"blockquote", "ol", "ul", "li", "pre", "hr",
+	"table", "tr", "th", "td",
+	"header", "footer", "main", "section", "article", "aside", "nav", "figure", "figcaption", "time",
+}
+
+// testURL is a valid image URL.
+const testURL = "data:image/svg+xml;base64,PHN2Zy8+"
+
+// randText returns short text, sometimes empty or with newlines.
+func randText(r *rand.Rand) string {
+	const alphabet = "ab \n"
+	b := make([]byte, r.Intn(4))
+	for i := range b {
+		b[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(b)
+}
+
+// randNodes returns up to four schema-valid nodes of any tag, nested up to
+// depth levels.
+func randNodes(r *rand.Rand, depth int) []article.Node {
+	nodes := make([]article.Node, r.Intn(4))
+	for i := range nodes {
+		nodes[i] = randNode(r, depth)
+	}
+	return nodes
+}
+
+// randNode returns a schema-valid node of a random tag with random
+// properties and content.
+func randNode(r *rand.Rand, depth int) article.Node {
+	n := article.Node{Tag: allTags[r.Intn(len(allTags))]}
+	switch n.Tag {
+	case "br", "hr":
+		return n
+	case "img":
+		n.URL = testURL
+		if r.Intn(2) == 0 {
+			n.Alt = "alt"
+		}
+		return n
+	case "math":
+		n.Mode = []string{"inline", "display"}[r.Intn(2)]
+		n.Content = article.Text("x^" + randText(r))
+		if r.Intn(2) == 0 {
+			numbered := r.Intn(2) == 0
+			n.Numbered = &numbered
+		}
+		if r.Intn(2) == 0 {
+			n.Label = "eq"
+		}
+		return n
+	case "ol":
+		if r.Intn(2) == 0 {
+			start := 1 + r.Intn(3)
+			n.Start = &start
+		}
+	case "pre", "span":
+		if r.Intn(2) == 0 {
+			n.Lang = []string{"go", "en"}[r.Intn(2)]
+		}
+	case "time":
+		if r.Intn(2) == 0 {
+			n.Datetime = "2025-01-01"
+		}
+	}
+	switch c := r.Intn(3); {
+	case c == 0 || depth == 0:
+		n.Content = article.Text(randText(r))
+	case c == 1:
+		n.Content = article.Children(randNodes(r, depth-1)...)
+	}
+	return n
+}
+
+// tree is a random document for testing/quick.
+type tree []article.Node
+
+// Generate implements quick.Generator.
+func (tree) Generate(r *rand.Rand, _ int) reflect.Value {
+	return reflect.ValueOf(tree(randNodes(r, 4)))
+}
+
+// quillDelta is a random document Delta using Quill's formats and ours.
+type quillDelta Delta
+
+// Generate implements quick.Generator.
+func (quillDelta) Generate(r *rand.Rand, _ int) reflect.Value {
+	pick := func(values ...any) any { return values[r.Intn(len(values))] }
+	inline := []map[string]any{
+		nil, {"bold": true}, {"italic": true, "underline": true}, {"script": "sub"},
+		{"code": true, "strike": true}, {"lang": "fr", "mark": true}, {"time": true},
+		{"link": "https://example.com"}, {"b": true, "small": true, "i": true},
+	}
+	block := []map[string]any{
+		nil, {"header": 2.0}, {"header": 6.0}, {"code-block": true}, {"code-block": "go"},
+		{"list": "bullet"}, {"list": "ordered", "indent": 2.0}, {"list": "ordered", "start": 4.0},
+		{"list": "checked"}, {"table": "row-1"}, {"table": "row-2", "cell": "th"},
+		{"blockquote": true}, {"figcaption": true}, {"bare": true, "container": "section"},
+		{"container": "article/aside"}, {"container": "div/nav", "align": "center"},
+	}
+	var d Delta
+	for i := r.Intn(12); i > 0; i-- {
+		switch r.Intn(4) {
+		case 0:
+			d.Ops = append(d.Ops, Op{Insert: "\n", Attributes: block[r.Intn(len(block))]})
+		case 1:
+			d.Ops = append(d.Ops, Op{Insert: pick(
+				map[string]any{"image": testURL}, map[string]any{"formula": "x"},
+				map[string]any{"br": true}, map[string]any{"divider": true}, map[string]any{"video": "v"},
+			), Attributes: inline[r.Intn(len(inline))]})
+		default:
+			d.Ops = append(d.Ops, Op{Insert: randText(r), Attributes: inline[r.Intn(len(inline))]})
+		}
+	}
+	return reflect.ValueOf(quillDelta(d))
+}
+
+// toNodes converts d, failing the test on error.
+func toNodes(t *testing.T, d Delta) []article.Node {
+	t.Helper()
+	nodes, err := ToNodes(d)
+	if err != nil {
+		t.Fatalf("ToNodes: %v", err)
+	}
+	return nodes
+}
+
+// validate checks nodes against the article schema.
+func validate(nodes []article.Node) error {
+	return article.ValidateArticle(article.Article{
+		Version: article.CurrentVersion,
+		Metadata: article.Metadata{
+			Title: "t", Author: "a",
+			PublicationDate: "2025-01-01T00:00:00Z", UpdatedDate: "2025-01-01T00:00:00Z",
+		},
+		Document: nodes,
+	})
+}
+
+// TestDeltaRoundTrip checks that Deltas produced by FromNodes survive a
+// round trip through nodes unchanged.
+func TestDeltaRoundTrip(t *testing.T) {
+	f := func(in tree) bool {
+		d := FromNodes(in)
+		got := FromNodes(toNodes(t, d))
+		if !reflect.DeepEqual(got, d) {
+			t.Logf("input %s\nfirst %s\nagain %s", compactJSON(t, []article.Node(in)), compactJSON(t, d), compactJSON(t, got))
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestNodesRoundTrip checks that normalized trees survive a round trip
+// through a Delta, including its JSON encoding, unchanged and stay valid.
+func TestNodesRoundTrip(t *testing.T) {
+	f := func(in tree) bool {
+		if err := validate(in); err != nil {
+			t.Logf("generator produced an invalid tree: %v", err)
+			return false
+		}
+		norm := toNodes(t, FromNodes(in))
+		b, err := json.Marshal(FromNodes(norm))
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		var d Delta
+		if err := json.Unmarshal(b, &d); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		got := toNodes(t, d)
+		if compactJSON(t, got) != compactJSON(t, norm) {
+			t.Logf("input %s\nfirst %s\nagain %s", compactJSON(t, []article.Node(in)), compactJSON(t, norm), compactJSON(t, got))
+			return false
+		}
+		if err := validate(got); err != nil {
+			t.Logf("invalid output %s: %v", compactJSON(t, got), err)
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestTextPreserved checks that no text is lost. List items may reorder
+// text, so the characters are compared as a multiset.
+func TestTextPreserved(t *testing.T) {
+	chars := func(nodes []article.Node) string {
+		s := []rune(strings.ReplaceAll(plainText(nodes), "\n", ""))
+		slices.Sort(s)
+		return string(s)
+	}
+	f := func(in tree) bool {
+		return chars(in) == chars(toNodes(t, FromNodes(in)))
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestQuillRoundTrip checks that arbitrary Quill Deltas convert to valid
+// trees that are normalized after one conversion.
+func TestQuillRoundTrip(t *testing.T) {
+	f := func(in quillDelta) bool {
+		nodes := toNodes(t, Delta(in))
+		if err := validate(nodes); err != nil {
+			t.Logf("invalid output %s: %v", compactJSON(t, nodes), err)
+			return false
+		}
+		d := FromNodes(nodes)
+		if got := FromNodes(toNodes(t, d)); !reflect.DeepEqual(got, d) {
+			t.Logf("input %s\nfirst %s\nagain %s", compactJSON(t, Delta(in)), compactJSON(t, d), compactJSON(t, got))
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(f, quickConfig); err != nil {
+		t.Fatal(err)
+	}
+}
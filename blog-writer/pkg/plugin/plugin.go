@@ -0,0 +1,194 @@
+// Copyright (c) 2025 blog-writer authors
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Handler signatures for each capability kind.
+type (
+	ExportFunc  func(context.Context, ExportRequest) (ExportResult, error)
+	LintFunc    func(context.Context, LintRequest) (LintResult, error)
+	ImportFunc  func(context.Context, ImportRequest) (ImportResult, error)
+	CommandFunc func(context.Context, CommandRequest) (CommandResult, error)
+)
+
+// Plugin serves registered capabilities to the app.
+type Plugin struct {
+	manifest Manifest
+	handlers map[Kind]map[string]any
+
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// New returns a Plugin named name at version with no capabilities.
+func New(name, version string) *Plugin {
+	return &Plugin{
+		manifest: Manifest{Name: name, Version: version, ProtocolVersion: ProtocolVersion, Capabilities: []Capability{}},
+		handlers: map[Kind]map[string]any{},
+	}
+}
+
+// Exporter registers fn as the exporter c.
+func (p *Plugin) Exporter(c Capability, fn ExportFunc) { p.register(KindExporter, c, fn) }
+
+// Linter registers fn as the linter c.
+func (p *Plugin) Linter(c Capability, fn LintFunc) { p.register(KindLinter, c, fn) }
+
+// Importer registers fn as the importer c.
+func (p *Plugin) Importer(c Capability, fn ImportFunc) { p.register(KindImporter, c, fn) }
+
+// Command registers fn as the command c.
+func (p *Plugin) Command(c Capability, fn CommandFunc) { p.register(KindCommand, c, fn) }
+
+// register records fn as capability c of kind.
+func (p *Plugin) register(kind Kind, c Capability, fn any) {
+	c.Kind = kind
+	if p.handlers[kind] == nil {
+		p.handlers[kind] = map[string]any{}
+	}
+	p.handlers[kind][c.ID] = fn
+	p.manifest.Capabilities = append(p.manifest.Capabilities, c)
+}
+
+// Manifest returns the plugin's manifest.
+func (p *Plugin) Manifest() Manifest {
+	return p.manifest
+}
+
+// Run serves the app over stdin and stdout until it shuts the plugin down.
+func (p *Plugin) Run() error {
+	return p.Serve(context.Background(), os.Stdin, os.Stdout)
+}
+
+// Serve reads requests from r and writes responses to w, one at a time,
+// until a shutdown notification, the end of r or the cancellation of ctx.
+// Handler panics are reported to the app as errors.
+func (p *Plugin) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	p.mu.Lock()
+	p.enc = json.NewEncoder(w)
+	p.mu.Unlock()
+	br := bufio.NewReader(r)
+	for ctx.Err() == nil {
+		line, err := br.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			var m Message
+			if jerr := json.Unmarshal(line, &m); jerr != nil {
+				p.send(Message{Error: &Error{Code: CodeParseError, Message: jerr.Error()}})
+			} else if m.Method == MethodShutdown {
+				return nil
+			} else if m.ID != nil {
+				p.send(p.handle(ctx, m))
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+// Logf sends a log notification to the app. It does nothing before Serve.
+func (p *Plugin) Logf(level, format string, args ...any) {
+	params, _ := json.Marshal(LogParams{Level: level, Message: fmt.Sprintf(format, args...)})
+	p.send(Message{Method: MethodLog, Params: params})
+}
+
+// send writes m to the app.
+func (p *Plugin) send(m Message) {
+	m.JSONRPC = "2.0"
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.enc != nil {
+		_ = p.enc.Encode(m)
+	}
+}
+
+// handle executes request m and returns its response.
+func (p *Plugin) handle(ctx context.Context, m Message) (resp Message) {
+	resp = Message{ID: m.ID}
+	defer func() {
+		if r := recover(); r != nil {
+			resp.Result, resp.Error = nil, &Error{Code: CodeInternal, Message: fmt.Sprintf("panic: %v", r)}
+		}
+	}()
+	result, err := p.dispatch(ctx, m.Method, m.Params)
+	if err != nil {
+		var perr *Error
+		if !errors.As(err, &perr) {
+			perr = &Error{Code: CodeFailed, Message: err.Error()}
+		}
+		resp.Error = perr
+		return resp
+	}
+	b, err := json.Marshal(result)
+	if err != nil {
+		resp.Error = &Error{Code: CodeInternal, Message: err.Error()}
+		return resp
+	}
+	resp.Result = b
+	return resp
+}
+
+// dispatch decodes params for method and calls the matching handler.
+func (p *Plugin) dispatch(ctx context.Context, method string, params json.RawMessage) (any, error) {
+	switch method {
+	case MethodInitialize:
+		return p.manifest, nil
+	case MethodExport:
+		var req ExportRequest
+		fn, err := lookup[ExportFunc](p, KindExporter, params, &req, &req.Capability)
+		if err != nil {
+			return nil, err
+		}
+		return fn(ctx, req)
+	case MethodLint:
+		var req LintRequest
+		fn, err := lookup[LintFunc](p, KindLinter, params, &req, &req.Capability)
+		if err != nil {
+			return nil, err
+		}
+		return fn(ctx, req)
+	case MethodImport:
+		var req ImportRequest
+		fn, err := lookup[ImportFunc](p, KindImporter, params, &req, &req.Capability)
+		if err != nil {
+			return nil, err
+		}
+		return fn(ctx, req)
+	case MethodCommand:
+		var req CommandRequest
+		fn, err := lookup[CommandFunc](p, KindCommand, params, &req, &req.Capability)
+		if err != nil {
+			return nil, err
+		}
+		return fn(ctx, req)
+	}
+	return nil, &Error{Code: CodeMethodNotFound, Message: "unknown method " + method}
+}
+
+// lookup decodes params into req and returns the handler of kind named by
+// the decoded capability.
+func lookup[F any](p *Plugin, kind Kind, params json.RawMessage, req any, capability *string) (F, error) {
+	var fn F
+	if err := json.Unmarshal(params, req); err != nil {
+		return fn, &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	h, ok := p.handlers[kind][*capability].(F)
+	if !ok {
+		return fn, &Error{Code: CodeInvalidParams, Message: fmt.Sprintf("unknown %s %q", kind, *capability)}
+	}
+	return h, nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025 blog-writer authors
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// serve runs p over the newline-separated requests in input and returns the
+// decoded messages it wrote.
+func serve(t *testing.T, p *Plugin, input ...string) []Message {
+	t.Helper()
+	var out bytes.Buffer
+	if err := p.Serve(context.Background(), strings.NewReader(strings.Join(input, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("Serve: %v", err)
+	}
+	var msgs []Message
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var m Message
+		if err := dec.Decode(&m); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		msgs = append(msgs, m)
+	}
+	return msgs
+}
+
+// TestServe covers initialization, dispatch, errors, panics and logging.
+func TestServe(t *testing.T) {
+	p := New("demo", "1.2.3")
+	p.Linter(Capability{ID: "title", Name: "Title"}, func(_ context.Context, req LintRequest) (LintResult, error) {
+		if req.Article.Metadata.Title == "" {
+			p.Logf("info", "empty title in %s", req.ID)
+			return LintResult{Diagnostics: []Diagnostic{{Path: "/metadata/title", Severity: SeverityError, Message: "missing title"}}}, nil
+		}
+		return LintResult{}, nil
+	})
+	p.Command(Capability{ID: "fail"}, func(context.Context, CommandRequest) (CommandResult, error) {
+		return CommandResult{}, errors.New("nope")
+	})
+	p.Exporter(Capability{ID: "panic", Extension: ".txt"}, func(context.Context, ExportRequest) (ExportResult, error) {
+		panic("boom")
+	})
+	msgs := serve(t, p,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":1}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"lint","params":{"capability":"title","id":"1","article":{"metadata":{},"document":[]}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"command","params":{"capability":"fail"}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"export","params":{"capability":"panic"}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"lint","params":{"capability":"other"}}`,
+		`{"jsonrpc":"2.0","id":6,"method":"nope"}`,
+		`{"jsonrpc":"2.0","method":"shutdown"}`,
+		`{"jsonrpc":"2.0","id":7,"method":"initialize"}`,
+	)
+	if len(msgs) != 7 {
+		t.Fatalf("expected 7 messages, got %d: %+v", len(msgs), msgs)
+	}
+	var m Manifest
+	if err := json.Unmarshal(msgs[0].Result, &m); err != nil || m.Name != "demo" || len(m.Capabilities) != 3 || m.Capabilities[0].Kind != KindLinter {
+		t.Fatalf("unexpected manifest %s: %v", msgs[0].Result, err)
+	}
+	if msgs[1].Method != MethodLog || !strings.Contains(string(msgs[1].Params), "empty title in 1") {
+		t.Fatalf("expected log notification, got %+v", msgs[1])
+	}
+	var lint LintResult
+	if err := json.Unmarshal(msgs[2].Result, &lint); err != nil || len(lint.Diagnostics) != 1 {
+		t.Fatalf("unexpected lint result %s: %v", msgs[2].Result, err)
+	}
+	wantCodes := []int{CodeFailed, CodeInternal, CodeInvalidParams, CodeMethodNotFound}
+	for i, code := range wantCodes {
+		if e := msgs[3+i].Error; e == nil || e.Code != code {
+			t.Errorf("message %d: expected code %d, got %+v", 3+i, code, e)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2025 blog-writer authors
+
+// Package plugin is the SDK for Blog-Writer plugins and defines the protocol
+// the app speaks with them.
+//
+// A plugin is an executable placed in a repository's .blog-writer/plugins
+// directory or in the plugins directory next to the user's config.yml. The
+// app starts it with the repository as working directory and exchanges
+// newline-delimited JSON-RPC 2.0 messages over stdin and stdout; stderr is
+// copied to the app log. The app first calls "initialize", to which the
+// plugin answers with its Manifest, then "export", "lint", "import" or
+// "command" for the capabilities the manifest declares, and finally sends
+// a "shutdown" notification. Plugins may send "log" notifications at any
+// time.
+//
+// Most plugins only need New, one registration per capability and Run:
+//
+//	func main() {
+//		p := plugin.New("wordcount", "1.0.0")
+//		p.Linter(plugin.Capability{ID: "length", Name: "Article length"}, lintLength)
+//		if err := p.Run(); err != nil {
+//			os.Exit(1)
+//		}
+//	}
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"blog-writer/pkg/article"
+)
+
+// ProtocolVersion is the version of the protocol defined here. The app
+// refuses plugins reporting a different version.
+const ProtocolVersion = 1
+
+// EnvProtocol is set in a plugin's environment to the app's ProtocolVersion.
+const EnvProtocol = "BLOG_WRITER_PLUGIN_PROTOCOL"
+
+// Methods of the protocol.
+const (
+	// MethodInitialize is the first request; params are InitializeParams
+	// and the result is a Manifest.
+	MethodInitialize = "initialize"
+	// MethodExport converts an article; ExportRequest → ExportResult.
+	MethodExport = "export"
+	// MethodLint checks an article; LintRequest → LintResult.
+	MethodLint = "lint"
+	// MethodImport creates an article from a file; ImportRequest →
+	// ImportResult.
+	MethodImport = "import"
+	// MethodCommand runs a custom action; CommandRequest → CommandResult.
+	MethodCommand = "command"
+	// MethodShutdown is a notification asking the plugin to exit.
+	MethodShutdown = "shutdown"
+	// MethodLog is a notification from the plugin; params are LogParams.
+	MethodLog = "log"
+)
+
+// Article model types exchanged with plugins.
+type (
+	Article  = article.Article
+	Metadata = article.Metadata
+	Node     = article.Node
+	Content  = article.Content
+)
+
+// Kind is the type of a capability.
+type Kind string
+
+// Capability kinds.
+const (
+	// KindExporter converts an article into another format.
+	KindExporter Kind = "exporter"
+	// KindLinter reports problems in an article.
+	KindLinter Kind = "linter"
+	// KindImporter creates an article from a file.
+	KindImporter Kind = "importer"
+	// KindCommand is a custom action on an article or repository.
+	KindCommand Kind = "command"
+)
+
+// Capability is a feature offered by a plugin. The app refers to it as
+// "<plugin>/<id>", where <plugin> is the executable's name without
+// extension.
+type Capability struct {
+	Kind        Kind   `json:"kind"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Extension is the file extension produced by an exporter, e.g. ".md".
+	Extension string `json:"extension,omitempty"`
+	// Extensions lists the file extensions an importer accepts.
+	Extensions []string `json:"extensions,omitempty"`
+}
+
+// Manifest describes a plugin and its capabilities.
+type Manifest struct {
+	Name            string       `json:"name"`
+	Version         string       `json:"version"`
+	ProtocolVersion int          `json:"protocolVersion"`
+	Capabilities    []Capability `json:"capabilities"`
+}
+
+// InitializeParams are the parameters of MethodInitialize.
+type InitializeParams struct {
+	ProtocolVersion int    `json:"protocolVersion"`
+	HostVersion     string `json:"hostVersion"`
+}
+
+// ExportRequest asks an exporter to convert Article.
+type ExportRequest struct {
+	Capability string            `json:"capability"`
+	Repo       string            `json:"repo"`
+	ID         string            `json:"id"`
+	Article    Article           `json:"article"`
+	Options    map[string]string `json:"options,omitempty"`
+}
+
+// ExportResult is the converted article.
+type ExportResult struct {
+	Data      []byte `json:"data"`
+	MediaType string `json:"mediaType,omitempty"`
+}
+
+// LintRequest asks a linter to check Article.
+type LintRequest struct {
+	Capability string  `json:"capability"`
+	Repo       string  `json:"repo"`
+	ID         string  `json:"id"`
+	Article    Article `json:"article"`
+}
+
+// Severities of a Diagnostic.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Diagnostic is a problem reported by a linter.
+type Diagnostic struct {
+	// Path is the JSON pointer of the offending value, e.g. "/document/0".
+	Path     string `json:"path"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	// Source is set by the app to the capability reference.
+	Source string `json:"source,omitempty"`
+}
+
+// LintResult lists the problems found.
+type LintResult struct {
+	Diagnostics []Diagnostic `json:"diagnostics"`
+}
+
+// ImportRequest asks an importer to convert the file Name with contents Data.
+type ImportRequest struct {
+	Capability string `json:"capability"`
+	Repo       string `json:"repo"`
+	Name       string `json:"name"`
+	Data       []byte `json:"data"`
+}
+
+// ImportResult is the imported article.
+type ImportResult struct {
+	Article Article `json:"article"`
+}
+
+// CommandRequest runs a command, on Article when one is open.
+type CommandRequest struct {
+	Capability string   `json:"capability"`
+	Repo       string   `json:"repo"`
+	ID         string   `json:"id,omitempty"`
+	Article    *Article `json:"article,omitempty"`
+	Args       []string `json:"args,omitempty"`
+}
+
+// CommandResult is the outcome of a command. A non-nil Article replaces the
+// article the command ran on.
+type CommandResult struct {
+	Message string   `json:"message,omitempty"`
+	Article *Article `json:"article,omitempty"`
+}
+
+// LogParams are the parameters of a MethodLog notification. Level is
+// "debug", "info", "warn" or "error".
+type LogParams struct {
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+// Message is a JSON-RPC 2.0 message on the wire: a request when Method and
+// ID are set, a notification when only Method is set, and a response
+// otherwise.
+type Message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *int64          `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// JSON-RPC error codes used by the protocol.
+const (
+	CodeParseError     = -32700
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternal       = -32603
+	// CodeFailed reports an error returned by a capability handler.
+	CodeFailed = -32000
+)
+
+// Error is a JSON-RPC error returned by a plugin.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
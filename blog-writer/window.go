@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Asymmetric Effort, LLC. <scaldwell@asymmetric-effort.com>
+package main
+
+import (
+	"github.com/wailsapp/wails/v2/pkg/options"
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"blog-writer/internal/config"
+)
+
+// windowOptions returns the initial window size and start state from the
+// remembered geometry.
+func (a *App) windowOptions() (int, int, options.WindowStartState) {
+	cfg, _ := config.Load(a.cfgPath)
+	w, h := cfg.Window.Size()
+	if cfg.Window.Maximized {
+		return w, h, options.Maximised
+	}
+	return w, h, options.Normal
+}
+
+// restoreWindow moves and resizes the window to the remembered geometry,
+// clamped to the screens currently attached.
+func (a *App) restoreWindow() {
+	cfg, err := config.Load(a.cfgPath)
+	if err != nil || cfg.Window.Maximized {
+		return
+	}
+	all, err := runtime.ScreenGetAll(a.ctx)
+	if err != nil {
+		return
+	}
+	screens := make([]config.Screen, 0, len(all))
+	for _, s := range all {
+		screens = append(screens, config.Screen{Width: s.Size.Width, Height: s.Size.Height, Current: s.IsCurrent, Primary: s.IsPrimary})
+	}
+	win, ok := cfg.Window.Clamp(screens)
+	if !ok {
+		return
+	}
+	runtime.WindowSetSize(a.ctx, win.Width, win.Height)
+	runtime.WindowSetPosition(a.ctx, win.X, win.Y)
+}
+
+// saveWindow stores the window geometry in the user config. While the
+// window is maximized only the flag is updated so the normal geometry is
+// kept for the next un-maximize.
+func (a *App) saveWindow() {
+	err := config.Update(a.cfgPath, func(cfg *config.Config) error {
+		cfg.Window.Maximized = runtime.WindowIsMaximised(a.ctx)
+		if !cfg.Window.Maximized && !runtime.WindowIsMinimised(a.ctx) {
+			cfg.Window.X, cfg.Window.Y = runtime.WindowGetPosition(a.ctx)
+			cfg.Window.Width, cfg.Window.Height = runtime.WindowGetSize(a.ctx)
+			if all, err := runtime.ScreenGetAll(a.ctx); err == nil {
+				for _, s := range all {
+					if s.IsCurrent {
+						cfg.Window.ScreenWidth, cfg.Window.ScreenHeight = s.Size.Width, s.Size.Height
+					}
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("save window state", "err", err)
+	}
+}